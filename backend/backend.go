@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package backend
+
+import (
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Backend stores the collections, environments, execution history, logs and
+// configuration of a client. FileBackend keeps them in a directory tree,
+// MemoryBackend in memory and SQLiteBackend in a single database file.
+type Backend interface {
+	// LoadCollection loads a collection by name.
+	LoadCollection(name string) (*collections.Collection, error)
+	// SaveCollection creates or replaces a collection, stored under its name.
+	SaveCollection(collection *collections.Collection) error
+	// ListCollections returns the names of the stored collections.
+	ListCollections() ([]string, error)
+	// DeleteCollection deletes a collection by name.
+	DeleteCollection(name string) error
+
+	// LoadEnvironment loads an environment by name.
+	LoadEnvironment(name string) (*environment.Environment, error)
+	// SaveEnvironment creates or replaces an environment, stored under its name.
+	SaveEnvironment(env *environment.Environment) error
+	// ListEnvironments returns the names of the stored environments.
+	ListEnvironments() ([]string, error)
+	// DeleteEnvironment deletes an environment by name.
+	DeleteEnvironment(name string) error
+	// UpdateEnvironment loads an environment, or starts an empty one when it does not
+	// exist, applies update and saves the result. Updates of the same environment,
+	// from this or another process, do not interleave. update must not call the backend.
+	UpdateEnvironment(name string, update func(env *environment.Environment) error) error
+
+	// SaveHistory adds an execution result to the history.
+	SaveHistory(result *types.ExecutionResult) error
+	// LoadHistory loads an execution result by its history ID.
+	LoadHistory(id string) (*types.ExecutionResult, error)
+	// ListHistory returns the IDs of the history entries, most recent first.
+	ListHistory() ([]string, error)
+	// QueryHistory returns the history entries matching query, most recent first.
+	QueryHistory(query HistoryQuery) ([]HistoryEntry, error)
+	// PruneHistory removes the history entries outside policy and returns their number.
+	PruneHistory(policy RetentionPolicy) (int, error)
+	// ClearHistory removes all history entries.
+	ClearHistory() error
+
+	// SaveLogs stores a batch of log entries.
+	SaveLogs(logs []types.LogEntry) error
+	// LoadLogs loads a batch of log entries by its ID.
+	LoadLogs(id string) ([]types.LogEntry, error)
+	// ListLogs returns the IDs of the stored log batches, most recent first.
+	ListLogs() ([]string, error)
+
+	// LoadConfig returns the stored YAML configuration, or nil when there is none.
+	LoadConfig() ([]byte, error)
+	// SaveConfig stores the YAML configuration.
+	SaveConfig(data []byte) error
+
+	// Close releases the resources held by the backend.
+	Close() error
+}
+
+// Collection layouts of FileBackend. LayoutDirectory stores a collection as a
+// directory with a file per request, which keeps merges of shared collections simple.
+const (
+	LayoutFile      = "file"
+	LayoutDirectory = "directory"
+)
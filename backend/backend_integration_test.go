@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationSQLiteBackend_ExecuteAndRecord(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	sqliteBackend := newTestSQLiteBackend(t)
+
+	env := environment.NewEnvironment("test")
+	env.Set("base_url", http_server.GetServerURL())
+	if err := sqliteBackend.SaveEnvironment(env); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection := &collections.Collection{
+		Name:    "Test Collection",
+		EnvName: "test",
+		Items: []*types.RequestItem{
+			{
+				Name: "Health",
+				Request: &types.Request{
+					Method: http.MethodGet,
+					URL:    "{{base_url}}/health",
+				},
+			},
+		},
+	}
+	if err := sqliteBackend.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := sqliteBackend.LoadCollection("Test Collection")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loadedEnv, err := sqliteBackend.LoadEnvironment(loaded.EnvName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), loadedEnv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := collections.NewCollectionExecutor(core.NewHTTPClient(10*time.Second, 30*time.Second, false), resolver)
+	result, err := executor.ExecuteCollection(loaded, loadedEnv.Name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Statistics.Success != 1 {
+		t.Fatalf("expected success 1, got %d", result.Statistics.Success)
+	}
+
+	if err := sqliteBackend.SaveHistory(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := sqliteBackend.ListHistory()
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("expected 1 history entry, got %v (%v)", ids, err)
+	}
+
+	recorded, err := sqliteBackend.LoadHistory(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorded.Requests) != 1 || recorded.Requests[0].Response.StatusCode != http.StatusOK {
+		t.Errorf("unexpected recorded result: %+v", recorded)
+	}
+}
@@ -0,0 +1,419 @@
+package backend
+
+import (
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// The helpers below check the behavior every Backend implementation shares.
+
+func testBackendCollections(t *testing.T, b Backend, notFound bool) {
+	t.Helper()
+
+	collection := &collections.Collection{
+		Name:    "api",
+		EnvName: "dev",
+		Items: []*types.RequestItem{
+			{
+				Name: "Create",
+				Request: &types.Request{
+					Method: "POST",
+					URL:    "{{base_url}}/users",
+					Body: &types.RequestBody{
+						Type:    "application/json",
+						Content: []byte(`{"name": "test"}`),
+					},
+				},
+			},
+		},
+	}
+
+	if err := b.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.SaveCollection(&collections.Collection{Name: "admin"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection.Items[0].Name = "changed after save"
+
+	loaded, err := b.LoadCollection("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.EnvName != "dev" || len(loaded.Items) != 1 || loaded.Items[0].Name != "Create" {
+		t.Errorf("unexpected collection: %+v", loaded)
+	}
+	if string(loaded.Items[0].Request.Body.Content) != `{"name": "test"}` {
+		t.Errorf("unexpected body: %s", loaded.Items[0].Request.Body.Content)
+	}
+
+	names, err := b.ListCollections()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(names, ",") != "admin,api" {
+		t.Errorf("expected [admin api], got %v", names)
+	}
+
+	if err := b.DeleteCollection("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := b.LoadCollection("api"); err == nil {
+		t.Fatal("expected error for deleted collection")
+	} else if notFound && !stderrors.Is(err, errors.ErrCollectionNotFound) {
+		t.Errorf("expected ErrCollectionNotFound, got %v", err)
+	}
+	if err := b.DeleteCollection("api"); err == nil {
+		t.Error("expected error for deleting a missing collection")
+	}
+
+	if err := b.SaveCollection(&collections.Collection{}); err == nil {
+		t.Error("expected error for collection without name")
+	}
+}
+
+func testBackendEnvironments(t *testing.T, b Backend, notFound bool) {
+	t.Helper()
+
+	env := environment.NewEnvironment("dev")
+	env.Set("base_url", "http://localhost")
+
+	if err := b.SaveEnvironment(env); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	env.Set("base_url", "http://changed")
+
+	loaded, err := b.LoadEnvironment("dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, _ := loaded.Get("base_url"); value != "http://localhost" {
+		t.Errorf("expected stored value, got %q", value)
+	}
+
+	if err := b.SaveEnvironment(env); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err = b.LoadEnvironment("dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, _ := loaded.Get("base_url"); value != "http://changed" {
+		t.Errorf("expected replaced value, got %q", value)
+	}
+
+	names, err := b.ListEnvironments()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "dev" {
+		t.Errorf("expected [dev], got %v", names)
+	}
+
+	if err := b.DeleteEnvironment("dev"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := b.LoadEnvironment("dev"); err == nil {
+		t.Fatal("expected error for deleted environment")
+	} else if notFound && !stderrors.Is(err, errors.ErrEnvironmentNotFound) {
+		t.Errorf("expected ErrEnvironmentNotFound, got %v", err)
+	}
+}
+
+func testBackendUpdateEnvironment(t *testing.T, b Backend) {
+	t.Helper()
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- b.UpdateEnvironment("counter", func(env *environment.Environment) error {
+				value, _ := env.Get("count")
+				count, _ := strconv.Atoi(value)
+				env.Set("count", strconv.Itoa(count+1))
+				return nil
+			})
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	env, err := b.LoadEnvironment("counter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count, _ := env.Get("count"); count != strconv.Itoa(workers) {
+		t.Errorf("expected count %d, got %s", workers, count)
+	}
+
+	errUpdate := stderrors.New("update failed")
+	err = b.UpdateEnvironment("counter", func(env *environment.Environment) error {
+		env.Set("count", "0")
+		return errUpdate
+	})
+	if !stderrors.Is(err, errUpdate) {
+		t.Errorf("expected update error, got %v", err)
+	}
+
+	env, err = b.LoadEnvironment("counter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count, _ := env.Get("count"); count != strconv.Itoa(workers) {
+		t.Errorf("expected failed update to leave count %d, got %s", workers, count)
+	}
+}
+
+func testBackendHistory(t *testing.T, b Backend) {
+	t.Helper()
+
+	ids, err := b.ListHistory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("expected empty history, got %v", ids)
+	}
+
+	for _, name := range []string{"first", "second"} {
+		result := &types.ExecutionResult{
+			CollectionName: name,
+			Requests: []*types.RequestExecution{
+				{Request: &types.Request{Method: "GET", URL: "http://example.com/" + name}},
+			},
+		}
+		if err := b.SaveHistory(result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	ids, err = b.ListHistory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 entries, got %v", ids)
+	}
+
+	last, err := b.LoadHistory(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last.CollectionName != "second" || len(last.Requests) != 1 {
+		t.Errorf("expected most recent entry first, got %+v", last)
+	}
+
+	// Entries saved within the same 100µs must not overwrite each other.
+	for i := 0; i < 10; i++ {
+		if err := b.SaveHistory(&types.ExecutionResult{CollectionName: "burst"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if ids, _ := b.ListHistory(); len(ids) != 12 {
+		t.Errorf("expected 12 entries, got %d", len(ids))
+	}
+
+	if _, err := b.LoadHistory("missing"); err == nil {
+		t.Error("expected error for missing history entry")
+	}
+
+	if err := b.ClearHistory(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids, _ := b.ListHistory(); len(ids) != 0 {
+		t.Errorf("expected empty history after clear, got %v", ids)
+	}
+}
+
+func testBackendQueryHistory(t *testing.T, b Backend) {
+	t.Helper()
+
+	start := time.Now().Add(-time.Second)
+	ok := 0
+	results := []*types.ExecutionResult{
+		{
+			CollectionName: "api",
+			Environment:    "dev",
+			Requests: []*types.RequestExecution{
+				{Request: &types.Request{URL: "http://example.com/users"}, Response: &types.Response{StatusCode: 200}},
+				{Request: &types.Request{URL: "grpc://example.com/greeter.Greeter/SayHello"}, Response: &types.Response{GRPCCode: &ok, Status: "OK"}},
+			},
+		},
+		{
+			CollectionName: "api",
+			Environment:    "prod",
+			Requests: []*types.RequestExecution{
+				{Request: &types.Request{URL: "http://example.com/orders"}, Response: &types.Response{StatusCode: 500}},
+			},
+		},
+		{
+			CollectionName: "admin",
+			Environment:    "dev",
+			Requests: []*types.RequestExecution{
+				{Request: &types.Request{URL: "http://example.com/users"}, Error: "connection refused"},
+			},
+		},
+	}
+
+	for _, result := range results {
+		if err := b.SaveHistory(result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		query    HistoryQuery
+		expected []string
+	}{
+		{"all", HistoryQuery{}, []string{"admin/dev", "api/prod", "api/dev"}},
+		{"collection", HistoryQuery{CollectionName: "api"}, []string{"api/prod", "api/dev"}},
+		{"environment", HistoryQuery{Environment: "dev"}, []string{"admin/dev", "api/dev"}},
+		{"status code", HistoryQuery{StatusCode: 500}, []string{"api/prod"}},
+		{"gRPC code", HistoryQuery{GRPCCode: &ok}, []string{"api/dev"}},
+		{"url", HistoryQuery{URLContains: "/users"}, []string{"admin/dev", "api/dev"}},
+		{"errors only", HistoryQuery{Errors: ErrorsOnly}, []string{"admin/dev"}},
+		{"errors none", HistoryQuery{Errors: ErrorsNone}, []string{"api/prod", "api/dev"}},
+		{"combined", HistoryQuery{CollectionName: "api", StatusCode: 200}, []string{"api/dev"}},
+		{"since", HistoryQuery{Since: start}, []string{"admin/dev", "api/prod", "api/dev"}},
+		{"since future", HistoryQuery{Since: time.Now().Add(time.Minute)}, []string{}},
+		{"until", HistoryQuery{Until: start}, []string{}},
+		{"limit", HistoryQuery{Limit: 2}, []string{"admin/dev", "api/prod"}},
+		{"offset", HistoryQuery{Offset: 2, Limit: 2}, []string{"api/dev"}},
+		{"no match", HistoryQuery{CollectionName: "missing"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := b.QueryHistory(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []string{}
+			for _, entry := range entries {
+				if entry.ID == "" || entry.Time.Before(start) {
+					t.Errorf("unexpected entry ID %q or time %v", entry.ID, entry.Time)
+				}
+				got = append(got, entry.Result.CollectionName+"/"+entry.Result.Environment)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func testBackendPruneHistory(t *testing.T, b Backend) {
+	t.Helper()
+
+	prune := func(policy RetentionPolicy, expected int) {
+		t.Helper()
+
+		removed, err := b.PruneHistory(policy)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if removed != expected {
+			t.Errorf("expected %d removed entries for %+v, got %d", expected, policy, removed)
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := b.SaveHistory(&types.ExecutionResult{CollectionName: strconv.Itoa(i)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	prune(RetentionPolicy{}, 0)
+	prune(RetentionPolicy{MaxCount: 3}, 2)
+
+	entries, err := b.QueryHistory(HistoryQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Result.CollectionName != "4" || entries[2].Result.CollectionName != "2" {
+		t.Errorf("expected the 3 most recent entries to be kept, got %d", len(entries))
+	}
+
+	prune(RetentionPolicy{MaxAge: time.Hour, MaxSize: 1 << 20}, 0)
+	prune(RetentionPolicy{MaxSize: 1}, 3)
+
+	if err := b.SaveHistory(&types.ExecutionResult{CollectionName: "old"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	prune(RetentionPolicy{MaxAge: time.Millisecond}, 1)
+}
+
+func testBackendLogs(t *testing.T, b Backend) {
+	t.Helper()
+
+	logs := []types.LogEntry{{Time: time.Now().UTC(), Level: "info", Message: "started"}}
+	if err := b.SaveLogs(logs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := b.ListLogs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 1 {
+		t.Fatalf("expected 1 log batch, got %v", ids)
+	}
+
+	loaded, err := b.LoadLogs(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Message != "started" {
+		t.Errorf("unexpected logs: %+v", loaded)
+	}
+}
+
+func testBackendConfig(t *testing.T, b Backend) {
+	t.Helper()
+
+	data, err := b.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data != nil {
+		t.Fatalf("expected no config, got %q", data)
+	}
+
+	config := []byte("logging:\n  level: debug\n")
+	if err := b.SaveConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err = b.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != string(config) {
+		t.Errorf("expected %q, got %q", config, data)
+	}
+}
@@ -0,0 +1,348 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package backend
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// FileBackend stores everything as files under a base directory: collections and
+// environments as JSON or YAML files, history and logs as timestamped JSON files
+// and the configuration as config.yaml.
+type FileBackend struct {
+	mu             sync.RWMutex
+	storage        *storage.FileStorage
+	historyStorage *storage.HistoryStorage
+	logStorage     *storage.LogStorage
+	layout         string
+	format         storage.Format
+}
+
+// environmentLocksDir is the directory of the lock files of environments, within the
+// environments directory.
+const environmentLocksDir = ".locks"
+
+// NewFileBackend creates a FileBackend, creating the directories under basePath if needed.
+func NewFileBackend(basePath string) (*FileBackend, error) {
+	fileStorage, err := storage.NewFileStorage(basePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileBackend{
+		storage:        fileStorage,
+		historyStorage: storage.NewHistoryStorage(fileStorage),
+		logStorage:     storage.NewLogStorage(fileStorage),
+		layout:         LayoutFile,
+		format:         storage.FormatJSON,
+	}, nil
+}
+
+// Storage returns the file storage holding the directories of the backend.
+func (fb *FileBackend) Storage() *storage.FileStorage {
+	return fb.storage
+}
+
+// SetCollectionLayout sets the layout (LayoutFile or LayoutDirectory) and format of
+// collections saved for the first time. Empty values keep the defaults, a single
+// JSON file per collection. Existing collections keep their layout and format.
+func (fb *FileBackend) SetCollectionLayout(layout string, format storage.Format) {
+	if layout == "" {
+		layout = LayoutFile
+	}
+
+	if format == "" {
+		format = storage.FormatJSON
+	}
+
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	fb.layout = layout
+	fb.format = format
+}
+
+// LoadCollection loads a collection stored either as a single file or as a directory.
+func (fb *FileBackend) LoadCollection(name string) (*collections.Collection, error) {
+	if dirPath := collections.GetCollectionDir(fb.storage, name); collections.IsCollectionDir(dirPath) {
+		return collections.LoadCollectionFromDir(dirPath)
+	}
+
+	return collections.LoadCollectionFromFile(collections.GetCollectionPath(fb.storage, name))
+}
+
+// SaveCollection saves a collection. A collection that is already stored keeps its
+// layout and format; a new one uses the layout set with SetCollectionLayout.
+func (fb *FileBackend) SaveCollection(collection *collections.Collection) error {
+	fb.mu.RLock()
+	layout, format := fb.layout, fb.format
+	fb.mu.RUnlock()
+
+	dirPath := collections.GetCollectionDir(fb.storage, collection.Name)
+	filePath := collections.GetCollectionPath(fb.storage, collection.Name)
+	_, err := os.Stat(filePath)
+	fileExists := err == nil
+
+	switch {
+	case collections.IsCollectionDir(dirPath):
+		return collections.SaveCollectionToDir(collection, dirPath, format)
+	case fileExists:
+		return collections.SaveCollectionToFile(collection, filePath)
+	case layout == LayoutDirectory:
+		return collections.SaveCollectionToDir(collection, dirPath, format)
+	default:
+		filePath = filepath.Join(fb.storage.CollectionsDir(), collection.Name+storage.Extension(format))
+		return collections.SaveCollectionToFile(collection, filePath)
+	}
+}
+
+// ListCollections returns the names of the collections stored as JSON or YAML files
+// or as collection directories.
+func (fb *FileBackend) ListCollections() ([]string, error) {
+	names, err := storage.ListNames(fb.storage.CollectionsDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collections directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(fb.storage.CollectionsDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collections directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || slices.Contains(names, entry.Name()) {
+			continue
+		}
+		if collections.IsCollectionDir(filepath.Join(fb.storage.CollectionsDir(), entry.Name())) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// DeleteCollection deletes the file or the directory of a collection.
+func (fb *FileBackend) DeleteCollection(name string) error {
+	if dirPath := collections.GetCollectionDir(fb.storage, name); collections.IsCollectionDir(dirPath) {
+		return os.RemoveAll(dirPath)
+	}
+
+	return os.Remove(collections.GetCollectionPath(fb.storage, name))
+}
+
+// LoadEnvironment loads an environment from its JSON or YAML file.
+func (fb *FileBackend) LoadEnvironment(name string) (*environment.Environment, error) {
+	return environment.NewEnvironmentFromFile(storage.FindFile(fb.storage.EnvironmentsDir(), name))
+}
+
+// SaveEnvironment saves an environment to its file, keeping the format of an existing one.
+func (fb *FileBackend) SaveEnvironment(env *environment.Environment) error {
+	lock, err := fb.lockEnvironment(env.Name)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	return env.Save(storage.FindFile(fb.storage.EnvironmentsDir(), env.Name))
+}
+
+// UpdateEnvironment updates an environment while holding an advisory lock on it, so
+// that getman processes sharing the directory do not lose each other's changes.
+func (fb *FileBackend) UpdateEnvironment(name string, update func(env *environment.Environment) error) error {
+	lock, err := fb.lockEnvironment(name)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	filePath := storage.FindFile(fb.storage.EnvironmentsDir(), name)
+	env, err := environment.NewEnvironmentFromFile(filePath)
+
+	if stderrors.Is(err, fs.ErrNotExist) {
+		env, err = environment.NewEnvironment(name), nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := update(env); err != nil {
+		return err
+	}
+
+	return env.Save(filePath)
+}
+
+// lockEnvironment locks an environment by name, whatever the extension of its file.
+// The lock files are kept apart from the environments, in the .locks directory of the
+// environments directory, and are removed with their environment.
+func (fb *FileBackend) lockEnvironment(name string) (*storage.FileLock, error) {
+	dir := filepath.Join(fb.storage.EnvironmentsDir(), environmentLocksDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create locks directory: %w", err)
+	}
+
+	return storage.LockFile(filepath.Join(dir, name))
+}
+
+// ListEnvironments returns the names of the environments stored as JSON or YAML files.
+func (fb *FileBackend) ListEnvironments() ([]string, error) {
+	names, err := storage.ListNames(fb.storage.EnvironmentsDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environments directory: %w", err)
+	}
+
+	return names, nil
+}
+
+// DeleteEnvironment deletes the file of an environment and its lock file.
+func (fb *FileBackend) DeleteEnvironment(name string) error {
+	lock, err := fb.lockEnvironment(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(storage.FindFile(fb.storage.EnvironmentsDir(), name))
+	if lockErr := lock.Remove(); err == nil {
+		err = lockErr
+	}
+
+	return err
+}
+
+// SaveHistory saves an execution result to a timestamped file.
+func (fb *FileBackend) SaveHistory(result *types.ExecutionResult) error {
+	return fb.historyStorage.Save(result)
+}
+
+// LoadHistory loads an execution result by the timestamp of its file.
+func (fb *FileBackend) LoadHistory(id string) (*types.ExecutionResult, error) {
+	return fb.historyStorage.Load(id)
+}
+
+// ListHistory returns the timestamps of the history files, most recent first.
+func (fb *FileBackend) ListHistory() ([]string, error) {
+	return fb.historyStorage.List()
+}
+
+// QueryHistory returns the history entries matching query, most recent first.
+// Every history file saved within the time range of the query is read.
+func (fb *FileBackend) QueryHistory(query HistoryQuery) ([]HistoryEntry, error) {
+	infos, err := fb.historyInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryHistory(infos, fb.historyStorage.Load, query), nil
+}
+
+// PruneHistory removes the history files outside policy.
+func (fb *FileBackend) PruneHistory(policy RetentionPolicy) (int, error) {
+	if policy.IsZero() {
+		return 0, nil
+	}
+
+	infos, err := fb.historyInfos()
+	if err != nil {
+		return 0, err
+	}
+
+	if policy.MaxSize > 0 {
+		for i := range infos {
+			if infos[i].size, err = fb.historyStorage.Size(infos[i].id); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	removed := 0
+	for _, id := range expiredEntries(infos, policy, time.Now()) {
+		if err := fb.historyStorage.Delete(id); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// historyInfos lists the history files, most recent first.
+func (fb *FileBackend) historyInfos() ([]historyInfo, error) {
+	ids, err := fb.historyStorage.List()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]historyInfo, 0, len(ids))
+	for _, id := range ids {
+		t, err := storage.EntryTime(id)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, historyInfo{id: id, time: t})
+	}
+
+	return infos, nil
+}
+
+// ClearHistory removes all history files.
+func (fb *FileBackend) ClearHistory() error {
+	return fb.historyStorage.Clear()
+}
+
+// SaveLogs saves log entries to a timestamped file.
+func (fb *FileBackend) SaveLogs(logs []types.LogEntry) error {
+	return fb.logStorage.Save(logs)
+}
+
+// LoadLogs loads log entries by the timestamp of their file.
+func (fb *FileBackend) LoadLogs(id string) ([]types.LogEntry, error) {
+	return fb.logStorage.Load(id)
+}
+
+// ListLogs returns the timestamps of the log files, most recent first.
+func (fb *FileBackend) ListLogs() ([]string, error) {
+	return fb.logStorage.List()
+}
+
+// LoadConfig reads config.yaml from the base directory.
+func (fb *FileBackend) LoadConfig() ([]byte, error) {
+	data, err := os.ReadFile(fb.storage.ConfigPath())
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return data, nil
+}
+
+// SaveConfig writes config.yaml to the base directory.
+func (fb *FileBackend) SaveConfig(data []byte) error {
+	if err := storage.WriteFileAtomic(fb.storage.ConfigPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// Close does nothing; files are not kept open between calls.
+func (fb *FileBackend) Close() error {
+	return nil
+}
@@ -0,0 +1,166 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func newTestFileBackend(t *testing.T) (*FileBackend, string) {
+	t.Helper()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { helper.CleanupTempDir(dir) })
+
+	fileBackend, err := NewFileBackend(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return fileBackend, dir
+}
+
+func TestUnitFileBackend_Collections(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendCollections(t, fileBackend, false)
+}
+
+func TestUnitFileBackend_Environments(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendEnvironments(t, fileBackend, false)
+}
+
+func TestUnitFileBackend_UpdateEnvironment(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendUpdateEnvironment(t, fileBackend)
+}
+
+func TestUnitFileBackend_History(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendHistory(t, fileBackend)
+}
+
+func TestUnitFileBackend_QueryHistory(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendQueryHistory(t, fileBackend)
+}
+
+func TestUnitFileBackend_PruneHistory(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendPruneHistory(t, fileBackend)
+}
+
+func TestUnitFileBackend_Logs(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+	testBackendLogs(t, fileBackend)
+}
+
+func TestUnitFileBackend_Config(t *testing.T) {
+	fileBackend, dir := newTestFileBackend(t)
+	testBackendConfig(t, fileBackend)
+
+	if _, err := os.Stat(filepath.Join(dir, "config.yaml")); err != nil {
+		t.Errorf("expected config.yaml: %v", err)
+	}
+}
+
+func TestUnitFileBackend_CollectionLayout(t *testing.T) {
+	fileBackend, dir := newTestFileBackend(t)
+	fileBackend.SetCollectionLayout(LayoutDirectory, storage.FormatYAML)
+
+	collection := &collections.Collection{
+		Name: "api",
+		Items: []*types.RequestItem{
+			{Name: "List", Request: &types.Request{Method: "GET", URL: "http://example.com"}},
+		},
+	}
+
+	if err := fileBackend.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "collections", "api", "List.yaml")); err != nil {
+		t.Fatalf("expected request file: %v", err)
+	}
+
+	names, err := fileBackend.ListCollections()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "api" {
+		t.Errorf("expected [api], got %v", names)
+	}
+
+	if err := fileBackend.DeleteCollection("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "collections", "api")); !os.IsNotExist(err) {
+		t.Error("expected collection directory to be removed")
+	}
+}
+
+func TestUnitFileBackend_ReadsExistingFiles(t *testing.T) {
+	fileBackend, dir := newTestFileBackend(t)
+
+	envYAML := "name: dev\nvariables:\n  token: abc\n"
+	if err := os.WriteFile(filepath.Join(dir, "environments", "dev.yaml"), []byte(envYAML), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	env, err := fileBackend.LoadEnvironment("dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := env.Get("token"); token != "abc" {
+		t.Errorf("expected token abc, got %q", token)
+	}
+
+	if fileBackend.Storage().BasePath() != dir {
+		t.Errorf("expected base path %s, got %s", dir, fileBackend.Storage().BasePath())
+	}
+}
+
+func TestUnitFileBackend_EnvironmentLocks(t *testing.T) {
+	fileBackend, _ := newTestFileBackend(t)
+
+	err := fileBackend.UpdateEnvironment("dev", func(env *environment.Environment) error {
+		env.Set("token", "abc")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	environmentsDir := fileBackend.storage.EnvironmentsDir()
+	names, err := fileBackend.ListEnvironments()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "dev" {
+		t.Errorf("expected only the dev environment, got %v", names)
+	}
+	if _, err := os.Stat(filepath.Join(environmentsDir, "dev.lock")); !os.IsNotExist(err) {
+		t.Error("expected no lock file next to the environments")
+	}
+
+	if err := fileBackend.DeleteEnvironment("dev"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	locks, err := os.ReadDir(filepath.Join(environmentsDir, environmentLocksDir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(locks) != 0 {
+		t.Errorf("expected the lock file to be removed with the environment, got %v", locks)
+	}
+}
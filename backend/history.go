@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package backend
+
+import (
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// ErrorFilter selects history entries by the presence of request errors.
+type ErrorFilter int
+
+const (
+	// ErrorsAny matches entries with or without request errors.
+	ErrorsAny ErrorFilter = iota
+	// ErrorsOnly matches entries with at least one request that failed with an error.
+	ErrorsOnly
+	// ErrorsNone matches entries without request errors.
+	ErrorsNone
+)
+
+// HistoryQuery selects history entries. Zero fields match every entry; set fields
+// must all match. Request-level filters match when any request of the entry does.
+type HistoryQuery struct {
+	// CollectionName matches the collection name of the entry exactly.
+	CollectionName string
+	// Environment matches the environment name of the entry exactly.
+	Environment string
+	// StatusCode matches entries with a response of that HTTP status code.
+	StatusCode int
+	// GRPCCode, when set, matches entries with a gRPC call of that status code,
+	// including 0 for OK.
+	GRPCCode *int
+	// URLContains matches entries with a request URL containing the substring.
+	URLContains string
+	// Errors matches entries by the presence of request errors, such as failed
+	// connections. Error status codes are not request errors; use StatusCode for them.
+	Errors ErrorFilter
+	// Since and Until bound the time the entry was saved: Since is inclusive,
+	// Until exclusive.
+	Since time.Time
+	Until time.Time
+	// Offset skips that many matching entries and Limit, when positive, caps the
+	// number of returned entries, for paging through the results.
+	Offset int
+	Limit  int
+}
+
+// HistoryEntry is a history entry returned by a query.
+type HistoryEntry struct {
+	ID     string
+	Time   time.Time
+	Result *types.ExecutionResult
+}
+
+// RetentionPolicy limits the history kept by a backend. The most recent entries are
+// kept while all limits hold; zero limits are not applied.
+type RetentionPolicy struct {
+	// MaxCount is the number of entries to keep.
+	MaxCount int
+	// MaxAge is the age after which entries are removed.
+	MaxAge time.Duration
+	// MaxSize is the total size in bytes of the entries to keep.
+	MaxSize int64
+}
+
+// IsZero reports whether the policy keeps the whole history.
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxCount <= 0 && p.MaxAge <= 0 && p.MaxSize <= 0
+}
+
+// Matches reports whether an entry saved at t with the given result matches the query,
+// ignoring Offset and Limit.
+func (q HistoryQuery) Matches(result *types.ExecutionResult, t time.Time) bool {
+	if !q.Since.IsZero() && t.Before(q.Since) {
+		return false
+	}
+
+	if !q.Until.IsZero() && !t.Before(q.Until) {
+		return false
+	}
+
+	if q.CollectionName != "" && result.CollectionName != q.CollectionName {
+		return false
+	}
+
+	if q.Environment != "" && result.Environment != q.Environment {
+		return false
+	}
+
+	statusFound := q.StatusCode == 0
+	grpcFound := q.GRPCCode == nil
+	urlFound := q.URLContains == ""
+	hasError := false
+
+	for _, execution := range result.Requests {
+		if execution == nil {
+			continue
+		}
+
+		if execution.Response != nil && execution.Response.StatusCode == q.StatusCode {
+			statusFound = true
+		}
+
+		if execution.Response != nil && execution.Response.GRPCCode != nil && q.GRPCCode != nil &&
+			*execution.Response.GRPCCode == *q.GRPCCode {
+			grpcFound = true
+		}
+
+		if execution.Request != nil && q.URLContains != "" && strings.Contains(execution.Request.URL, q.URLContains) {
+			urlFound = true
+		}
+
+		if execution.Error != "" {
+			hasError = true
+		}
+	}
+
+	switch q.Errors {
+	case ErrorsOnly:
+		if !hasError {
+			return false
+		}
+	case ErrorsNone:
+		if hasError {
+			return false
+		}
+	}
+
+	return statusFound && grpcFound && urlFound
+}
+
+// historyInfo describes a stored history entry for the retention policy.
+type historyInfo struct {
+	id   string
+	time time.Time
+	size int64
+}
+
+// queryHistory runs a query over entries listed most recent first, loading each
+// entry saved within the time range of the query. Unreadable entries are skipped.
+func queryHistory(infos []historyInfo, load func(id string) (*types.ExecutionResult, error), query HistoryQuery) []HistoryEntry {
+	entries := []HistoryEntry{}
+	skipped := 0
+
+	for _, info := range infos {
+		if query.Limit > 0 && len(entries) == query.Limit {
+			break
+		}
+
+		if !query.Since.IsZero() && info.time.Before(query.Since) {
+			continue
+		}
+
+		if !query.Until.IsZero() && !info.time.Before(query.Until) {
+			continue
+		}
+
+		result, err := load(info.id)
+		if err != nil || !query.Matches(result, info.time) {
+			continue
+		}
+
+		if skipped < query.Offset {
+			skipped++
+			continue
+		}
+
+		entries = append(entries, HistoryEntry{ID: info.id, Time: info.time, Result: result})
+	}
+
+	return entries
+}
+
+// expiredEntries returns the IDs of the entries, listed most recent first, that fall
+// outside the policy at now.
+func expiredEntries(infos []historyInfo, policy RetentionPolicy, now time.Time) []string {
+	var (
+		expired []string
+		size    int64
+	)
+
+	for i, info := range infos {
+		size += info.size
+
+		switch {
+		case policy.MaxCount > 0 && i >= policy.MaxCount,
+			policy.MaxAge > 0 && now.Sub(info.time) > policy.MaxAge,
+			policy.MaxSize > 0 && size > policy.MaxSize:
+			expired = append(expired, info.id)
+		}
+	}
+
+	return expired
+}
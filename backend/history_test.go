@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitHistoryQuery_Matches(t *testing.T) {
+	now := time.Now()
+	ok, notFound := 0, 5
+	result := &types.ExecutionResult{
+		CollectionName: "api",
+		Requests: []*types.RequestExecution{
+			nil,
+			{Request: &types.Request{URL: "http://example.com/users"}},
+			{Request: &types.Request{URL: "http://example.com/orders"}, Response: &types.Response{StatusCode: 404}},
+			{Request: &types.Request{URL: "grpc://example.com/greeter.Greeter/SayHello"}, Response: &types.Response{GRPCCode: &ok, Status: "OK"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		query    HistoryQuery
+		expected bool
+	}{
+		{"empty", HistoryQuery{}, true},
+		{"status of another request", HistoryQuery{StatusCode: 404, URLContains: "/users"}, true},
+		{"missing status", HistoryQuery{StatusCode: 200}, false},
+		{"gRPC OK", HistoryQuery{GRPCCode: &ok}, true},
+		{"missing gRPC code", HistoryQuery{GRPCCode: &notFound}, false},
+		{"without errors", HistoryQuery{Errors: ErrorsNone}, true},
+		{"with errors", HistoryQuery{Errors: ErrorsOnly}, false},
+		{"since is inclusive", HistoryQuery{Since: now}, true},
+		{"until is exclusive", HistoryQuery{Until: now}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Matches(result, now); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnitExpiredEntries(t *testing.T) {
+	now := time.Now()
+	infos := []historyInfo{
+		{id: "c", time: now.Add(-time.Minute), size: 10},
+		{id: "b", time: now.Add(-time.Hour), size: 10},
+		{id: "a", time: now.Add(-2 * time.Hour), size: 10},
+	}
+
+	tests := []struct {
+		name     string
+		policy   RetentionPolicy
+		expected string
+	}{
+		{"none", RetentionPolicy{}, ""},
+		{"count", RetentionPolicy{MaxCount: 1}, "b,a"},
+		{"age", RetentionPolicy{MaxAge: 90 * time.Minute}, "a"},
+		{"size", RetentionPolicy{MaxSize: 25}, "a"},
+		{"combined", RetentionPolicy{MaxCount: 3, MaxAge: 90 * time.Minute, MaxSize: 15}, "b,a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(expiredEntries(infos, tt.policy, now), ","); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// MemoryBackend keeps everything in memory, which makes it a good fit for tests.
+// Values are stored encoded, so changes to a loaded or saved value never leak
+// into the backend.
+type MemoryBackend struct {
+	mu           sync.RWMutex
+	collections  map[string][]byte
+	environments map[string][]byte
+	history      []memoryEntry
+	logs         []memoryEntry
+	config       []byte
+}
+
+// memoryEntry is a history or log entry, kept in the order it was saved.
+type memoryEntry struct {
+	id   string
+	time time.Time
+	data []byte
+}
+
+// NewMemoryBackend creates an empty MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		collections:  make(map[string][]byte),
+		environments: make(map[string][]byte),
+	}
+}
+
+// LoadCollection loads a collection by name.
+func (mb *MemoryBackend) LoadCollection(name string) (*collections.Collection, error) {
+	mb.mu.RLock()
+	data, ok := mb.collections[name]
+	mb.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrCollectionNotFound, name)
+	}
+
+	return collections.ParseCollection(data, storage.FormatJSON)
+}
+
+// SaveCollection creates or replaces a collection.
+func (mb *MemoryBackend) SaveCollection(collection *collections.Collection) error {
+	data, err := collections.MarshalCollection(collection, storage.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.collections[collection.Name] = data
+	return nil
+}
+
+// ListCollections returns the names of the collections in lexical order.
+func (mb *MemoryBackend) ListCollections() ([]string, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return sortedKeys(mb.collections), nil
+}
+
+// DeleteCollection deletes a collection by name.
+func (mb *MemoryBackend) DeleteCollection(name string) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if _, ok := mb.collections[name]; !ok {
+		return fmt.Errorf("%w: %s", errors.ErrCollectionNotFound, name)
+	}
+
+	delete(mb.collections, name)
+	return nil
+}
+
+// LoadEnvironment loads an environment by name.
+func (mb *MemoryBackend) LoadEnvironment(name string) (*environment.Environment, error) {
+	mb.mu.RLock()
+	data, ok := mb.environments[name]
+	mb.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrEnvironmentNotFound, name)
+	}
+
+	return environment.ParseEnvironment(data, storage.FormatJSON)
+}
+
+// SaveEnvironment creates or replaces an environment.
+func (mb *MemoryBackend) SaveEnvironment(env *environment.Environment) error {
+	data, err := env.Marshal(storage.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.environments[env.Name] = data
+	return nil
+}
+
+// ListEnvironments returns the names of the environments in lexical order.
+func (mb *MemoryBackend) ListEnvironments() ([]string, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return sortedKeys(mb.environments), nil
+}
+
+// DeleteEnvironment deletes an environment by name.
+func (mb *MemoryBackend) DeleteEnvironment(name string) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if _, ok := mb.environments[name]; !ok {
+		return fmt.Errorf("%w: %s", errors.ErrEnvironmentNotFound, name)
+	}
+
+	delete(mb.environments, name)
+	return nil
+}
+
+// UpdateEnvironment updates an environment while holding the lock of the backend.
+func (mb *MemoryBackend) UpdateEnvironment(name string, update func(env *environment.Environment) error) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	env := environment.NewEnvironment(name)
+
+	if data, ok := mb.environments[name]; ok {
+		var err error
+		if env, err = environment.ParseEnvironment(data, storage.FormatJSON); err != nil {
+			return err
+		}
+	}
+
+	if err := update(env); err != nil {
+		return err
+	}
+
+	data, err := env.Marshal(storage.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	mb.environments[env.Name] = data
+	return nil
+}
+
+// SaveHistory adds an execution result to the history under a unique timestamp ID.
+func (mb *MemoryBackend) SaveHistory(result *types.ExecutionResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution result: %w", err)
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.history = addEntry(mb.history, time.Now(), data)
+	return nil
+}
+
+// LoadHistory loads an execution result by its ID.
+func (mb *MemoryBackend) LoadHistory(id string) (*types.ExecutionResult, error) {
+	mb.mu.RLock()
+	data, ok := findEntry(mb.history, id)
+	mb.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: history entry %s not found", errors.ErrStorageError, id)
+	}
+
+	var result types.ExecutionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse history entry: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListHistory returns the IDs of the history entries, most recent first.
+func (mb *MemoryBackend) ListHistory() ([]string, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return entryIDs(mb.history), nil
+}
+
+// QueryHistory returns the history entries matching query, most recent first.
+func (mb *MemoryBackend) QueryHistory(query HistoryQuery) ([]HistoryEntry, error) {
+	mb.mu.RLock()
+	infos := entryInfos(mb.history)
+	mb.mu.RUnlock()
+
+	return queryHistory(infos, mb.LoadHistory, query), nil
+}
+
+// PruneHistory removes the history entries outside policy.
+func (mb *MemoryBackend) PruneHistory(policy RetentionPolicy) (int, error) {
+	if policy.IsZero() {
+		return 0, nil
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	expired := expiredEntries(entryInfos(mb.history), policy, time.Now())
+	mb.history = slices.DeleteFunc(mb.history, func(entry memoryEntry) bool {
+		return slices.Contains(expired, entry.id)
+	})
+
+	return len(expired), nil
+}
+
+// ClearHistory removes all history entries.
+func (mb *MemoryBackend) ClearHistory() error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.history = nil
+	return nil
+}
+
+// SaveLogs stores a batch of log entries under a unique timestamp ID.
+func (mb *MemoryBackend) SaveLogs(logs []types.LogEntry) error {
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal logs: %w", err)
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.logs = addEntry(mb.logs, time.Now(), data)
+	return nil
+}
+
+// LoadLogs loads a batch of log entries by its ID.
+func (mb *MemoryBackend) LoadLogs(id string) ([]types.LogEntry, error) {
+	mb.mu.RLock()
+	data, ok := findEntry(mb.logs, id)
+	mb.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: log entry %s not found", errors.ErrStorageError, id)
+	}
+
+	var logs []types.LogEntry
+	if err := json.Unmarshal(data, &logs); err != nil {
+		return nil, fmt.Errorf("failed to parse logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// ListLogs returns the IDs of the log batches, most recent first.
+func (mb *MemoryBackend) ListLogs() ([]string, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return entryIDs(mb.logs), nil
+}
+
+// LoadConfig returns the stored configuration, or nil when none was saved.
+func (mb *MemoryBackend) LoadConfig() ([]byte, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return slices.Clone(mb.config), nil
+}
+
+// SaveConfig stores the configuration.
+func (mb *MemoryBackend) SaveConfig(data []byte) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.config = slices.Clone(data)
+	return nil
+}
+
+// Close does nothing; the stored values stay available.
+func (mb *MemoryBackend) Close() error {
+	return nil
+}
+
+func sortedKeys(values map[string][]byte) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// addEntry appends an entry saved at t under the first free ID.
+func addEntry(entries []memoryEntry, t time.Time, data []byte) []memoryEntry {
+	for attempt := 0; ; attempt++ {
+		id := storage.EntryID(t, attempt)
+		if _, taken := findEntry(entries, id); !taken {
+			return append(entries, memoryEntry{id: id, time: t, data: data})
+		}
+	}
+}
+
+func findEntry(entries []memoryEntry, id string) ([]byte, bool) {
+	for _, entry := range entries {
+		if entry.id == id {
+			return entry.data, true
+		}
+	}
+
+	return nil, false
+}
+
+// entryInfos describes the entries, most recent first.
+func entryInfos(entries []memoryEntry) []historyInfo {
+	infos := make([]historyInfo, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		infos = append(infos, historyInfo{id: entries[i].id, time: entries[i].time, size: int64(len(entries[i].data))})
+	}
+
+	return infos
+}
+
+func entryIDs(entries []memoryEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		ids = append(ids, entries[i].id)
+	}
+
+	return ids
+}
@@ -0,0 +1,46 @@
+package backend
+
+import (
+	"testing"
+)
+
+func TestUnitMemoryBackend_Collections(t *testing.T) {
+	testBackendCollections(t, NewMemoryBackend(), true)
+}
+
+func TestUnitMemoryBackend_Environments(t *testing.T) {
+	testBackendEnvironments(t, NewMemoryBackend(), true)
+}
+
+func TestUnitMemoryBackend_UpdateEnvironment(t *testing.T) {
+	testBackendUpdateEnvironment(t, NewMemoryBackend())
+}
+
+func TestUnitMemoryBackend_History(t *testing.T) {
+	testBackendHistory(t, NewMemoryBackend())
+}
+
+func TestUnitMemoryBackend_QueryHistory(t *testing.T) {
+	testBackendQueryHistory(t, NewMemoryBackend())
+}
+
+func TestUnitMemoryBackend_PruneHistory(t *testing.T) {
+	testBackendPruneHistory(t, NewMemoryBackend())
+}
+
+func TestUnitMemoryBackend_Logs(t *testing.T) {
+	testBackendLogs(t, NewMemoryBackend())
+}
+
+func TestUnitMemoryBackend_Config(t *testing.T) {
+	memoryBackend := NewMemoryBackend()
+	testBackendConfig(t, memoryBackend)
+
+	data, _ := memoryBackend.LoadConfig()
+	data[0] = 'X'
+
+	stored, _ := memoryBackend.LoadConfig()
+	if stored[0] == 'X' {
+		t.Error("expected a copy of the stored config")
+	}
+}
@@ -0,0 +1,568 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package backend
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteFileName is the conventional name of the database file in a base directory.
+const SQLiteFileName = "getman.db"
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS collections (
+	name TEXT PRIMARY KEY,
+	data BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS environments (
+	name TEXT PRIMARY KEY,
+	data BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS history (
+	seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+	id         TEXT NOT NULL UNIQUE,
+	created_at INTEGER NOT NULL,
+	data       BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS logs (
+	seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+	id         TEXT NOT NULL UNIQUE,
+	created_at INTEGER NOT NULL,
+	data       BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS config (
+	id   INTEGER PRIMARY KEY CHECK (id = 1),
+	data BLOB NOT NULL
+);
+`
+
+// SQLiteBackend stores everything in a single SQLite database, which scales to large
+// histories better than a file per entry. It uses a pure Go driver, so no C
+// toolchain is needed. Collections and environments are stored as JSON documents.
+type SQLiteBackend struct {
+	db *sql.DB
+}
+
+// NewSQLiteBackend opens or creates the database at path, creating its directory
+// if needed. The path ":memory:" opens a database that lives until Close.
+func NewSQLiteBackend(path string) (*SQLiteBackend, error) {
+	dsn := path
+
+	if path != ":memory:" {
+		expandedPath, err := storage.ExpandPath(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand path: %w", err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(expandedPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+
+		// Immediate transactions take the write lock up front, so that concurrent
+		// read-modify-write transactions wait for each other instead of failing.
+		dsn = expandedPath + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open database: %w", errors.ErrStorageError, err)
+	}
+
+	// SQLite allows a single writer; one connection also keeps ":memory:" databases shared.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: failed to create schema: %w", errors.ErrStorageError, err)
+	}
+
+	return &SQLiteBackend{db: db}, nil
+}
+
+// LoadCollection loads a collection by name.
+func (sb *SQLiteBackend) LoadCollection(name string) (*collections.Collection, error) {
+	data, err := sb.loadDocument("collections", name)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", errors.ErrCollectionNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return collections.ParseCollection(data, storage.FormatJSON)
+}
+
+// SaveCollection creates or replaces a collection.
+func (sb *SQLiteBackend) SaveCollection(collection *collections.Collection) error {
+	data, err := collections.MarshalCollection(collection, storage.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	return sb.saveDocument("collections", collection.Name, data)
+}
+
+// ListCollections returns the names of the collections in lexical order.
+func (sb *SQLiteBackend) ListCollections() ([]string, error) {
+	return sb.listDocuments("collections")
+}
+
+// DeleteCollection deletes a collection by name.
+func (sb *SQLiteBackend) DeleteCollection(name string) error {
+	deleted, err := sb.deleteDocument("collections", name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %s", errors.ErrCollectionNotFound, name)
+	}
+
+	return nil
+}
+
+// LoadEnvironment loads an environment by name.
+func (sb *SQLiteBackend) LoadEnvironment(name string) (*environment.Environment, error) {
+	data, err := sb.loadDocument("environments", name)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", errors.ErrEnvironmentNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return environment.ParseEnvironment(data, storage.FormatJSON)
+}
+
+// SaveEnvironment creates or replaces an environment.
+func (sb *SQLiteBackend) SaveEnvironment(env *environment.Environment) error {
+	data, err := env.Marshal(storage.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	return sb.saveDocument("environments", env.Name, data)
+}
+
+// ListEnvironments returns the names of the environments in lexical order.
+func (sb *SQLiteBackend) ListEnvironments() ([]string, error) {
+	return sb.listDocuments("environments")
+}
+
+// DeleteEnvironment deletes an environment by name.
+func (sb *SQLiteBackend) DeleteEnvironment(name string) error {
+	deleted, err := sb.deleteDocument("environments", name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %s", errors.ErrEnvironmentNotFound, name)
+	}
+
+	return nil
+}
+
+// UpdateEnvironment updates an environment within a transaction.
+func (sb *SQLiteBackend) UpdateEnvironment(name string, update func(env *environment.Environment) error) error {
+	tx, err := sb.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%w: failed to begin transaction: %w", errors.ErrStorageError, err)
+	}
+	defer tx.Rollback()
+
+	env := environment.NewEnvironment(name)
+
+	var data []byte
+	err = tx.QueryRow("SELECT data FROM environments WHERE name = ?", name).Scan(&data)
+
+	switch {
+	case err == nil:
+		if env, err = environment.ParseEnvironment(data, storage.FormatJSON); err != nil {
+			return err
+		}
+	case err != sql.ErrNoRows:
+		return fmt.Errorf("%w: failed to read environments: %w", errors.ErrStorageError, err)
+	}
+
+	if err := update(env); err != nil {
+		return err
+	}
+
+	if data, err = env.Marshal(storage.FormatJSON); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO environments (name, data) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET data = excluded.data"
+	if _, err := tx.Exec(query, env.Name, data); err != nil {
+		return fmt.Errorf("%w: failed to write environments: %w", errors.ErrStorageError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: failed to commit transaction: %w", errors.ErrStorageError, err)
+	}
+
+	return nil
+}
+
+// SaveHistory adds an execution result to the history under a unique timestamp ID.
+func (sb *SQLiteBackend) SaveHistory(result *types.ExecutionResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution result: %w", err)
+	}
+
+	return sb.saveEntry("history", data)
+}
+
+// LoadHistory loads an execution result by its ID.
+func (sb *SQLiteBackend) LoadHistory(id string) (*types.ExecutionResult, error) {
+	data, err := sb.loadEntry("history", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.ExecutionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse history entry: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListHistory returns the IDs of the history entries, most recent first.
+func (sb *SQLiteBackend) ListHistory() ([]string, error) {
+	return sb.listEntries("history")
+}
+
+// QueryHistory returns the history entries matching query, most recent first. The
+// filters run in the database, on the JSON documents of the entries.
+func (sb *SQLiteBackend) QueryHistory(query HistoryQuery) ([]HistoryEntry, error) {
+	conditions := []string{"1 = 1"}
+	var args []any
+
+	if query.CollectionName != "" {
+		conditions = append(conditions, "json_extract(doc, '$.collection_name') = ?")
+		args = append(args, query.CollectionName)
+	}
+
+	if query.Environment != "" {
+		conditions = append(conditions, "json_extract(doc, '$.environment') = ?")
+		args = append(args, query.Environment)
+	}
+
+	if query.StatusCode != 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM json_each(doc, '$.requests') WHERE json_extract(value, '$.response.status_code') = ?)")
+		args = append(args, query.StatusCode)
+	}
+
+	if query.GRPCCode != nil {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM json_each(doc, '$.requests') WHERE json_extract(value, '$.response.grpc_code') = ?)")
+		args = append(args, *query.GRPCCode)
+	}
+
+	if query.URLContains != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM json_each(doc, '$.requests') WHERE instr(json_extract(value, '$.request.url'), ?) > 0)")
+		args = append(args, query.URLContains)
+	}
+
+	const hasError = "EXISTS (SELECT 1 FROM json_each(doc, '$.requests') WHERE coalesce(json_extract(value, '$.error'), '') <> '')"
+
+	switch query.Errors {
+	case ErrorsOnly:
+		conditions = append(conditions, hasError)
+	case ErrorsNone:
+		conditions = append(conditions, "NOT "+hasError)
+	}
+
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, query.Since.UnixNano())
+	}
+
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, query.Until.UnixNano())
+	}
+
+	limit := -1
+	if query.Limit > 0 {
+		limit = query.Limit
+	}
+	args = append(args, limit, max(query.Offset, 0))
+
+	// Entries are stored as BLOBs, which the JSON functions would read as JSONB,
+	// so the filters run on a text copy.
+	rows, err := sb.db.Query(
+		"SELECT id, created_at, data FROM (SELECT seq, id, created_at, data, CAST(data AS TEXT) AS doc FROM history) WHERE "+
+			strings.Join(conditions, " AND ")+" ORDER BY seq DESC LIMIT ? OFFSET ?",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to query history: %w", errors.ErrStorageError, err)
+	}
+	defer rows.Close()
+
+	entries := []HistoryEntry{}
+	for rows.Next() {
+		var (
+			entry     HistoryEntry
+			createdAt int64
+			data      []byte
+		)
+
+		if err := rows.Scan(&entry.ID, &createdAt, &data); err != nil {
+			return nil, fmt.Errorf("%w: failed to query history: %w", errors.ErrStorageError, err)
+		}
+
+		if err := json.Unmarshal(data, &entry.Result); err != nil {
+			return nil, fmt.Errorf("failed to parse history entry: %w", err)
+		}
+
+		entry.Time = time.Unix(0, createdAt)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: failed to query history: %w", errors.ErrStorageError, err)
+	}
+
+	return entries, nil
+}
+
+// PruneHistory removes the history entries outside policy within a transaction.
+func (sb *SQLiteBackend) PruneHistory(policy RetentionPolicy) (int, error) {
+	if policy.IsZero() {
+		return 0, nil
+	}
+
+	tx, err := sb.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to begin transaction: %w", errors.ErrStorageError, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT id, created_at, length(data) FROM history ORDER BY seq DESC")
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to read history: %w", errors.ErrStorageError, err)
+	}
+
+	var infos []historyInfo
+	for rows.Next() {
+		var (
+			info      historyInfo
+			createdAt int64
+		)
+
+		if err := rows.Scan(&info.id, &createdAt, &info.size); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%w: failed to read history: %w", errors.ErrStorageError, err)
+		}
+
+		info.time = time.Unix(0, createdAt)
+		infos = append(infos, info)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%w: failed to read history: %w", errors.ErrStorageError, err)
+	}
+
+	expired := expiredEntries(infos, policy, time.Now())
+	for _, id := range expired {
+		if _, err := tx.Exec("DELETE FROM history WHERE id = ?", id); err != nil {
+			return 0, fmt.Errorf("%w: failed to prune history: %w", errors.ErrStorageError, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%w: failed to commit transaction: %w", errors.ErrStorageError, err)
+	}
+
+	return len(expired), nil
+}
+
+// ClearHistory removes all history entries.
+func (sb *SQLiteBackend) ClearHistory() error {
+	if _, err := sb.db.Exec("DELETE FROM history"); err != nil {
+		return fmt.Errorf("%w: failed to clear history: %w", errors.ErrStorageError, err)
+	}
+
+	return nil
+}
+
+// SaveLogs stores a batch of log entries under a unique timestamp ID.
+func (sb *SQLiteBackend) SaveLogs(logs []types.LogEntry) error {
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal logs: %w", err)
+	}
+
+	return sb.saveEntry("logs", data)
+}
+
+// LoadLogs loads a batch of log entries by its ID.
+func (sb *SQLiteBackend) LoadLogs(id string) ([]types.LogEntry, error) {
+	data, err := sb.loadEntry("logs", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []types.LogEntry
+	if err := json.Unmarshal(data, &logs); err != nil {
+		return nil, fmt.Errorf("failed to parse logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// ListLogs returns the IDs of the log batches, most recent first.
+func (sb *SQLiteBackend) ListLogs() ([]string, error) {
+	return sb.listEntries("logs")
+}
+
+// LoadConfig returns the stored configuration, or nil when none was saved.
+func (sb *SQLiteBackend) LoadConfig() ([]byte, error) {
+	var data []byte
+	err := sb.db.QueryRow("SELECT data FROM config WHERE id = 1").Scan(&data)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read config: %w", errors.ErrStorageError, err)
+	}
+
+	return data, nil
+}
+
+// SaveConfig stores the configuration.
+func (sb *SQLiteBackend) SaveConfig(data []byte) error {
+	_, err := sb.db.Exec("INSERT INTO config (id, data) VALUES (1, ?) ON CONFLICT (id) DO UPDATE SET data = excluded.data", data)
+
+	if err != nil {
+		return fmt.Errorf("%w: failed to write config: %w", errors.ErrStorageError, err)
+	}
+
+	return nil
+}
+
+// Close closes the database.
+func (sb *SQLiteBackend) Close() error {
+	return sb.db.Close()
+}
+
+// The table names below are constants of this file, never user input.
+
+func (sb *SQLiteBackend) loadDocument(table, name string) ([]byte, error) {
+	var data []byte
+	err := sb.db.QueryRow("SELECT data FROM "+table+" WHERE name = ?", name).Scan(&data)
+
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: failed to read %s: %w", errors.ErrStorageError, table, err)
+	}
+
+	return data, err
+}
+
+func (sb *SQLiteBackend) saveDocument(table, name string, data []byte) error {
+	query := "INSERT INTO " + table + " (name, data) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET data = excluded.data"
+
+	if _, err := sb.db.Exec(query, name, data); err != nil {
+		return fmt.Errorf("%w: failed to write %s: %w", errors.ErrStorageError, table, err)
+	}
+
+	return nil
+}
+
+func (sb *SQLiteBackend) listDocuments(table string) ([]string, error) {
+	return sb.queryStrings("SELECT name FROM " + table + " ORDER BY name")
+}
+
+func (sb *SQLiteBackend) deleteDocument(table, name string) (bool, error) {
+	res, err := sb.db.Exec("DELETE FROM "+table+" WHERE name = ?", name)
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to delete from %s: %w", errors.ErrStorageError, table, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to delete from %s: %w", errors.ErrStorageError, table, err)
+	}
+
+	return count > 0, nil
+}
+
+// saveEntry adds a history or log entry under the first free ID.
+func (sb *SQLiteBackend) saveEntry(table string, data []byte) error {
+	now := time.Now()
+	query := "INSERT OR IGNORE INTO " + table + " (id, created_at, data) VALUES (?, ?, ?)"
+
+	for attempt := 0; ; attempt++ {
+		res, err := sb.db.Exec(query, storage.EntryID(now, attempt), now.UnixNano(), data)
+		if err != nil {
+			return fmt.Errorf("%w: failed to write %s entry: %w", errors.ErrStorageError, table, err)
+		}
+
+		if count, err := res.RowsAffected(); err != nil || count > 0 {
+			return err
+		}
+	}
+}
+
+func (sb *SQLiteBackend) loadEntry(table, id string) ([]byte, error) {
+	var data []byte
+	err := sb.db.QueryRow("SELECT data FROM "+table+" WHERE id = ?", id).Scan(&data)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s entry %s not found", errors.ErrStorageError, table, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read %s entry: %w", errors.ErrStorageError, table, err)
+	}
+
+	return data, nil
+}
+
+func (sb *SQLiteBackend) listEntries(table string) ([]string, error) {
+	return sb.queryStrings("SELECT id FROM " + table + " ORDER BY seq DESC")
+}
+
+func (sb *SQLiteBackend) queryStrings(query string) ([]string, error) {
+	rows, err := sb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrStorageError, err)
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrStorageError, err)
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrStorageError, err)
+	}
+
+	return values, nil
+}
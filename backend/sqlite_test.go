@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func newTestSQLiteBackend(t *testing.T) *SQLiteBackend {
+	t.Helper()
+
+	sqliteBackend, err := NewSQLiteBackend(":memory:")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { sqliteBackend.Close() })
+
+	return sqliteBackend
+}
+
+func TestUnitSQLiteBackend_Collections(t *testing.T) {
+	testBackendCollections(t, newTestSQLiteBackend(t), true)
+}
+
+func TestUnitSQLiteBackend_Environments(t *testing.T) {
+	testBackendEnvironments(t, newTestSQLiteBackend(t), true)
+}
+
+func TestUnitSQLiteBackend_UpdateEnvironment(t *testing.T) {
+	testBackendUpdateEnvironment(t, newTestSQLiteBackend(t))
+}
+
+func TestUnitSQLiteBackend_History(t *testing.T) {
+	testBackendHistory(t, newTestSQLiteBackend(t))
+}
+
+func TestUnitSQLiteBackend_QueryHistory(t *testing.T) {
+	testBackendQueryHistory(t, newTestSQLiteBackend(t))
+}
+
+func TestUnitSQLiteBackend_PruneHistory(t *testing.T) {
+	testBackendPruneHistory(t, newTestSQLiteBackend(t))
+}
+
+func TestUnitSQLiteBackend_Logs(t *testing.T) {
+	testBackendLogs(t, newTestSQLiteBackend(t))
+}
+
+func TestUnitSQLiteBackend_Config(t *testing.T) {
+	testBackendConfig(t, newTestSQLiteBackend(t))
+}
+
+func TestUnitSQLiteBackend_Persistence(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	dbPath := filepath.Join(dir, "data", SQLiteFileName)
+	sqliteBackend, err := NewSQLiteBackend(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection := &collections.Collection{
+		Name: "api",
+		Items: []*types.RequestItem{
+			{Name: "List", Request: &types.Request{Method: "GET", URL: "http://example.com"}},
+		},
+	}
+	if err := sqliteBackend.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sqliteBackend.SaveHistory(&types.ExecutionResult{CollectionName: "api"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sqliteBackend.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sqliteBackend, err = NewSQLiteBackend(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sqliteBackend.Close()
+
+	loaded, err := sqliteBackend.LoadCollection("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].Name != "List" {
+		t.Errorf("unexpected collection: %+v", loaded)
+	}
+
+	ids, err := sqliteBackend.ListHistory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 1 {
+		t.Errorf("expected 1 history entry, got %v", ids)
+	}
+}
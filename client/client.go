@@ -6,16 +6,25 @@ package getman
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/KonnorFrik/getman/backend"
+	"github.com/KonnorFrik/getman/codegen"
 	"github.com/KonnorFrik/getman/collections"
 	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/diff"
 	"github.com/KonnorFrik/getman/environment"
 	"github.com/KonnorFrik/getman/formatter"
 	"github.com/KonnorFrik/getman/importer"
+	"github.com/KonnorFrik/getman/logging"
+	"github.com/KonnorFrik/getman/report"
+	"github.com/KonnorFrik/getman/snapshot"
 	"github.com/KonnorFrik/getman/storage"
 	"github.com/KonnorFrik/getman/types"
 )
@@ -24,52 +33,72 @@ import (
 // It provides methods for managing collections, environments, executing requests,
 // and handling storage operations.
 type Client struct {
-	storage            *storage.FileStorage
-	historyStorage     *storage.HistoryStorage
-	logStorage         *storage.LogStorage
+	backend            backend.Backend
 	httpClient         *core.HTTPClient
 	collectionExecutor *collections.CollectionExecutor
 	variableResolver *core.VariableResolver
 	config           *Config
+	logger           *slog.Logger
+	logOutput        io.Writer
+	customLogger     bool
+
+	// The retention state of the history, see applyRetention.
+	historyMu       sync.Mutex
+	historyCount    int
+	historyCounted  bool
+	historyPrunedAt time.Time
 }
 
 const globalEnvName = "global"
 
+// historyPruneInterval is the least time between two prunes of the history for the
+// MaxAge and MaxSize limits of Config.History.
+const historyPruneInterval = time.Minute
+
 // NewClient creates a new Client instance with the specified base path for storage.
-// It initializes all required components including file storage, history storage,
-// log storage, HTTP client, and variable resolver.
+// Collections, environments, history and logs are stored as files under the base path.
 func NewClient(basePath string) (*Client, error) {
-	var (
-		client Client
-		globalEnv = environment.NewEnvironment(globalEnvName)
-	)
-
-	fileStorage, err := storage.NewFileStorage(basePath)
+	fileBackend, err := backend.NewFileBackend(basePath)
 
 	if err != nil {
 		return nil, err
 	}
 
-	client.storage = fileStorage
+	return NewClientWithBackend(fileBackend)
+}
+
+// NewClientWithBackend creates a new Client instance on top of a storage backend,
+// such as backend.NewMemoryBackend for tests or backend.NewSQLiteBackend for large
+// histories. It initializes all required components including the HTTP client and
+// variable resolver, using the configuration stored in the backend.
+func NewClientWithBackend(b backend.Backend) (*Client, error) {
+	if b == nil {
+		return nil, fmt.Errorf("%w: 'backend' is nil", ErrInvalidArgument)
+	}
+
+	var (
+		client Client
+		globalEnv = environment.NewEnvironment(globalEnvName)
+	)
+
+	client.backend = b
 	config := DefaultConfig()
-	configPath := fileStorage.ConfigPath()
 
-	if _, err := os.Stat(configPath); err == nil {
-		loadedConfig, err := LoadConfig(configPath)
+	if data, err := b.LoadConfig(); err == nil && data != nil {
+		loadedConfig, err := parseConfig(data)
 
 		if err == nil {
 			config = loadedConfig
 		}
 	}
 
-	historyStorage := storage.NewHistoryStorage(fileStorage)
-	logStorage := storage.NewLogStorage(fileStorage)
 	variableResolver, err := core.NewVariableResolver(globalEnv, nil)
 
 	if err != nil {
 		return nil, err
 	}
 
+	client.variableResolver = variableResolver
 	client.LoadGlobalEnvironment()
 
 	// if err != nil {
@@ -80,14 +109,20 @@ func NewClient(basePath string) (*Client, error) {
 	readTimeout := config.Defaults.Timeout.Read
 	autoManageCookies := config.Defaults.Cookies.AutoManage
 	httpClient := core.NewHTTPClient(connectTimeout, readTimeout, autoManageCookies)
+
+	if err := httpClient.SetProxy(config.Defaults.Proxy.settings()); err != nil {
+		return nil, err
+	}
+
+	httpClient.SetMaxBodySize(config.Defaults.MaxBodySize)
+
 	collectionExecutor := collections.NewCollectionExecutor(httpClient, variableResolver)
 
-	client.historyStorage = historyStorage
-	client.logStorage = logStorage
-	client.variableResolver = variableResolver
 	client.httpClient = httpClient
 	client.collectionExecutor = collectionExecutor
 	client.config = config
+	client.applyStorageConfig()
+	client.applyLoggingConfig()
 
 	return &client, nil
 }
@@ -115,8 +150,7 @@ func NewClientWithDefaults() (*Client, error) {
 
 // LoadLocalEnvironment loads a local environment by name from storage.
 func (c *Client) LoadLocalEnvironment(name string) error {
-	filePath := filepath.Join(c.storage.EnvironmentsDir(), fmt.Sprintf("%s.json", name))
-	env, err := environment.NewEnvironmentFromFile(filePath)
+	env, err := c.backend.LoadEnvironment(name)
 
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrEnvironmentNotFound, name)
@@ -129,8 +163,7 @@ func (c *Client) LoadLocalEnvironment(name string) error {
 
 // LoadGlobalEnvironment loads the global environment from storage.
 func (c *Client) LoadGlobalEnvironment() error {
-	filePath := filepath.Join(c.storage.EnvironmentsDir(), fmt.Sprintf("%s.json", globalEnvName))
-	env, err := environment.NewEnvironmentFromFile(filePath)
+	env, err := c.backend.LoadEnvironment(globalEnvName)
 
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrEnvironmentNotFound, globalEnvName)
@@ -149,8 +182,7 @@ func (c *Client) SaveEnvironments() error {
 	)
 
 	if localEnv != nil {
-		filePath := filepath.Join(c.storage.EnvironmentsDir(), fmt.Sprintf("%s.json", localEnv.Name))
-		err := localEnv.Save(filePath)
+		err := c.backend.SaveEnvironment(localEnv)
 
 		if err != nil {
 			return err
@@ -158,8 +190,7 @@ func (c *Client) SaveEnvironments() error {
 	}
 
 	if globalEnv != nil {
-		filePath := filepath.Join(c.storage.EnvironmentsDir(), fmt.Sprintf("%s.json", globalEnv.Name))
-		err := globalEnv.Save(filePath)
+		err := c.backend.SaveEnvironment(globalEnv)
 
 		if err != nil {
 			return err
@@ -175,8 +206,7 @@ func (c *Client) SaveEnvironment(env *Environment) error {
 		return fmt.Errorf("%w: 'env' is nil", ErrInvalidArgument)
 	}
 
-	filePath := filepath.Join(c.storage.EnvironmentsDir(), fmt.Sprintf("%s.json", env.Name))
-	err := env.Save(filePath)
+	err := c.backend.SaveEnvironment(env)
 
 	if err != nil {
 		return err
@@ -192,37 +222,48 @@ func (c *Client) SaveEnvironment(env *Environment) error {
 	return nil
 }
 
-// ListEnvironments returns a list of all available environment names.
-func (c *Client) ListEnvironments() ([]string, error) {
-	dir := c.storage.EnvironmentsDir()
-	entries, err := os.ReadDir(dir)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to read environments directory: %w", err)
+// UpdateEnvironment applies update to the stored environment, or to a new empty one,
+// and saves the result. Concurrent updates, also from other getman processes sharing
+// the storage, do not lose each other's changes. A loaded environment with the same
+// name is replaced by the updated one.
+func (c *Client) UpdateEnvironment(name string, update func(env *Environment) error) error {
+	if update == nil {
+		return fmt.Errorf("%w: 'update' is nil", ErrInvalidArgument)
 	}
 
-	var names []string
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
+	var updated *environment.Environment
+	err := c.backend.UpdateEnvironment(name, func(env *environment.Environment) error {
+		if err := update(env); err != nil {
+			return err
 		}
 
-		name := entry.Name()
+		updated = env
+		return nil
+	})
 
-		if len(name) > 5 && name[len(name)-5:] == ".json" {
-			names = append(names, name[:len(name)-5])
-		}
+	if err != nil {
+		return err
+	}
+
+	if localEnv := c.variableResolver.GetLocal(); localEnv != nil && localEnv.Name == name {
+		c.variableResolver.SetLocal(updated)
 	}
 
-	return names, nil
+	if globalEnv := c.variableResolver.GetGlobal(); globalEnv != nil && globalEnv.Name == name {
+		c.variableResolver.SetGlobal(updated)
+	}
+
+	return nil
+}
+
+// ListEnvironments returns a list of all available environment names.
+func (c *Client) ListEnvironments() ([]string, error) {
+	return c.backend.ListEnvironments()
 }
 
 // DeleteEnvironment deletes an environment by name.
 func (c *Client) DeleteEnvironment(name string) error {
-	filePath := filepath.Join(c.storage.EnvironmentsDir(), fmt.Sprintf("%s.json", name))
-
-	if err := os.Remove(filePath); err != nil {
+	if err := c.backend.DeleteEnvironment(name); err != nil {
 		return fmt.Errorf("%w: %s", ErrEnvironmentNotFound, name)
 	}
 
@@ -298,16 +339,13 @@ func (c *Client) ResolveVariables(template string) (string, error) {
 
 // LoadCollection loads a collection by name from storage.
 func (c *Client) LoadCollection(name string) (*collections.Collection, error) {
-	filePath := collections.GetCollectionPath(c.storage, name)
-	collection, err := collections.LoadCollectionFromFile(filePath)
-
-	fmt.Printf("LoadCollection: load: %s\n", name)
+	collection, err := c.backend.LoadCollection(name)
 
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrCollectionNotFound, name)
 	}
 
-	fmt.Printf("LoadCollection: loaded: %+v\n", collection)
+	c.logger.Debug("collection loaded", "collection", name, "items", len(collection.Items))
 
 	if collection.EnvName != "" {
 		err = c.LoadLocalEnvironment(collection.EnvName)
@@ -322,38 +360,24 @@ func (c *Client) LoadCollection(name string) (*collections.Collection, error) {
 
 // SaveCollection saves a collection to storage.
 func (c *Client) SaveCollection(collection *collections.Collection) error {
-	filePath := collections.GetCollectionPath(c.storage, collection.Name)
-	return collections.SaveCollectionToFile(collection, filePath)
+	if collection == nil {
+		return fmt.Errorf("%w: 'collection' is nil", ErrInvalidArgument)
+	}
+
+	return c.backend.SaveCollection(collection)
 }
 
 // ListCollections returns a list of all available collection names.
 func (c *Client) ListCollections() ([]string, error) {
-	dir := c.storage.CollectionsDir()
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read collections directory: %w", err)
-	}
-
-	var names []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if len(name) > 5 && name[len(name)-5:] == ".json" {
-			names = append(names, name[:len(name)-5])
-		}
-	}
-
-	return names, nil
+	return c.backend.ListCollections()
 }
 
 // DeleteCollection deletes a collection by name.
 func (c *Client) DeleteCollection(name string) error {
-	filePath := collections.GetCollectionPath(c.storage, name)
-	if err := os.Remove(filePath); err != nil {
+	if err := c.backend.DeleteCollection(name); err != nil {
 		return fmt.Errorf("%w: %s", ErrCollectionNotFound, name)
 	}
+
 	return nil
 }
 
@@ -362,6 +386,62 @@ func (c *Client) ImportFromPostman(filePath string) (*collections.Collection, er
 	return importer.ImportFromPostman(filePath)
 }
 
+// ImportFromCurl converts a curl command line into a request item that can be added
+// to a collection. Options that could not be applied are returned as warnings.
+func (c *Client) ImportFromCurl(command string) (*types.RequestItem, []string, error) {
+	return importer.ImportFromCurl(command)
+}
+
+// ImportFromOpenAPI imports an OpenAPI 3.x or Swagger 2.0 spec (JSON or YAML). The returned
+// environment holds the server URLs and is linked to the collection through EnvName.
+func (c *Client) ImportFromOpenAPI(filePath string) (*collections.Collection, *environment.Environment, error) {
+	return importer.ImportFromOpenAPI(filePath)
+}
+
+// ImportFromHAR imports the entries of a HAR file captured in browser developer tools
+// as a new collection. Static assets are dropped unless opts keeps them.
+func (c *Client) ImportFromHAR(filePath string, opts *HAROptions) (*collections.Collection, error) {
+	return importer.ImportFromHAR(filePath, opts)
+}
+
+// ImportFromInsomnia imports the workspaces and environments of an Insomnia v4 export.
+// Features that could not be imported are listed in the warnings of the result.
+func (c *Client) ImportFromInsomnia(filePath string) (*ImportResult, error) {
+	return importer.ImportFromInsomnia(filePath)
+}
+
+// ImportFromBruno imports a Bruno collection folder with its environments.
+// Features that could not be imported are listed in the warnings of the result.
+func (c *Client) ImportFromBruno(dirPath string) (*ImportResult, error) {
+	return importer.ImportFromBruno(dirPath)
+}
+
+// ImportFromHTTPFile imports a .http or .rest file of the JetBrains HTTP client or VS Code
+// REST Client, with the environments of an http-client.env.json next to it.
+func (c *Client) ImportFromHTTPFile(filePath string) (*ImportResult, error) {
+	return importer.ImportFromHTTPFile(filePath)
+}
+
+// ImportHTTPEnvironments imports the environments of an http-client.env.json file.
+func (c *Client) ImportHTTPEnvironments(filePath string) ([]*environment.Environment, error) {
+	return importer.ImportHTTPEnvironments(filePath)
+}
+
+// ExportToHTTPFile writes a collection as a .http file.
+func (c *Client) ExportToHTTPFile(collection *collections.Collection, filePath string) error {
+	return importer.ExportToHTTPFile(collection, filePath)
+}
+
+// ExportHTTPEnvironments writes environments to an http-client.env.json file.
+func (c *Client) ExportHTTPEnvironments(envs []*environment.Environment, filePath string) error {
+	return importer.ExportHTTPEnvironments(envs, filePath)
+}
+
+// ExportToHAR writes the requests of an execution result to a HAR 1.2 file.
+func (c *Client) ExportToHAR(result *types.ExecutionResult, filePath string) error {
+	return importer.ExportToHAR(result, filePath)
+}
+
 // ExportToPostman exports a collection to a Postman-compatible JSON file.
 func (c *Client) ExportToPostman(collection *collections.Collection, filePath string) error {
 	data, err := json.MarshalIndent(collection, "", "  ")
@@ -378,6 +458,12 @@ func (c *Client) ExportToPostman(collection *collections.Collection, filePath st
 
 // ExecuteRequest executes a single HTTP request and returns the execution result.
 func (c *Client) ExecuteRequest(req *types.Request) (*types.RequestExecution, error) {
+	return c.ExecuteRequestWithOptions(req, nil)
+}
+
+// ExecuteRequestWithOptions executes a single HTTP request, consuming the response body
+// according to the options (size limit, streaming sink, chunk callback).
+func (c *Client) ExecuteRequestWithOptions(req *types.Request, opts *ExecuteOptions) (*types.RequestExecution, error) {
 	if err := c.ValidateRequest(req); err != nil {
 		return nil, err
 	}
@@ -388,24 +474,57 @@ func (c *Client) ExecuteRequest(req *types.Request) (*types.RequestExecution, er
 	}
 
 	startTime := time.Now()
-	response, err := c.httpClient.Execute(resolvedReq)
+	exchange, err := c.httpClient.ExecuteExchange(resolvedReq, opts)
 	duration := time.Since(startTime)
 
 	execution := &types.RequestExecution{
 		Request:   resolvedReq,
+		Response:  exchange.Response,
+		Events:    exchange.Events,
+		Messages:  exchange.Messages,
 		Duration:  duration,
 		Timestamp: time.Now(),
 	}
 
 	if err != nil {
 		execution.Error = err.Error()
-	} else {
-		execution.Response = response
 	}
 
+	execution.Unresolved = req
+	c.recordExecution(execution)
 	return execution, nil
 }
 
+// ExecuteStream executes a Server-Sent Events request and delivers events on a channel
+// until one of the stop conditions in opts is reached.
+func (c *Client) ExecuteStream(req *types.Request, opts *StreamOptions) (*EventStream, error) {
+	if err := c.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
+	resolvedReq, err := c.resolveRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.httpClient.ExecuteStream(resolvedReq, opts)
+}
+
+// GenerateCode resolves the variables of a request against the current environment
+// and turns it into a code snippet in the given language.
+func (c *Client) GenerateCode(req *types.Request, lang CodeLanguage, opts *CodegenOptions) (string, error) {
+	if err := c.ValidateRequest(req); err != nil {
+		return "", err
+	}
+
+	resolvedReq, err := c.resolveRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	return codegen.Generate(resolvedReq, lang, opts)
+}
+
 // ExecuteCollection executes all requests in a collection by name.
 func (c *Client) ExecuteCollection(collectionName string) (*types.ExecutionResult, error) {
 	collection, err := c.LoadCollection(collectionName)
@@ -421,7 +540,10 @@ func (c *Client) ExecuteCollection(collectionName string) (*types.ExecutionResul
 		localEnvName = localEnv.Name
 	}
 
-	return c.collectionExecutor.ExecuteCollection(collection, localEnvName)
+	executor, saveLogs := c.runExecutor()
+	defer saveLogs()
+
+	return executor.ExecuteCollection(collection, localEnvName)
 }
 
 func (c *Client) ExecuteCollectionAsync(collectionName string) <-chan *types.RequestExecution {
@@ -438,7 +560,20 @@ func (c *Client) ExecuteCollectionAsync(collectionName string) <-chan *types.Req
 		localEnvName = localEnv.Name
 	}
 
-	return c.collectionExecutor.ExecuteCollectionAsync(collection, localEnvName)
+	executor, saveLogs := c.runExecutor()
+	executions := executor.ExecuteCollectionAsync(collection, localEnvName)
+	ch := make(chan *types.RequestExecution, 1)
+
+	go func() {
+		defer close(ch)
+		defer saveLogs()
+
+		for execution := range executions {
+			ch <- execution
+		}
+	}()
+
+	return ch
 }
 
 // ExecuteCollectionSelective executes only the specified requests from a collection.
@@ -456,12 +591,15 @@ func (c *Client) ExecuteCollectionSelective(collectionName string, itemNames []s
 		localEnvName = localEnv.Name
 	}
 
-	return c.collectionExecutor.ExecuteCollectionSelective(collection, localEnvName, itemNames)
+	executor, saveLogs := c.runExecutor()
+	defer saveLogs()
+
+	return executor.ExecuteCollectionSelective(collection, localEnvName, itemNames)
 }
 
 // ValidateRequest validates a request before execution, checking method, URL, and variables.
 func (c *Client) ValidateRequest(req *types.Request) error {
-	if req.Method == "" {
+	if req.Method == "" && !core.IsWebSocket(req) && !core.IsGRPC(req) {
 		return fmt.Errorf("%w: method is required", ErrInvalidRequest)
 	}
 
@@ -487,37 +625,291 @@ func (c *Client) ValidateRequest(req *types.Request) error {
 		}
 	}
 
+	if req.WebSocket != nil {
+		for _, step := range req.WebSocket.Steps {
+			if err := c.variableResolver.ValidateVariables(step.Send); err != nil {
+				return err
+			}
+
+			if err := c.variableResolver.ValidateVariables(step.Expect); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
-// GetHistory retrieves request execution history up to the specified limit.
+// GetHistory retrieves request executions from the most recent history entries,
+// up to the specified number of entries.
 func (c *Client) GetHistory(limit int) ([]*types.RequestExecution, error) {
-	return c.historyStorage.GetHistory(limit)
+	ids, err := c.backend.ListHistory()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > len(ids) {
+		limit = len(ids)
+	}
+
+	var allExecutions []*types.RequestExecution
+
+	for _, id := range ids[:max(limit, 0)] {
+		result, err := c.backend.LoadHistory(id)
+
+		if err != nil {
+			continue
+		}
+
+		allExecutions = append(allExecutions, result.Requests...)
+	}
+
+	return allExecutions, nil
 }
 
 // GetLastExecution retrieves the most recent execution result.
 func (c *Client) GetLastExecution() (*types.ExecutionResult, error) {
-	return c.historyStorage.GetLast()
+	ids, err := c.backend.ListHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no history files found")
+	}
+
+	return c.backend.LoadHistory(ids[0])
 }
 
 // GetLogs retrieves the most recent log entries as JSON bytes.
 func (c *Client) GetLogs() ([]byte, error) {
-	return c.logStorage.GetLast()
+	ids, err := c.backend.ListLogs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no log files found")
+	}
+
+	logs, err := c.backend.LoadLogs(ids[0])
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(logs, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal logs: %w", err)
+	}
+
+	return data, nil
 }
 
 // ClearHistory removes all stored execution history.
 func (c *Client) ClearHistory() error {
-	return c.historyStorage.Clear()
+	if err := c.backend.ClearHistory(); err != nil {
+		return err
+	}
+
+	c.historyMu.Lock()
+	c.historyCount, c.historyCounted = 0, true
+	c.historyMu.Unlock()
+	return nil
 }
 
-// SaveHistory saves an execution result to history storage.
+// SaveHistory saves an execution result to history storage and applies the
+// retention policy of Config.History when one of its limits may be exceeded.
 func (c *Client) SaveHistory(result *types.ExecutionResult) error {
-	return c.historyStorage.Save(result)
+	if err := c.backend.SaveHistory(result); err != nil {
+		return err
+	}
+
+	return c.applyRetention()
+}
+
+// applyRetention prunes the history after an entry was saved. Pruning scans the whole
+// history, so it runs when the number of entries exceeds MaxEntries, and for MaxAge
+// and MaxSize at most every historyPruneInterval. The number of entries is counted
+// once and then tracked; entries saved by other clients are found by the next prune.
+func (c *Client) applyRetention() error {
+	policy := c.config.History.retention()
+	if policy.IsZero() {
+		return nil
+	}
+
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	c.historyCount++
+
+	due := !c.historyCounted ||
+		(policy.MaxCount > 0 && c.historyCount > policy.MaxCount) ||
+		((policy.MaxAge > 0 || policy.MaxSize > 0) && time.Since(c.historyPrunedAt) >= historyPruneInterval)
+	if !due {
+		return nil
+	}
+
+	if !c.historyCounted {
+		ids, err := c.backend.ListHistory()
+		if err != nil {
+			return err
+		}
+		c.historyCount, c.historyCounted = len(ids), true
+	}
+
+	removed, err := c.PruneHistory()
+	c.historyCount -= removed
+	c.historyPrunedAt = time.Now()
+	return err
+}
+
+// resetRetention makes the next saved entry apply the retention policy, as after a
+// change of the policy.
+func (c *Client) resetRetention() {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	c.historyCounted = false
+	c.historyPrunedAt = time.Time{}
+}
+
+// ReplayHistory executes the requests of a history entry again. With resolve false
+// the requests are sent exactly as recorded; with resolve true their unresolved form
+// is resolved against the current environments. Requests recorded without their
+// unresolved form are resolved as they were sent. The replay is recorded like a
+// single request execution.
+func (c *Client) ReplayHistory(id string, resolve bool) (*types.ExecutionResult, error) {
+	entry, err := c.backend.LoadHistory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*types.RequestItem
+
+	for _, execution := range entry.Requests {
+		if execution == nil || execution.Request == nil {
+			continue
+		}
+
+		req := execution.Request
+		if resolve && execution.Unresolved != nil {
+			req = execution.Unresolved
+		}
+
+		items = append(items, &types.RequestItem{Name: execution.Name, Request: req})
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: history entry %s has no requests", ErrInvalidArgument, id)
+	}
+
+	envName := entry.Environment
+	if resolve {
+		envName = c.currentEnvironmentName()
+	}
+
+	executor, saveLogs := c.runExecutor()
+	result := executor.ExecuteRequests(entry.CollectionName, envName, items, resolve)
+	saveLogs()
+
+	if c.config.History.RecordRequests {
+		// Recording is best effort, as for single requests.
+		if err := c.SaveHistory(result); err != nil {
+			c.logger.Warn("failed to record history", "error", err)
+		}
+	}
+
+	return result, nil
+}
+
+// DiffHistory compares two history entries, such as two runs of a collection, by
+// their IDs.
+func (c *Client) DiffHistory(beforeID, afterID string, opts *DiffOptions) (*ResultDiff, error) {
+	before, err := c.backend.LoadHistory(beforeID)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := c.backend.LoadHistory(afterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Results(before, after, opts), nil
+}
+
+// EnableSnapshots turns on snapshot testing of collection runs. The response body of
+// every successfully executed item is compared with its snapshot, stored under the
+// snapshots directory of the file backend; missing snapshots are recorded, and
+// mismatches fail the execution and are reported in RequestExecution.Snapshot. With
+// opts.Update set the snapshots are replaced instead. Failed items are skipped.
+func (c *Client) EnableSnapshots(opts *SnapshotOptions) error {
+	fileBackend, ok := c.backend.(*backend.FileBackend)
+	if !ok {
+		return fmt.Errorf("%w: snapshots require a file backend", ErrInvalidArgument)
+	}
+
+	c.collectionExecutor.SetSnapshots(snapshot.NewStore(fileBackend.Storage().SnapshotsDir()), opts)
+	return nil
+}
+
+// DisableSnapshots turns off snapshot testing of collection runs.
+func (c *Client) DisableSnapshots() {
+	c.collectionExecutor.SetSnapshots(nil, nil)
+}
+
+// QueryHistory returns the history entries matching query, most recent first. Unlike
+// GetHistory, each entry keeps the execution result it was saved with.
+func (c *Client) QueryHistory(query HistoryQuery) ([]HistoryEntry, error) {
+	return c.backend.QueryHistory(query)
+}
+
+// PruneHistory removes the history entries outside the retention policy of
+// Config.History and returns their number.
+func (c *Client) PruneHistory() (int, error) {
+	return c.backend.PruneHistory(c.config.History.retention())
 }
 
 // SaveLogs saves log entries to storage.
 func (c *Client) SaveLogs(logs []types.LogEntry) error {
-	return c.logStorage.Save(logs)
+	return c.backend.SaveLogs(logs)
+}
+
+// SetLogger sets the logger of the client, also used by its HTTP client and collection
+// executor, in place of the logger built from Config.Logging. A nil logger disables
+// logging.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = logging.Discard()
+	}
+
+	c.customLogger = true
+	c.setLogger(logger)
+}
+
+// SetLogOutput sets where the logger built from Config.Logging writes, and drops a
+// logger set with SetLogger. Logs are discarded until an output is set; a nil writer
+// discards them again.
+func (c *Client) SetLogOutput(w io.Writer) {
+	c.logOutput = w
+	c.customLogger = false
+	c.applyLoggingConfig()
+}
+
+// Logger returns the logger of the client.
+func (c *Client) Logger() *slog.Logger {
+	return c.logger
+}
+
+// Backend returns the storage backend of the client.
+func (c *Client) Backend() backend.Backend {
+	return c.backend
+}
+
+// Close releases the resources held by the storage backend.
+func (c *Client) Close() error {
+	return c.backend.Close()
 }
 
 // GetConfig returns the current client configuration.
@@ -531,9 +923,107 @@ func (c *Client) UpdateConfig(config *Config) error {
 		return err
 	}
 
+	if err := c.httpClient.SetProxy(config.Defaults.Proxy.settings()); err != nil {
+		return err
+	}
+
+	c.httpClient.SetMaxBodySize(config.Defaults.MaxBodySize)
+
+	data, err := marshalConfig(config)
+	if err != nil {
+		return err
+	}
+
 	c.config = config
-	configPath := c.storage.ConfigPath()
-	return SaveConfig(config, configPath)
+	c.applyStorageConfig()
+	c.applyLoggingConfig()
+	c.resetRetention()
+	return c.backend.SaveConfig(data)
+}
+
+// applyLoggingConfig creates the logger of the client from the level and format of the
+// config, unless a logger was set with SetLogger. Without a log output the logger
+// discards everything.
+func (c *Client) applyLoggingConfig() {
+	if c.customLogger {
+		return
+	}
+
+	if c.logOutput == nil {
+		c.setLogger(logging.Discard())
+		return
+	}
+
+	logger, err := logging.New(c.logOutput, c.config.Logging.Level, c.config.Logging.Format)
+	if err != nil {
+		// Only validated configs are applied; keep logging rather than fail.
+		logger = slog.New(slog.NewTextHandler(c.logOutput, &slog.HandlerOptions{ReplaceAttr: logging.RedactAttr}))
+	}
+
+	c.setLogger(logger)
+}
+
+func (c *Client) setLogger(logger *slog.Logger) {
+	c.logger = logger
+	c.httpClient.SetLogger(logger)
+	c.collectionExecutor.SetLogger(logger)
+}
+
+// runExecutor returns the collection executor for a run and a function saving the
+// log entries of the run to the backend when Config.Logging.Persist is set. Saving
+// the entries is best effort, like recording history.
+func (c *Client) runExecutor() (*collections.CollectionExecutor, func()) {
+	if !c.config.Logging.Persist {
+		return c.collectionExecutor, func() {}
+	}
+
+	level, err := logging.ParseLevel(c.config.Logging.Level)
+	if err != nil {
+		level = slog.LevelInfo
+	}
+
+	recorder := logging.NewRecorder(level)
+	executor := c.collectionExecutor.WithLogger(slog.New(logging.Tee(c.logger.Handler(), recorder)))
+
+	return executor, func() {
+		entries := recorder.Entries()
+		if len(entries) == 0 {
+			return
+		}
+
+		if err := c.backend.SaveLogs(entries); err != nil {
+			c.logger.Warn("failed to save logs", "error", err)
+		}
+	}
+}
+
+// recordExecution saves a single request execution to history when
+// Config.History.RecordRequests is set. Recording is best effort: a failure to save
+// the entry does not fail the request.
+func (c *Client) recordExecution(execution *types.RequestExecution) {
+	if !c.config.History.RecordRequests {
+		return
+	}
+
+	if err := c.SaveHistory(collections.NewRequestResult("", c.currentEnvironmentName(), execution)); err != nil {
+		c.logger.Warn("failed to record history", "error", err)
+	}
+}
+
+// currentEnvironmentName returns the name of the local environment, or "" when none is loaded.
+func (c *Client) currentEnvironmentName() string {
+	if localEnv := c.variableResolver.GetLocal(); localEnv != nil {
+		return localEnv.Name
+	}
+
+	return ""
+}
+
+// applyStorageConfig passes the collection layout of the config to a file backend.
+func (c *Client) applyStorageConfig() {
+	if fileBackend, ok := c.backend.(*backend.FileBackend); ok {
+		fileBackend.SetCollectionLayout(c.config.Storage.Layout, storage.Format(c.config.Storage.Format))
+	}
 }
 
 func (c *Client) resolveRequest(req *types.Request) (*types.Request, error) {
@@ -548,13 +1038,25 @@ func (c *Client) resolveRequest(req *types.Request) (*types.Request, error) {
 	}
 
 	resolvedReq := &types.Request{
-		Method:  req.Method,
-		URL:     resolvedURL,
-		Headers: resolvedHeaders,
-		Body:    req.Body,
-		Auth:    req.Auth,
-		Timeout: req.Timeout,
-		Cookies: req.Cookies,
+		Method:       req.Method,
+		URL:          resolvedURL,
+		Headers:      resolvedHeaders,
+		Body:         req.Body,
+		Auth:         req.Auth,
+		Timeout:      req.Timeout,
+		Cookies:      req.Cookies,
+		Proxy:        req.Proxy,
+		TLS:          req.TLS,
+		ResponseBody: req.ResponseBody,
+		Stream:       req.Stream,
+		GRPC:         req.GRPC,
+	}
+
+	if req.WebSocket != nil {
+		resolvedReq.WebSocket, err = c.variableResolver.ResolveWebSocket(req.WebSocket)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if req.Body != nil && len(req.Body.Content) > 0 {
@@ -566,7 +1068,18 @@ func (c *Client) resolveRequest(req *types.Request) (*types.Request, error) {
 			Type:        req.Body.Type,
 			Content:     []byte(resolvedBodyContent),
 			ContentType: req.Body.ContentType,
+			GraphQL:     req.Body.GraphQL,
+		}
+	}
+
+	if req.Body != nil && req.Body.GraphQL != nil {
+		resolvedGraphQL, err := c.variableResolver.ResolveGraphQL(req.Body.GraphQL)
+		if err != nil {
+			return nil, err
 		}
+		resolvedBody := *resolvedReq.Body
+		resolvedBody.GraphQL = resolvedGraphQL
+		resolvedReq.Body = &resolvedBody
 	}
 
 	if req.Auth != nil {
@@ -621,6 +1134,17 @@ func FormatResponse(resp *types.Response) string {
 	return formatter.FormatResponse(resp)
 }
 
+// FormatBody formats a body for display by the renderer of its content type.
+func FormatBody(body []byte, contentType string) string {
+	return formatter.FormatBody(body, contentType)
+}
+
+// RegisterBodyRenderer registers a renderer for bodies of a media type, a "+suffix"
+// or a "type/*" wildcard, used by FormatResponse and PrintResponse.
+func RegisterBodyRenderer(pattern string, renderer BodyRenderer) {
+	formatter.RegisterBodyRenderer(pattern, renderer)
+}
+
 // FormatRequest formats a request as a string for display.
 func FormatRequest(req *types.Request) string {
 	return formatter.FormatRequest(req)
@@ -636,6 +1160,73 @@ func FormatStatistics(stats *types.Statistics) string {
 	return formatter.FormatStatistics(stats)
 }
 
+// FormatTimings formats request phase timings as a text waterfall.
+func FormatTimings(timings *types.Timings) string {
+	return formatter.FormatTimings(timings)
+}
+
+// PrintTimings prints request phase timings as a colored waterfall to stdout.
+func PrintTimings(timings *types.Timings) {
+	formatter.PrintTimings(timings)
+}
+
+// FormatSnippet formats a resolved request as a code snippet with masked credentials.
+func FormatSnippet(req *types.Request, lang CodeLanguage) (string, error) {
+	return formatter.FormatSnippet(req, lang)
+}
+
+// PrintSnippet prints a resolved request as a code snippet with masked credentials to stdout.
+func PrintSnippet(req *types.Request, lang CodeLanguage) error {
+	return formatter.PrintSnippet(req, lang)
+}
+
+// DiffResults compares two execution results, such as two runs of a collection.
+func DiffResults(before, after *types.ExecutionResult, opts *DiffOptions) *ResultDiff {
+	return diff.Results(before, after, opts)
+}
+
+// DiffResponses compares two responses.
+func DiffResponses(before, after *types.Response, opts *DiffOptions) *ResponseDiff {
+	return diff.Responses(before, after, opts)
+}
+
+// NewReporter returns a reporter of a format: "junit", "json", "tap" or "html".
+func NewReporter(format string) (Reporter, error) {
+	return report.New(format)
+}
+
+// ParseReportOutput parses a report output in the form "format:path", e.g.
+// "junit:report.xml".
+func ParseReportOutput(spec string) (ReportOutput, error) {
+	return report.ParseOutput(spec)
+}
+
+// WriteReports writes the reports of execution results to the files of the outputs,
+// such as JUnit XML and TAP reports for CI.
+func WriteReports(outputs []ReportOutput, results ...*types.ExecutionResult) error {
+	return report.WriteFiles(outputs, results...)
+}
+
+// FormatResultDiff formats the difference between two execution results as text.
+func FormatResultDiff(d *ResultDiff) string {
+	return formatter.FormatResultDiff(d)
+}
+
+// PrintResultDiff prints the difference between two execution results to stdout with colors.
+func PrintResultDiff(d *ResultDiff) {
+	formatter.PrintResultDiff(d)
+}
+
+// FormatResponseDiff formats the difference between two responses as text.
+func FormatResponseDiff(d *ResponseDiff) string {
+	return formatter.FormatResponseDiff(d)
+}
+
+// PrintResponseDiff prints the difference between two responses to stdout with colors.
+func PrintResponseDiff(d *ResponseDiff) {
+	formatter.PrintResponseDiff(d)
+}
+
 // PrintResponse prints a formatted response to stdout.
 func PrintResponse(resp *types.Response) {
 	formatter.PrintResponse(resp)
@@ -388,3 +388,72 @@ func TestIntegrationCollectionManagement(t *testing.T) {
 	}
 }
 
+
+func TestIntegrationExecuteRequest_ReplayHistory(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.SetGlobalVariable("baseUrl", http_server.GetServerURL())
+	client.SetGlobalVariable("code", "201")
+
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    "{{baseUrl}}/status/{{code}}",
+	}
+
+	if _, err := client.ExecuteRequest(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := client.QueryHistory(HistoryQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected the execution to be recorded, got %d entries", len(entries))
+	}
+
+	recorded := entries[0].Result.Requests[0]
+	if recorded.Unresolved == nil || recorded.Unresolved.URL != req.URL {
+		t.Errorf("expected unresolved request to be recorded, got %+v", recorded.Unresolved)
+	}
+	if recorded.Request.URL != http_server.GetServerURL()+"/status/201" {
+		t.Errorf("expected resolved request to be recorded, got %s", recorded.Request.URL)
+	}
+
+	client.SetGlobalVariable("code", "202")
+
+	asSent, err := client.ReplayHistory(entries[0].ID, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := asSent.Requests[0].Response.StatusCode; status != http.StatusCreated {
+		t.Errorf("expected replay as sent to return 201, got %d", status)
+	}
+
+	resolved, err := client.ReplayHistory(entries[0].ID, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := resolved.Requests[0].Response.StatusCode; status != http.StatusAccepted {
+		t.Errorf("expected re-resolved replay to return 202, got %d", status)
+	}
+
+	if entries, _ := client.QueryHistory(HistoryQuery{}); len(entries) != 3 {
+		t.Errorf("expected replays to be recorded, got %d entries", len(entries))
+	}
+}
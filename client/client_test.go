@@ -1,11 +1,21 @@
 package getman
 
 import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/KonnorFrik/getman/backend"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/snapshot"
 	"github.com/KonnorFrik/getman/testutil/helper"
 	"github.com/KonnorFrik/getman/testutil/fixture"
 	"github.com/KonnorFrik/getman/types"
@@ -39,6 +49,106 @@ func TestUnitNewClientWithDefaults(t *testing.T) {
 	}
 }
 
+func TestUnitNewClientWithBackend(t *testing.T) {
+	memoryBackend := backend.NewMemoryBackend()
+	if err := memoryBackend.SaveConfig([]byte(fixture.GetTestConfigYAML())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	global := environment.NewEnvironment("global")
+	global.Set("token", "abc")
+	if err := memoryBackend.SaveEnvironment(global); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client, err := NewClientWithBackend(memoryBackend)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if client.Backend() != memoryBackend {
+		t.Error("expected client to use the given backend")
+	}
+
+	if token, _ := client.GetGlobalVariable("token"); token != "abc" {
+		t.Errorf("expected global variable from backend, got %q", token)
+	}
+
+	collection := fixture.CreateTestCollection("test", []*types.RequestItem{
+		{
+			Name: "Test Request",
+			Request: &types.Request{
+				Method: "GET",
+				URL:    "http://example.com",
+			},
+		},
+	})
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names, err := client.ListCollections()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "test" {
+		t.Errorf("expected [test], got %v", names)
+	}
+
+	if err := client.UpdateConfig(DefaultConfig()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := memoryBackend.LoadConfig(); !strings.Contains(string(data), "base_path") {
+		t.Errorf("expected config saved to backend, got %q", data)
+	}
+}
+
+func TestUnitNewClientWithBackend_Nil(t *testing.T) {
+	if _, err := NewClientWithBackend(nil); err == nil {
+		t.Fatal("expected error for nil backend")
+	}
+}
+
+func TestUnitUpdateEnvironment(t *testing.T) {
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.SaveEnvironment(environment.NewEnvironment("dev")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.LoadLocalEnvironment("dev"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = client.UpdateEnvironment("dev", func(env *Environment) error {
+		env.Set("token", "new")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if token, _ := client.GetCurrentEnvironment().Get("token"); token != "new" {
+		t.Errorf("expected loaded environment to be refreshed, got %q", token)
+	}
+
+	stored, err := client.Backend().LoadEnvironment("dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := stored.Get("token"); token != "new" {
+		t.Errorf("expected stored environment to be updated, got %q", token)
+	}
+
+	if err := client.UpdateEnvironment("dev", nil); err == nil {
+		t.Error("expected error for nil update")
+	}
+}
+
 func TestUnitLoadEnvironment(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 	if err != nil {
@@ -354,6 +464,63 @@ func TestUnitListCollections(t *testing.T) {
 	}
 }
 
+func TestUnitListCollections_YAML(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collectionYAML := "name: api\nitems:\n  - name: health\n    request:\n      method: GET\n      url: http://example.com/health\n"
+	if err := os.WriteFile(filepath.Join(dir, "collections", "api.yaml"), []byte(collectionYAML), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	envYAML := "name: dev\nvariables:\n  token: abc\n"
+	if err := os.WriteFile(filepath.Join(dir, "environments", "dev.yml"), []byte(envYAML), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	names, err := client.ListCollections()
+	if err != nil || len(names) != 1 || names[0] != "api" {
+		t.Fatalf("expected collection api, got %v (%v)", names, err)
+	}
+
+	envs, err := client.ListEnvironments()
+	if err != nil || len(envs) != 1 || envs[0] != "dev" {
+		t.Fatalf("expected environment dev, got %v (%v)", envs, err)
+	}
+
+	collection, err := client.LoadCollection("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection.Items[0].Request.URL = "http://example.com/status"
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "collections", "api.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read collection: %v", err)
+	}
+	if !strings.Contains(string(data), "url: http://example.com/status") {
+		t.Errorf("expected collection to be saved as YAML, got:\n%s", data)
+	}
+
+	if err := client.LoadLocalEnvironment("dev"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := client.GetVariable("token"); token != "abc" {
+		t.Errorf("expected token from YAML environment, got %q", token)
+	}
+}
+
 func TestUnitDeleteCollection(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 	if err != nil {
@@ -377,6 +544,102 @@ func TestUnitDeleteCollection(t *testing.T) {
 	}
 }
 
+func TestUnitSaveCollection_DirectoryLayout(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := DefaultConfig()
+	config.Storage.Layout = LayoutDirectory
+	config.Storage.Format = "yaml"
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection := fixture.CreateTestCollection("test", []*types.RequestItem{
+		{
+			Name:   "Test Request",
+			Folder: "Users",
+			Request: &types.Request{
+				Method: "GET",
+				URL:    "http://example.com",
+			},
+		},
+	})
+
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requestPath := filepath.Join(dir, "collections", "test", "Users", "Test Request.yaml")
+	if _, err := os.Stat(requestPath); err != nil {
+		t.Fatalf("expected request file: %v", err)
+	}
+
+	loaded, err := client.LoadCollection("test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].Folder != "Users" {
+		t.Errorf("unexpected items: %+v", loaded.Items)
+	}
+
+	names, err := client.ListCollections()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "test" {
+		t.Errorf("expected [test], got %v", names)
+	}
+
+	if err := client.DeleteCollection("test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "collections", "test")); !os.IsNotExist(err) {
+		t.Error("expected collection directory to be removed")
+	}
+}
+
+func TestUnitSaveCollection_KeepsLayout(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection := fixture.CreateTestCollection("test", []*types.RequestItem{})
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.Storage.Layout = LayoutDirectory
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "collections", "test.json")); err != nil {
+		t.Errorf("expected collection file to be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "collections", "test")); !os.IsNotExist(err) {
+		t.Error("expected no collection directory")
+	}
+}
+
 func TestUnitValidateRequest_Valid(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 	if err != nil {
@@ -422,6 +685,75 @@ func TestUnitValidateRequest_Invalid(t *testing.T) {
 	}
 }
 
+func TestUnitValidateRequest_WebSocket(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := &types.Request{
+		URL: "ws://example.com/socket",
+		WebSocket: &types.WebSocketSettings{
+			Steps: []types.WebSocketStep{{Send: "hello"}},
+		},
+	}
+
+	if err := client.ValidateRequest(req); err != nil {
+		t.Fatalf("expected WebSocket request without method to be valid, got: %v", err)
+	}
+
+	req.WebSocket.Steps[0].Send = "{{missing}}"
+	if err := client.ValidateRequest(req); err == nil {
+		t.Fatal("expected error for missing variable in WebSocket message")
+	}
+}
+
+func TestUnitGenerateCode(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.SetGlobalVariable("resource", "users")
+	client.SetGlobalVariable("token", "token-1234567890")
+
+	req := &types.Request{
+		Method: "GET",
+		URL:    "https://api.example.com/{{resource}}",
+		Auth:   &types.Auth{Type: "bearer", Token: "{{token}}"},
+	}
+
+	snippet, err := client.GenerateCode(req, "curl", &CodegenOptions{MaskSecrets: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(snippet, "'https://api.example.com/users'") {
+		t.Errorf("expected resolved URL in snippet, got:\n%s", snippet)
+	}
+
+	if !strings.Contains(snippet, "Authorization: Bearer toke...7890") {
+		t.Errorf("expected masked token in snippet, got:\n%s", snippet)
+	}
+
+	req.URL = "https://api.example.com/{{missing}}"
+	if _, err := client.GenerateCode(req, "curl", nil); err == nil {
+		t.Error("expected error for missing variable")
+	}
+}
+
 func TestUnitExecuteRequest(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -459,6 +791,491 @@ func TestUnitExecuteRequest(t *testing.T) {
 	}
 }
 
+func TestUnitQueryHistory(t *testing.T) {
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	for _, name := range []string{"users", "orders", "users"} {
+		result := &types.ExecutionResult{
+			CollectionName: name,
+			Requests: []*types.RequestExecution{
+				{Request: &types.Request{Method: "GET", URL: "http://example.com/" + name}},
+			},
+		}
+		if err := client.SaveHistory(result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, err := client.QueryHistory(HistoryQuery{CollectionName: "users"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].ID == entries[1].ID || len(entries[0].Result.Requests) != 1 {
+		t.Errorf("expected distinct entries with their requests, got %+v", entries)
+	}
+}
+
+func TestUnitSaveHistory_Retention(t *testing.T) {
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	config := DefaultConfig()
+	config.History.MaxEntries = 2
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := client.SaveHistory(&types.ExecutionResult{CollectionName: "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	ids, err := client.Backend().ListHistory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Errorf("expected history to be pruned to 2 entries, got %d", len(ids))
+	}
+}
+
+// pruneCountingBackend counts the prunes of the history.
+type pruneCountingBackend struct {
+	*backend.MemoryBackend
+	prunes int
+}
+
+func (b *pruneCountingBackend) PruneHistory(policy backend.RetentionPolicy) (int, error) {
+	b.prunes++
+	return b.MemoryBackend.PruneHistory(policy)
+}
+
+func TestUnitSaveHistory_PrunesWhenExceeded(t *testing.T) {
+	b := &pruneCountingBackend{MemoryBackend: backend.NewMemoryBackend()}
+	client, err := NewClientWithBackend(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	config := DefaultConfig()
+	config.History.MaxEntries = 3
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := client.SaveHistory(&types.ExecutionResult{CollectionName: "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if b.prunes != 1 {
+		t.Errorf("expected a single prune within the limit, got %d", b.prunes)
+	}
+
+	if err := client.SaveHistory(&types.ExecutionResult{CollectionName: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.prunes != 2 {
+		t.Errorf("expected a prune once the limit is exceeded, got %d", b.prunes)
+	}
+	if ids, _ := b.ListHistory(); len(ids) != 3 {
+		t.Errorf("expected history to be pruned to 3 entries, got %d", len(ids))
+	}
+
+	config.History.MaxEntries = 0
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.SaveHistory(&types.ExecutionResult{CollectionName: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.prunes != 2 {
+		t.Errorf("expected no prune without retention limits, got %d", b.prunes)
+	}
+}
+
+func TestUnitReplayHistory_KeepsNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	err = client.SaveHistory(&types.ExecutionResult{
+		CollectionName: "api",
+		Requests: []*types.RequestExecution{
+			{Name: "Get user", Request: &types.Request{Method: "GET", URL: server.URL}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := client.Backend().ListHistory()
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("expected a history entry, got %v, %v", ids, err)
+	}
+
+	result, err := client.ReplayHistory(ids[0], false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Name != "Get user" {
+		t.Errorf("expected the item name to be kept, got %q", result.Requests[0].Name)
+	}
+}
+
+func TestUnitExecuteRequest_RecordRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.SaveEnvironment(environment.NewEnvironment("dev")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.LoadLocalEnvironment("dev"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.ExecuteRequest(&types.Request{Method: "GET", URL: server.URL}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := client.QueryHistory(HistoryQuery{Environment: "dev"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Result.Statistics.Success != 1 {
+		t.Fatalf("expected a successful recorded execution, got %+v", entries)
+	}
+
+	config := DefaultConfig()
+	config.History.RecordRequests = false
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.ExecuteRequest(&types.Request{Method: "GET", URL: server.URL}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids, _ := client.Backend().ListHistory(); len(ids) != 1 {
+		t.Errorf("expected recording to be disabled, got %d entries", len(ids))
+	}
+
+	if _, err := client.ReplayHistory("missing", false); err == nil {
+		t.Error("expected error for missing history entry")
+	}
+}
+
+func TestUnitDiffHistory(t *testing.T) {
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	for _, status := range []int{200, 500} {
+		result := &types.ExecutionResult{
+			CollectionName: "api",
+			Requests: []*types.RequestExecution{
+				{
+					Request:  &types.Request{Method: "GET", URL: "http://example.com/users"},
+					Response: fixture.CreateTestResponse(status, []byte(`{"ok": true}`)),
+				},
+			},
+		}
+		if err := client.SaveHistory(result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	ids, err := client.Backend().ListHistory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := client.DiffHistory(ids[1], ids[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.Items) != 1 || d.Items[0].Response == nil || d.Items[0].Response.StatusAfter != 500 {
+		t.Errorf("expected a status change, got %+v", d.Items)
+	}
+
+	if _, err := client.DiffHistory(ids[0], "missing", nil); err == nil {
+		t.Error("expected error for missing history entry")
+	}
+}
+
+func TestUnitEnableSnapshots(t *testing.T) {
+	body := `{"id": 1, "at": "2025-01-02T03:04:05Z"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	client, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	collection := &Collection{
+		Name:  "api",
+		Items: []*RequestItem{{Name: "user", Request: &Request{Method: "GET", URL: server.URL}}},
+	}
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := &SnapshotOptions{Normalizers: []SnapshotNormalizer{snapshot.NormalizeTimestamps}}
+	if err := client.EnableSnapshots(opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.ExecuteCollection("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "snapshots", "api", "user.snap")); err != nil {
+		t.Fatalf("expected snapshot file: %v", err)
+	}
+
+	body = `{"id": 1, "at": "2026-10-19T12:00:00Z"}`
+
+	result, err := client.ExecuteCollection("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot.Status != types.SnapshotMatched {
+		t.Errorf("expected normalized snapshot to match, got %+v", result.Requests[0].Snapshot)
+	}
+
+	body = `{"id": 2}`
+
+	opts.Update = true
+	result, err = client.ExecuteCollection("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot.Status != types.SnapshotUpdated || result.Statistics.Failed != 0 {
+		t.Errorf("expected updated snapshot, got %+v", result.Requests[0].Snapshot)
+	}
+
+	client.DisableSnapshots()
+
+	result, err = client.ExecuteCollection("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot != nil {
+		t.Errorf("expected no snapshot check when disabled, got %+v", result.Requests[0].Snapshot)
+	}
+
+	memoryClient, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer memoryClient.Close()
+
+	if err := memoryClient.EnableSnapshots(nil); !stderrors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument without a file backend, got %v", err)
+	}
+}
+
+func TestUnitSetLogOutput(t *testing.T) {
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if client.Logger().Enabled(context.Background(), slog.LevelError) {
+		t.Error("expected logs to be discarded without a log output")
+	}
+
+	var buf bytes.Buffer
+	client.SetLogOutput(&buf)
+
+	collection := fixture.CreateTestCollection("api", nil)
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.LoadCollection(collection.Name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no debug logs at the info level, got %q", buf.String())
+	}
+
+	config := DefaultConfig()
+	config.Logging.Level = "debug"
+	config.Logging.Format = "json"
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.LoadCollection(collection.Name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"msg":"collection loaded"`) {
+		t.Errorf("expected a JSON debug record, got %q", buf.String())
+	}
+
+	var custom bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&custom, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if err := client.UpdateConfig(DefaultConfig()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.LoadCollection(collection.Name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(custom.String(), "collection loaded") {
+		t.Errorf("expected the custom logger to be kept, got %q", custom.String())
+	}
+}
+
+func TestUnitExecuteCollection_PersistLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithBackend(backend.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+	client.SetLogOutput(nil)
+
+	collection := &Collection{
+		Name:  "api",
+		Items: []*RequestItem{{Name: "user", Request: &Request{Method: "GET", URL: server.URL + "/?api_key=abc123"}}},
+	}
+	if err := client.SaveCollection(collection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.ExecuteCollection("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids, _ := client.Backend().ListLogs(); len(ids) != 0 {
+		t.Fatalf("expected no saved logs without persist, got %v", ids)
+	}
+
+	config := DefaultConfig()
+	config.Logging.Level = "debug"
+	config.Logging.Persist = true
+	if err := client.UpdateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.ExecuteCollection("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := client.Backend().ListLogs()
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("expected a saved log batch, got %v: %v", ids, err)
+	}
+
+	logs, err := client.Backend().LoadLogs(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(logs) != 2 || logs[1].Level != "info" {
+		t.Fatalf("expected a request and a run entry, got %+v", logs)
+	}
+	for _, entry := range logs {
+		if strings.Contains(entry.Message, "abc123") {
+			t.Errorf("expected secrets to be redacted, got %q", entry.Message)
+		}
+	}
+
+	for range client.ExecuteCollectionAsync("api") {
+	}
+	if ids, _ := client.Backend().ListLogs(); len(ids) != 2 {
+		t.Errorf("expected the async run to save its logs, got %v", ids)
+	}
+}
+
+func TestUnitWriteReports(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	result := &types.ExecutionResult{
+		CollectionName: "api",
+		Requests: []*types.RequestExecution{
+			{
+				Name:     "user",
+				Request:  &types.Request{Method: "GET", URL: "http://example.com/users/1"},
+				Response: fixture.CreateTestResponse(500, []byte("boom")),
+			},
+		},
+	}
+
+	var outputs []ReportOutput
+	for _, spec := range []string{"junit:" + filepath.Join(dir, "report.xml"), "tap:" + filepath.Join(dir, "report.tap")} {
+		output, err := ParseReportOutput(spec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		outputs = append(outputs, output)
+	}
+
+	if err := WriteReports(outputs, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "report.xml"))
+	if err != nil || !strings.Contains(string(data), `<testcase name="user" classname="api"`) {
+		t.Errorf("unexpected JUnit report %q: %v", data, err)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "report.tap"))
+	if err != nil || !strings.Contains(string(data), "not ok 1 - user") {
+		t.Errorf("unexpected TAP report %q: %v", data, err)
+	}
+
+	if _, err := NewReporter("csv"); !stderrors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
 func TestUnitGetHistory(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 	if err != nil {
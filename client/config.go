@@ -5,9 +5,15 @@ package getman
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"time"
 
+	"github.com/KonnorFrik/getman/backend"
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/logging"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,17 +22,32 @@ type Config struct {
 	Storage  StorageConfig  `yaml:"storage"`
 	Defaults DefaultsConfig `yaml:"defaults"`
 	Logging  LoggingConfig  `yaml:"logging"`
+	History  HistoryConfig  `yaml:"history,omitempty"`
 }
 
 // StorageConfig contains storage-related configuration settings.
+// Layout and Format apply to collections saved for the first time: the layout is
+// LayoutFile (default) or LayoutDirectory, the format "json" (default) or "yaml".
+// Existing collections keep the layout and format they are stored in. The layout
+// applies to the file backend only.
 type StorageConfig struct {
 	BasePath string `yaml:"base_path"`
+	Layout   string `yaml:"layout,omitempty"`
+	Format   string `yaml:"format,omitempty"`
 }
 
+// Collection layouts of StorageConfig.Layout, see backend.LayoutDirectory.
+const (
+	LayoutFile      = backend.LayoutFile
+	LayoutDirectory = backend.LayoutDirectory
+)
+
 // DefaultsConfig contains default settings for requests.
 type DefaultsConfig struct {
-	Timeout TimeoutConfig `yaml:"timeout"`
-	Cookies CookiesConfig `yaml:"cookies"`
+	Timeout     TimeoutConfig `yaml:"timeout"`
+	Cookies     CookiesConfig `yaml:"cookies"`
+	Proxy       ProxyConfig   `yaml:"proxy"`
+	MaxBodySize int64         `yaml:"max_body_size,omitempty"`
 }
 
 // TimeoutConfig contains timeout settings for HTTP requests.
@@ -40,10 +61,37 @@ type CookiesConfig struct {
 	AutoManage bool `yaml:"auto_manage"`
 }
 
-// LoggingConfig contains logging configuration settings.
+// ProxyConfig contains default proxy settings.
+// When URL is empty the proxy is taken from the HTTP_PROXY, HTTPS_PROXY
+// and NO_PROXY environment variables unless IgnoreEnvironment is set.
+type ProxyConfig struct {
+	URL               string   `yaml:"url,omitempty"`
+	Username          string   `yaml:"username,omitempty"`
+	Password          string   `yaml:"password,omitempty"`
+	NoProxy           []string `yaml:"no_proxy,omitempty"`
+	IgnoreEnvironment bool     `yaml:"ignore_environment,omitempty"`
+}
+
+// LoggingConfig contains logging configuration settings. Level is "debug", "info",
+// "warn" or "error" and Format "text" or "json". Logs are written to the output set
+// with Client.SetLogOutput and discarded without one. Persist also saves the log
+// entries of every collection run to storage, with secrets redacted.
 type LoggingConfig struct {
-	Level  string `yaml:"level"`
-	Format string `yaml:"format"`
+	Level   string `yaml:"level"`
+	Format  string `yaml:"format"`
+	Persist bool   `yaml:"persist,omitempty"`
+}
+
+// HistoryConfig contains execution history settings. RecordRequests saves every
+// single request execution to history. The retention limits are applied as entries
+// are saved: MaxEntries once it is exceeded, MaxAge and MaxSize at most once a minute.
+// The most recent entries are kept while all limits hold, and zero limits are not
+// applied. MaxSize is the total size of the entries in bytes.
+type HistoryConfig struct {
+	RecordRequests bool          `yaml:"record_requests,omitempty"`
+	MaxEntries     int           `yaml:"max_entries,omitempty"`
+	MaxAge         time.Duration `yaml:"max_age,omitempty"`
+	MaxSize        int64         `yaml:"max_size,omitempty"`
 }
 
 // LoadConfig loads configuration from a YAML file.
@@ -53,6 +101,11 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	return parseConfig(data)
+}
+
+// parseConfig decodes and validates a YAML configuration.
+func parseConfig(data []byte) (*Config, error) {
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
@@ -67,22 +120,32 @@ func LoadConfig(configPath string) (*Config, error) {
 
 // SaveConfig saves configuration to a YAML file.
 func SaveConfig(config *Config, configPath string) error {
-	if err := validateConfig(config); err != nil {
-		return fmt.Errorf("invalid config: %w", err)
-	}
-
-	data, err := yaml.Marshal(config)
+	data, err := marshalConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := storage.WriteFileAtomic(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
+// marshalConfig validates a configuration and encodes it as YAML.
+func marshalConfig(config *Config) ([]byte, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return data, nil
+}
+
 // DefaultConfig returns a configuration with default values.
 func DefaultConfig() *Config {
 	return &Config{
@@ -102,6 +165,10 @@ func DefaultConfig() *Config {
 			Level:  "info",
 			Format: "text",
 		},
+		History: HistoryConfig{
+			RecordRequests: true,
+			MaxEntries:     1000,
+		},
 	}
 }
 
@@ -110,6 +177,18 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("storage.base_path is required")
 	}
 
+	switch config.Storage.Layout {
+	case "", LayoutFile, LayoutDirectory:
+	default:
+		return fmt.Errorf("storage.layout must be %q or %q", LayoutFile, LayoutDirectory)
+	}
+
+	switch storage.Format(config.Storage.Format) {
+	case "", storage.FormatJSON, storage.FormatYAML:
+	default:
+		return fmt.Errorf("storage.format must be %q or %q", storage.FormatJSON, storage.FormatYAML)
+	}
+
 	if config.Defaults.Timeout.Connect <= 0 {
 		return fmt.Errorf("defaults.timeout.connect must be positive")
 	}
@@ -118,6 +197,18 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("defaults.timeout.read must be positive")
 	}
 
+	if err := core.ValidateProxy(config.Defaults.Proxy.settings()); err != nil {
+		return fmt.Errorf("defaults.proxy: %w", err)
+	}
+
+	if config.Defaults.MaxBodySize < 0 {
+		return fmt.Errorf("defaults.max_body_size must not be negative")
+	}
+
+	if config.History.MaxEntries < 0 || config.History.MaxAge < 0 || config.History.MaxSize < 0 {
+		return fmt.Errorf("history limits must not be negative")
+	}
+
 	if config.Logging.Level == "" {
 		return fmt.Errorf("logging.level is required")
 	}
@@ -126,5 +217,27 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("logging.format is required")
 	}
 
+	if _, err := logging.NewHandler(io.Discard, config.Logging.Level, config.Logging.Format); err != nil {
+		return fmt.Errorf("logging: %w", err)
+	}
+
 	return nil
 }
+
+func (h HistoryConfig) retention() backend.RetentionPolicy {
+	return backend.RetentionPolicy{
+		MaxCount: h.MaxEntries,
+		MaxAge:   h.MaxAge,
+		MaxSize:  h.MaxSize,
+	}
+}
+
+func (p ProxyConfig) settings() *types.ProxySettings {
+	return &types.ProxySettings{
+		URL:               p.URL,
+		Username:          p.Username,
+		Password:          p.Password,
+		NoProxy:           p.NoProxy,
+		IgnoreEnvironment: p.IgnoreEnvironment,
+	}
+}
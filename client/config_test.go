@@ -264,3 +264,133 @@ func TestUnitValidateConfig_MissingLoggingLevel(t *testing.T) {
 	}
 }
 
+
+func TestUnitValidateConfig_InvalidProxy(t *testing.T) {
+	config := DefaultConfig()
+	config.Defaults.Proxy.URL = "ftp://proxy.local:21"
+
+	err := validateConfig(config)
+	if err == nil {
+		t.Fatal("expected error for unsupported proxy scheme")
+	}
+}
+
+func TestUnitLoadConfig_Proxy(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	configYAML := `storage:
+  base_path: ~/.getman
+
+defaults:
+  timeout:
+    connect: 30s
+    read: 30s
+  cookies:
+    auto_manage: true
+  proxy:
+    url: socks5://proxy.local:1080
+    username: user
+    password: pass
+    no_proxy:
+      - localhost
+      - .internal.corp
+
+logging:
+  level: info
+  format: text
+`
+
+	filePath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(filePath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	config, err := LoadConfig(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.Defaults.Proxy.URL != "socks5://proxy.local:1080" {
+		t.Errorf("expected proxy URL to be loaded, got %s", config.Defaults.Proxy.URL)
+	}
+
+	if len(config.Defaults.Proxy.NoProxy) != 2 {
+		t.Errorf("expected 2 no_proxy patterns, got %d", len(config.Defaults.Proxy.NoProxy))
+	}
+}
+
+func TestUnitValidateConfig_NegativeMaxBodySize(t *testing.T) {
+	config := DefaultConfig()
+	config.Defaults.MaxBodySize = -1
+
+	err := validateConfig(config)
+	if err == nil {
+		t.Fatal("expected error for negative max body size")
+	}
+}
+
+func TestUnitValidateConfig_InvalidStorageLayout(t *testing.T) {
+	config := DefaultConfig()
+	config.Storage.Layout = "tree"
+
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for invalid storage layout")
+	}
+
+	config.Storage.Layout = LayoutDirectory
+	config.Storage.Format = "toml"
+
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for invalid storage format")
+	}
+}
+
+func TestUnitValidateConfig_NegativeHistoryLimits(t *testing.T) {
+	config := DefaultConfig()
+	config.History.MaxAge = -time.Hour
+
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for negative history max age")
+	}
+}
+
+func TestUnitValidateConfig_InvalidLogging(t *testing.T) {
+	config := DefaultConfig()
+	config.Logging.Level = "verbose"
+
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for unknown logging level")
+	}
+
+	config = DefaultConfig()
+	config.Logging.Format = "xml"
+
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for unknown logging format")
+	}
+
+	config.Logging.Format = "json"
+	config.Logging.Level = "DEBUG"
+
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUnitParseConfig_History(t *testing.T) {
+	data := fixture.GetTestConfigYAML() + "history:\n  max_entries: 50\n  max_age: 720h\n  max_size: 1048576\n"
+
+	config, err := parseConfig([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	policy := config.History.retention()
+	if policy.MaxCount != 50 || policy.MaxAge != 720*time.Hour || policy.MaxSize != 1<<20 {
+		t.Errorf("unexpected retention policy: %+v", policy)
+	}
+}
@@ -5,17 +5,44 @@ Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor
 package getman
 
 import (
+	"github.com/KonnorFrik/getman/backend"
+	"github.com/KonnorFrik/getman/codegen"
 	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/diff"
 	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/formatter"
+	"github.com/KonnorFrik/getman/importer"
+	"github.com/KonnorFrik/getman/report"
+	"github.com/KonnorFrik/getman/snapshot"
 	"github.com/KonnorFrik/getman/types"
 )
 
 type Request = types.Request
 type RequestBody = types.RequestBody
+type GraphQLBody = types.GraphQLBody
 type Auth = types.Auth
 type Timeout = types.Timeout
 type CookieSettings = types.CookieSettings
 type Response = types.Response
+type Timings = types.Timings
+type TimingStatistics = types.TimingStatistics
+type ProxySettings = types.ProxySettings
+type ResponseBodySettings = types.ResponseBodySettings
+type ExecuteOptions = core.ExecuteOptions
+type ChunkHandler = core.ChunkHandler
+type StreamSettings = types.StreamSettings
+type SSEEvent = types.SSEEvent
+type StreamOptions = core.StreamOptions
+type EventStream = core.EventStream
+type WebSocketSettings = types.WebSocketSettings
+type WebSocketStep = types.WebSocketStep
+type WebSocketMessage = types.WebSocketMessage
+type GRPCSettings = types.GRPCSettings
+type CodeLanguage = codegen.Language
+type CodegenOptions = codegen.Options
+type HAROptions = importer.HAROptions
+type ImportResult = importer.ImportResult
 type Environment = environment.Environment
 type Collection = collections.Collection
 type RequestItem = types.RequestItem
@@ -23,3 +50,16 @@ type RequestExecution = types.RequestExecution
 type ExecutionResult = types.ExecutionResult
 type Statistics = types.Statistics
 type LogEntry = types.LogEntry
+type HistoryQuery = backend.HistoryQuery
+type HistoryEntry = backend.HistoryEntry
+type ErrorFilter = backend.ErrorFilter
+type RetentionPolicy = backend.RetentionPolicy
+type DiffOptions = diff.Options
+type ResultDiff = diff.ResultDiff
+type ResponseDiff = diff.ResponseDiff
+type SnapshotOptions = snapshot.Options
+type SnapshotNormalizer = snapshot.Normalizer
+type SnapshotResult = types.SnapshotResult
+type Reporter = report.Reporter
+type ReportOutput = report.Output
+type BodyRenderer = formatter.BodyRenderer
@@ -0,0 +1,180 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// mergedHeaders joins repeated headers into a single comma separated value,
+// for languages that take headers as a map.
+func mergedHeaders(headers []header) []header {
+	var merged []header
+	index := make(map[string]int)
+
+	for _, h := range headers {
+		if i, ok := index[h.name]; ok {
+			merged[i].value += ", " + h.value
+			continue
+		}
+
+		index[h.name] = len(merged)
+		merged = append(merged, h)
+	}
+
+	return merged
+}
+
+// quoteString returns a double quoted string literal valid in Python and JavaScript.
+func quoteString(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func isASCII(value []byte) bool {
+	for _, b := range value {
+		if b >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+func goSnippet(s *snippet) string {
+	var sb strings.Builder
+	hasBody := len(s.body) > 0
+
+	sb.WriteString("package main\n\nimport (\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n")
+	if hasBody {
+		sb.WriteString("\t\"strings\"\n")
+	}
+	sb.WriteString(")\n\nfunc main() {\n")
+
+	bodyVar := "nil"
+	if hasBody {
+		bodyVar = "body"
+		sb.WriteString(fmt.Sprintf("\tbody := strings.NewReader(%s)\n\n", strconv.Quote(string(s.body))))
+	}
+
+	sb.WriteString(fmt.Sprintf("\treq, err := http.NewRequest(%q, %q, %s)\n", s.method, s.url, bodyVar))
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+
+	if len(s.headers) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, h := range s.headers {
+		sb.WriteString(fmt.Sprintf("\treq.Header.Add(%q, %q)\n", h.name, h.value))
+	}
+
+	sb.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	sb.WriteString("\tdefer resp.Body.Close()\n\n")
+	sb.WriteString("\tdata, err := io.ReadAll(resp.Body)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\n")
+	sb.WriteString("\tfmt.Println(resp.Status)\n")
+	sb.WriteString("\tfmt.Println(string(data))\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+func pythonBytes(value []byte) string {
+	var sb strings.Builder
+	sb.WriteString(`b"`)
+
+	for _, b := range value {
+		switch {
+		case b == '"' || b == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(b)
+		case b >= 0x20 && b < 0x7f:
+			sb.WriteByte(b)
+		default:
+			sb.WriteString(fmt.Sprintf("\\x%02x", b))
+		}
+	}
+
+	sb.WriteString(`"`)
+	return sb.String()
+}
+
+func pythonSnippet(s *snippet) string {
+	var sb strings.Builder
+	args := ""
+
+	sb.WriteString("import requests\n\n")
+	sb.WriteString(fmt.Sprintf("url = %s\n", quoteString(s.url)))
+
+	if headers := mergedHeaders(s.headers); len(headers) > 0 {
+		args += ", headers=headers"
+		sb.WriteString("headers = {\n")
+		for _, h := range headers {
+			sb.WriteString(fmt.Sprintf("    %s: %s,\n", quoteString(h.name), quoteString(h.value)))
+		}
+		sb.WriteString("}\n")
+	}
+
+	if len(s.body) > 0 {
+		args += ", data=data"
+
+		switch {
+		case !utf8.Valid(s.body):
+			sb.WriteString(fmt.Sprintf("data = %s\n", pythonBytes(s.body)))
+		case isASCII(s.body):
+			sb.WriteString(fmt.Sprintf("data = %s\n", quoteString(string(s.body))))
+		default:
+			// requests would encode a str body as Latin-1.
+			sb.WriteString(fmt.Sprintf("data = %s.encode(\"utf-8\")\n", quoteString(string(s.body))))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\nresponse = requests.request(%s, url%s)\n", quoteString(s.method), args))
+	sb.WriteString("print(response.status_code)\n")
+	sb.WriteString("print(response.text)\n")
+
+	return sb.String()
+}
+
+func javascriptSnippet(s *snippet) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("const response = await fetch(%s, {\n", quoteString(s.url)))
+	sb.WriteString(fmt.Sprintf("  method: %s,\n", quoteString(s.method)))
+
+	if headers := mergedHeaders(s.headers); len(headers) > 0 {
+		sb.WriteString("  headers: {\n")
+		for _, h := range headers {
+			sb.WriteString(fmt.Sprintf("    %s: %s,\n", quoteString(h.name), quoteString(h.value)))
+		}
+		sb.WriteString("  },\n")
+	}
+
+	if len(s.body) > 0 {
+		if utf8.Valid(s.body) {
+			sb.WriteString(fmt.Sprintf("  body: %s,\n", quoteString(string(s.body))))
+		} else {
+			values := make([]string, len(s.body))
+			for i, b := range s.body {
+				values[i] = strconv.Itoa(int(b))
+			}
+			sb.WriteString(fmt.Sprintf("  body: new Uint8Array([%s]),\n", strings.Join(values, ", ")))
+		}
+	}
+
+	sb.WriteString("});\n\n")
+	sb.WriteString("console.log(response.status);\n")
+	sb.WriteString("console.log(await response.text());\n")
+
+	return sb.String()
+}
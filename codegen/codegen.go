@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Language identifies the target of a generated snippet.
+type Language string
+
+const (
+	// Curl generates a curl command line.
+	Curl Language = "curl"
+	// HTTPie generates an HTTPie command line.
+	HTTPie Language = "httpie"
+	// Wget generates a wget command line.
+	Wget Language = "wget"
+	// Go generates a Go program using net/http.
+	Go Language = "go"
+	// Python generates a Python script using the requests package.
+	Python Language = "python"
+	// JavaScript generates JavaScript code using fetch.
+	JavaScript Language = "javascript"
+)
+
+const (
+	authTypeBasic  = "basic"
+	authTypeBearer = "bearer"
+	authTypeApiKey = "apikey"
+)
+
+// Options controls snippet generation.
+type Options struct {
+	// MaskSecrets replaces credentials from the request auth (passwords, tokens, API keys)
+	// with a masked form, keeping only the first and last four characters.
+	MaskSecrets bool
+}
+
+// Languages returns all supported snippet languages.
+func Languages() []Language {
+	return []Language{Curl, HTTPie, Wget, Go, Python, JavaScript}
+}
+
+// Generate turns a resolved request into a code snippet in the given language.
+// Headers, body and auth are applied the same way as when the request is executed.
+// WebSocket and gRPC requests are not supported.
+func Generate(req *types.Request, lang Language, opts *Options) (string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	s, err := prepare(req, opts)
+	if err != nil {
+		return "", err
+	}
+
+	switch Language(strings.ToLower(string(lang))) {
+	case Curl:
+		return curlSnippet(s), nil
+	case HTTPie:
+		return httpieSnippet(s), nil
+	case Wget:
+		return wgetSnippet(s), nil
+	case Go:
+		return goSnippet(s), nil
+	case Python:
+		return pythonSnippet(s), nil
+	case JavaScript:
+		return javascriptSnippet(s), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported snippet language %q", errors.ErrInvalidArgument, lang)
+	}
+}
+
+// MaskSecret hides a secret, keeping only its first and last four characters.
+// Secrets shorter than eight characters are fully masked.
+func MaskSecret(secret string) string {
+	if len(secret) < 8 {
+		return "***"
+	}
+	return secret[:4] + "..." + secret[len(secret)-4:]
+}
+
+type header struct {
+	name  string
+	value string
+}
+
+// snippet holds the parts of a prepared request that the generators render.
+type snippet struct {
+	method  string
+	url     string
+	headers []header
+	body    []byte
+}
+
+func prepare(req *types.Request, opts *Options) (*snippet, error) {
+	if core.IsWebSocket(req) || core.IsGRPC(req) {
+		return nil, fmt.Errorf("%w: snippets can only be generated for HTTP requests", errors.ErrInvalidArgument)
+	}
+
+	httpReq, err := core.NewHTTPRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if httpReq.Body != nil {
+		body, err = io.ReadAll(httpReq.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	if opts.MaskSecrets && req.Auth != nil {
+		maskAuth(httpReq, req.Auth)
+	}
+
+	s := &snippet{
+		method: httpReq.Method,
+		url:    httpReq.URL.String(),
+		body:   body,
+	}
+
+	for name, values := range httpReq.Header {
+		for _, value := range values {
+			s.headers = append(s.headers, header{name: name, value: value})
+		}
+	}
+
+	sort.SliceStable(s.headers, func(i, j int) bool {
+		return s.headers[i].name < s.headers[j].name
+	})
+
+	return s, nil
+}
+
+// maskAuth masks the credentials that applyAuth placed in the request.
+func maskAuth(httpReq *http.Request, auth *types.Auth) {
+	switch strings.ToLower(auth.Type) {
+	case authTypeBasic, authTypeBearer:
+		if value := httpReq.Header.Get("Authorization"); value != "" {
+			scheme, credentials, found := strings.Cut(value, " ")
+			if found {
+				httpReq.Header.Set("Authorization", scheme+" "+MaskSecret(credentials))
+			} else {
+				httpReq.Header.Set("Authorization", MaskSecret(value))
+			}
+		}
+	case authTypeApiKey:
+		switch strings.ToLower(auth.Location) {
+		case "header":
+			if value := httpReq.Header.Get(auth.KeyName); value != "" {
+				httpReq.Header.Set(auth.KeyName, MaskSecret(value))
+			}
+		case "query":
+			q := httpReq.URL.Query()
+			if value := q.Get(auth.KeyName); value != "" {
+				q.Set(auth.KeyName, MaskSecret(value))
+				httpReq.URL.RawQuery = q.Encode()
+			}
+		}
+	}
+}
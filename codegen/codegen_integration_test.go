@@ -0,0 +1,123 @@
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func echoRequest() *types.Request {
+	return &types.Request{
+		Method:  "PATCH",
+		URL:     http_server.GetServerURL() + "/echo?page=1",
+		Headers: map[string]string{"X-Trace": "abc"},
+		Body: &types.RequestBody{
+			Type:        "json",
+			Content:     []byte(`{"name":"it's \"me\""}`),
+			ContentType: "application/json",
+		},
+		Auth: &types.Auth{Type: "bearer", Token: "token-1234567890"},
+	}
+}
+
+func checkEcho(t *testing.T, output []byte) {
+	t.Helper()
+
+	start := strings.Index(string(output), "{")
+	if start < 0 {
+		t.Fatalf("expected JSON output, got: %s", output)
+	}
+
+	var echo struct {
+		Method  string              `json:"method"`
+		Headers map[string][]string `json:"headers"`
+		Query   map[string][]string `json:"query"`
+		Body    string              `json:"body"`
+	}
+
+	if err := json.NewDecoder(strings.NewReader(string(output[start:]))).Decode(&echo); err != nil {
+		t.Fatalf("failed to parse echo: %v\n%s", err, output)
+	}
+
+	if echo.Method != "PATCH" {
+		t.Errorf("expected method PATCH, got %s", echo.Method)
+	}
+
+	if echo.Body != `{"name":"it's \"me\""}` {
+		t.Errorf("unexpected body %q", echo.Body)
+	}
+
+	if got := echo.Headers["X-Trace"]; len(got) != 1 || got[0] != "abc" {
+		t.Errorf("expected X-Trace header, got %v", got)
+	}
+
+	if got := echo.Headers["Authorization"]; len(got) != 1 || got[0] != "Bearer token-1234567890" {
+		t.Errorf("expected Authorization header, got %v", got)
+	}
+
+	if got := echo.Query["page"]; len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected page query parameter, got %v", got)
+	}
+}
+
+func TestIntegrationGenerate_CurlRuns(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl is not installed")
+	}
+
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	snippet, err := Generate(echoRequest(), Curl, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, err := exec.Command("bash", "-c", snippet).Output()
+	if err != nil {
+		t.Fatalf("curl failed: %v\n%s", err, output)
+	}
+
+	checkEcho(t, output)
+}
+
+func TestIntegrationGenerate_JavaScriptRuns(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	snippet, err := Generate(echoRequest(), JavaScript, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	script := filepath.Join(t.TempDir(), "snippet.mjs")
+	if err := os.WriteFile(script, []byte(snippet), 0644); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	output, err := exec.Command("node", script).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "fetch is not defined") {
+			t.Skip("node has no fetch support")
+		}
+		t.Fatalf("node failed: %v\n%s", err, output)
+	}
+
+	checkEcho(t, output)
+}
@@ -0,0 +1,205 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func jsonRequest() *types.Request {
+	return &types.Request{
+		Method:  "POST",
+		URL:     "http://localhost:8080/users?page=1",
+		Headers: map[string]string{"X-Trace": "abc"},
+		Body: &types.RequestBody{
+			Type:        "json",
+			Content:     []byte(`{"name":"it's me"}`),
+			ContentType: "application/json",
+		},
+	}
+}
+
+func TestUnitGenerate_Curl(t *testing.T) {
+	snippet, err := Generate(jsonRequest(), Curl, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"curl \\\n  'http://localhost:8080/users?page=1'",
+		"-H 'Content-Type: application/json'",
+		"-H 'X-Trace: abc'",
+		`--data-raw '{"name":"it'\''s me"}'`,
+	}
+
+	for _, part := range expected {
+		if !strings.Contains(snippet, part) {
+			t.Errorf("expected snippet to contain %q, got:\n%s", part, snippet)
+		}
+	}
+
+	if strings.Contains(snippet, "-X POST") {
+		t.Errorf("expected -X to be omitted for POST with a body, got:\n%s", snippet)
+	}
+}
+
+func TestUnitGenerate_CurlMethod(t *testing.T) {
+	req := &types.Request{Method: "DELETE", URL: "http://localhost:8080/users/1"}
+
+	snippet, err := Generate(req, Curl, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(snippet, "-X DELETE") {
+		t.Errorf("expected -X DELETE, got:\n%s", snippet)
+	}
+}
+
+func TestUnitGenerate_Languages(t *testing.T) {
+	tests := []struct {
+		lang     Language
+		expected []string
+	}{
+		{HTTPie, []string{"--raw '{", "POST 'http://localhost:8080/users?page=1'", "'X-Trace:abc'"}},
+		{Wget, []string{"wget", "--method=POST", "--header='X-Trace: abc'", "--body-data="}},
+		{Go, []string{"package main", `http.NewRequest("POST", "http://localhost:8080/users?page=1", body)`, `req.Header.Add("X-Trace", "abc")`, `strings.NewReader("{\"name\":\"it's me\"}")`}},
+		{Python, []string{"import requests", `"X-Trace": "abc",`, `data = "{\"name\":\"it's me\"}"`, `requests.request("POST", url, headers=headers, data=data)`}},
+		{JavaScript, []string{`await fetch("http://localhost:8080/users?page=1"`, `method: "POST",`, `"X-Trace": "abc",`, `body: "{\"name\":\"it's me\"}",`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			snippet, err := Generate(jsonRequest(), tt.lang, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, part := range tt.expected {
+				if !strings.Contains(snippet, part) {
+					t.Errorf("expected snippet to contain %q, got:\n%s", part, snippet)
+				}
+			}
+		})
+	}
+}
+
+func TestUnitGenerate_UnsupportedLanguage(t *testing.T) {
+	_, err := Generate(jsonRequest(), Language("cobol"), nil)
+	if err == nil {
+		t.Error("expected error for unsupported language")
+	}
+}
+
+func TestUnitGenerate_UnsupportedRequest(t *testing.T) {
+	for _, url := range []string{"ws://localhost:8080/ws", "grpc://localhost:50051/pkg.Service/Method"} {
+		_, err := Generate(&types.Request{URL: url}, Curl, nil)
+		if err == nil {
+			t.Errorf("expected error for %s", url)
+		}
+	}
+}
+
+func TestUnitGenerate_Auth(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     *types.Auth
+		expected string
+		masked   string
+	}{
+		{
+			name:     "basic",
+			auth:     &types.Auth{Type: "basic", Username: "user", Password: "secret"},
+			expected: "Authorization: Basic dXNlcjpzZWNyZXQ=",
+			masked:   "Authorization: Basic dXNl...ZXQ=",
+		},
+		{
+			name:     "bearer",
+			auth:     &types.Auth{Type: "bearer", Token: "token-1234567890"},
+			expected: "Authorization: Bearer token-1234567890",
+			masked:   "Authorization: Bearer toke...7890",
+		},
+		{
+			name:     "apikey header",
+			auth:     &types.Auth{Type: "apikey", APIKey: "key-1234567890", KeyName: "X-API-Key", Location: "header"},
+			expected: "X-Api-Key: key-1234567890",
+			masked:   "X-Api-Key: key-...7890",
+		},
+		{
+			name:     "apikey query",
+			auth:     &types.Auth{Type: "apikey", APIKey: "key-1234567890", KeyName: "api_key", Location: "query"},
+			expected: "api_key=key-1234567890",
+			masked:   "api_key=key-...7890",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &types.Request{Method: "GET", URL: "http://localhost:8080/data", Auth: tt.auth}
+
+			plain, err := Generate(req, Curl, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(plain, tt.expected) {
+				t.Errorf("expected %q in snippet, got:\n%s", tt.expected, plain)
+			}
+
+			masked, err := Generate(req, Curl, &Options{MaskSecrets: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(masked, tt.masked) {
+				t.Errorf("expected %q in masked snippet, got:\n%s", tt.masked, masked)
+			}
+		})
+	}
+}
+
+func TestUnitGenerate_BinaryBody(t *testing.T) {
+	req := &types.Request{
+		Method: "PUT",
+		URL:    "http://localhost:8080/upload",
+		Body: &types.RequestBody{
+			Type:        "binary",
+			Content:     []byte{0x00, 0xff, 'a'},
+			ContentType: "application/octet-stream",
+		},
+	}
+
+	tests := []struct {
+		lang     Language
+		expected string
+	}{
+		{Curl, `printf '\x00\xff\x61' > body.bin`},
+		{Python, `data = b"\x00\xffa"`},
+		{JavaScript, "body: new Uint8Array([0, 255, 97]),"},
+		{Go, `strings.NewReader("\x00\xffa")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			snippet, err := Generate(req, tt.lang, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(snippet, tt.expected) {
+				t.Errorf("expected %q in snippet, got:\n%s", tt.expected, snippet)
+			}
+		})
+	}
+}
+
+func TestUnitMaskSecret(t *testing.T) {
+	if got := MaskSecret("short"); got != "***" {
+		t.Errorf("expected ***, got %s", got)
+	}
+
+	if got := MaskSecret("1234567890"); got != "1234...7890" {
+		t.Errorf("expected 1234...7890, got %s", got)
+	}
+}
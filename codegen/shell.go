@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	shellLineBreak = " \\\n  "
+	bodyFileName   = "body.bin"
+)
+
+// shellQuote quotes a value for POSIX shells. Values that are not printable UTF-8
+// text use ANSI-C quoting ($'...'), supported by bash and zsh.
+func shellQuote(value []byte) string {
+	if isPlainText(value) {
+		return "'" + strings.ReplaceAll(string(value), "'", `'\''`) + "'"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$'")
+
+	for _, b := range value {
+		switch {
+		case b == '\'' || b == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(b)
+		case b >= 0x20 && b < 0x7f:
+			sb.WriteByte(b)
+		default:
+			sb.WriteString(fmt.Sprintf("\\x%02x", b))
+		}
+	}
+
+	sb.WriteString("'")
+	return sb.String()
+}
+
+// isPlainText reports whether the value is valid UTF-8 without control characters
+// other than tabs and line breaks.
+func isPlainText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+
+	for _, r := range string(value) {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// needsBodyFile reports whether the body has NUL bytes, which cannot be passed on a command line.
+func needsBodyFile(body []byte) bool {
+	return bytes.IndexByte(body, 0) >= 0
+}
+
+// bodyFilePrelude returns a printf command writing the body to bodyFileName.
+func bodyFilePrelude(body []byte) string {
+	var sb strings.Builder
+	for _, b := range body {
+		sb.WriteString(fmt.Sprintf("\\x%02x", b))
+	}
+
+	return "printf '" + sb.String() + "' > " + bodyFileName + "\n"
+}
+
+func joinShell(prelude string, parts []string) string {
+	return prelude + strings.Join(parts, shellLineBreak) + "\n"
+}
+
+func headerLine(h header) []byte {
+	return []byte(h.name + ": " + h.value)
+}
+
+func curlSnippet(s *snippet) string {
+	parts := []string{"curl"}
+
+	hasBody := len(s.body) > 0
+	if !(s.method == http.MethodGet && !hasBody) && !(s.method == http.MethodPost && hasBody) {
+		parts = append(parts, "-X "+s.method)
+	}
+
+	parts = append(parts, shellQuote([]byte(s.url)))
+
+	for _, h := range s.headers {
+		parts = append(parts, "-H "+shellQuote(headerLine(h)))
+	}
+
+	var prelude string
+	switch {
+	case !hasBody:
+	case needsBodyFile(s.body):
+		prelude = bodyFilePrelude(s.body)
+		parts = append(parts, "--data-binary @"+bodyFileName)
+	case isPlainText(s.body):
+		parts = append(parts, "--data-raw "+shellQuote(s.body))
+	default:
+		parts = append(parts, "--data-binary "+shellQuote(s.body))
+	}
+
+	return joinShell(prelude, parts)
+}
+
+func httpieSnippet(s *snippet) string {
+	parts := []string{"http"}
+
+	useFile := needsBodyFile(s.body)
+	if len(s.body) > 0 && !useFile {
+		parts = append(parts, "--raw "+shellQuote(s.body))
+	}
+
+	parts = append(parts, s.method+" "+shellQuote([]byte(s.url)))
+
+	for _, h := range s.headers {
+		parts = append(parts, shellQuote([]byte(h.name+":"+h.value)))
+	}
+
+	var prelude string
+	if useFile {
+		// HTTPie reads the body from stdin when it is redirected.
+		prelude = bodyFilePrelude(s.body)
+		parts = append(parts, "< "+bodyFileName)
+	}
+
+	return joinShell(prelude, parts)
+}
+
+func wgetSnippet(s *snippet) string {
+	parts := []string{"wget", "--quiet", "--output-document=-", "--method=" + s.method}
+
+	for _, h := range s.headers {
+		parts = append(parts, "--header="+shellQuote(headerLine(h)))
+	}
+
+	var prelude string
+	switch {
+	case len(s.body) == 0:
+	case needsBodyFile(s.body):
+		prelude = bodyFilePrelude(s.body)
+		parts = append(parts, "--body-file="+bodyFileName)
+	default:
+		parts = append(parts, "--body-data="+shellQuote(s.body))
+	}
+
+	parts = append(parts, shellQuote([]byte(s.url)))
+	return joinShell(prelude, parts)
+}
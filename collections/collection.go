@@ -4,10 +4,8 @@ Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor
 package collections
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/KonnorFrik/getman/storage"
 	"github.com/KonnorFrik/getman/types"
@@ -21,15 +19,21 @@ type Collection struct {
 	EnvName     string               `json:"environment_name"`
 }
 
-// LoadCollectionFromFile loads a collection from a JSON file.
+// LoadCollectionFromFile loads a collection from a JSON or YAML file.
+// The format is chosen by the file extension.
 func LoadCollectionFromFile(filePath string) (*Collection, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read collection file: %w", err)
 	}
 
+	return ParseCollection(data, storage.FormatForPath(filePath))
+}
+
+// ParseCollection decodes and validates a collection stored in the given format.
+func ParseCollection(data []byte, format storage.Format) (*Collection, error) {
 	var collection Collection
-	if err := json.Unmarshal(data, &collection); err != nil {
+	if err := storage.Unmarshal(data, &collection, format); err != nil {
 		return nil, fmt.Errorf("failed to parse collection file: %w", err)
 	}
 
@@ -40,28 +44,40 @@ func LoadCollectionFromFile(filePath string) (*Collection, error) {
 	return &collection, nil
 }
 
-// SaveCollectionToFile saves a collection to a JSON file.
+// SaveCollectionToFile saves a collection to a JSON or YAML file chosen by the file
+// extension. Textual bodies are written as strings. An existing file is replaced
+// atomically, so a concurrent reader never sees a partial collection.
 func SaveCollectionToFile(collection *Collection, filePath string) error {
-	if err := validateCollection(collection); err != nil {
-		return fmt.Errorf("invalid collection: %w", err)
-	}
-
-	data, err := json.MarshalIndent(collection, "", "  ")
+	data, err := MarshalCollection(collection, storage.FormatForPath(filePath))
 	if err != nil {
-		return fmt.Errorf("failed to marshal collection: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := storage.WriteFileAtomic(filePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write collection file: %w", err)
 	}
 
 	return nil
 }
 
-// GetCollectionPath returns the file path for a collection by name.
+// MarshalCollection validates a collection and encodes it in the given format.
+func MarshalCollection(collection *Collection, format storage.Format) ([]byte, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid collection: %w", err)
+	}
+
+	data, err := storage.Marshal(collection, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal collection: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetCollectionPath returns the file path for a collection by name. An existing
+// YAML file is preferred over a new JSON one.
 func GetCollectionPath(fileStorage *storage.FileStorage, name string) string {
-	filename := fmt.Sprintf("%s.json", name)
-	return filepath.Join(fileStorage.CollectionsDir(), filename)
+	return storage.FindFile(fileStorage.CollectionsDir(), name)
 }
 
 func validateCollection(collection *Collection) error {
@@ -3,6 +3,7 @@ package collections
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/KonnorFrik/getman/storage"
@@ -348,3 +349,113 @@ func TestUnitSaveCollectionToFile_EmptyItems(t *testing.T) {
 		t.Errorf("expected 0 items, got %d", len(loadedCollection.Items))
 	}
 }
+
+func TestUnitSaveCollectionToFile_YAML(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{
+				Name: "Create",
+				Request: &types.Request{
+					Method: "POST",
+					URL:    "http://example.com",
+					Body:   &types.RequestBody{Type: "json", Content: []byte(`{"name":"Ann"}`), ContentType: "application/json"},
+				},
+			},
+		},
+	}
+
+	for _, name := range []string{"test.yaml", "test.yml", "test.json"} {
+		filePath := filepath.Join(dir, name)
+		if err := SaveCollectionToFile(collection, filePath); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+
+		if !strings.Contains(string(data), `{"name":"Ann"}`) && !strings.Contains(string(data), `{\"name\":\"Ann\"}`) {
+			t.Errorf("%s: expected body to be stored as text, got:\n%s", name, data)
+		}
+
+		loaded, err := LoadCollectionFromFile(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error loading collection: %v", err)
+		}
+
+		if string(loaded.Items[0].Request.Body.Content) != `{"name":"Ann"}` {
+			t.Errorf("%s: unexpected body: %s", name, loaded.Items[0].Request.Body.Content)
+		}
+	}
+}
+
+func TestUnitLoadCollectionFromFile_YAML(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	collectionYAML := `name: Test Collection
+environment_name: dev
+items:
+  - name: Create
+    request:
+      method: POST
+      url: "{{baseUrl}}/users"
+      body:
+        type: json
+        content_type: application/json
+        text: |
+          {"name": "Ann"}
+`
+
+	filePath := filepath.Join(dir, "test.yaml")
+	if err := os.WriteFile(filePath, []byte(collectionYAML), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	collection, err := LoadCollectionFromFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if collection.Name != "Test Collection" || collection.EnvName != "dev" {
+		t.Errorf("unexpected collection: %s %s", collection.Name, collection.EnvName)
+	}
+
+	req := collection.Items[0].Request
+	if req.URL != "{{baseUrl}}/users" || string(req.Body.Content) != "{\"name\": \"Ann\"}\n" {
+		t.Errorf("unexpected request: %s %q", req.URL, req.Body.Content)
+	}
+}
+
+func TestUnitGetCollectionPath_YAML(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	fs, err := storage.NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filePath := filepath.Join(fs.CollectionsDir(), "api.yml")
+	if err := os.WriteFile(filePath, []byte("name: api\nitems: []\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	if path := GetCollectionPath(fs, "api"); path != filePath {
+		t.Errorf("expected %s, got %s", filePath, path)
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package collections
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// ManifestName is the file name, without extension, of the manifest of a collection
+// directory. The manifest holds the collection settings and the order of the requests.
+const ManifestName = "collection"
+
+// collectionManifest is the manifest of a collection directory. Items are the
+// slash-separated paths of the request files relative to the directory.
+type collectionManifest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	EnvName     string   `json:"environment_name,omitempty"`
+	Items       []string `json:"items"`
+}
+
+// IsCollectionDir reports whether dirPath holds a collection in the directory layout.
+func IsCollectionDir(dirPath string) bool {
+	_, err := os.Stat(storage.FindFile(dirPath, ManifestName))
+	return err == nil
+}
+
+// GetCollectionDir returns the directory path for a collection by name.
+func GetCollectionDir(fileStorage *storage.FileStorage, name string) string {
+	return filepath.Join(fileStorage.CollectionsDir(), name)
+}
+
+// LoadCollectionFromDir loads a collection stored as a directory with a file per request.
+// Requests are ordered by the manifest; request files missing from the manifest, for
+// example added in another branch, are loaded after the listed ones.
+func LoadCollectionFromDir(dirPath string) (*Collection, error) {
+	manifest, err := readManifest(storage.FindFile(dirPath, ManifestName))
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &Collection{
+		Name:        manifest.Name,
+		Description: manifest.Description,
+		EnvName:     manifest.EnvName,
+		Items:       make([]*types.RequestItem, 0, len(manifest.Items)),
+	}
+
+	loaded := make(map[string]bool)
+	load := func(relPath string) error {
+		if loaded[relPath] {
+			return nil
+		}
+		loaded[relPath] = true
+
+		item, err := loadRequestFile(dirPath, relPath)
+		if err != nil {
+			return err
+		}
+		if item != nil {
+			collection.Items = append(collection.Items, item)
+		}
+		return nil
+	}
+
+	for _, relPath := range manifest.Items {
+		if err := load(relPath); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := requestFiles(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collection directory: %w", err)
+	}
+	for _, relPath := range files {
+		if err := load(relPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+// readManifest reads the manifest of a collection directory.
+func readManifest(manifestPath string) (*collectionManifest, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collection manifest: %w", err)
+	}
+
+	var manifest collectionManifest
+	if err := storage.Unmarshal(data, &manifest, storage.FormatForPath(manifestPath)); err != nil {
+		return nil, fmt.Errorf("failed to parse collection manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// cleanRequestPath cleans the path of a request file from a manifest, rejecting paths
+// outside the collection directory.
+func cleanRequestPath(relPath string) (string, error) {
+	cleaned := path.Clean(relPath)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid request file path %q", relPath)
+	}
+
+	return cleaned, nil
+}
+
+// loadRequestFile loads a request file. Its folder is the one stored in the file, if
+// any, or else the directory it is in. A file listed in the manifest that no longer
+// exists is skipped.
+func loadRequestFile(dirPath, relPath string) (*types.RequestItem, error) {
+	cleaned, err := cleanRequestPath(relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(dirPath, filepath.FromSlash(cleaned))
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read request file: %w", err)
+	}
+
+	var item types.RequestItem
+	if err := storage.Unmarshal(data, &item, storage.FormatForPath(filePath)); err != nil {
+		return nil, fmt.Errorf("failed to parse request file %s: %w", relPath, err)
+	}
+
+	if item.Folder == "" {
+		if folder := path.Dir(cleaned); folder != "." {
+			item.Folder = folder
+		}
+	}
+
+	return &item, nil
+}
+
+// requestFiles returns the slash-separated paths of the request files in a collection
+// directory, in lexical order.
+func requestFiles(dirPath string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dirPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if filePath != dirPath && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dirPath, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		name, ok := storage.TrimExtension(relPath)
+		if ok && name != ManifestName {
+			files = append(files, relPath)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// SaveCollectionToDir saves a collection as a directory with a file per request, a
+// directory per folder and a manifest. The files keep the format of an existing
+// manifest; a new directory is written in format. Files are only rewritten when their
+// content changes, and request files of the previous manifest that are no longer in
+// the collection are removed. A folder whose name is not a valid directory name is
+// stored in its request files, so that it is loaded back unchanged.
+func SaveCollectionToDir(collection *Collection, dirPath string, format storage.Format) error {
+	if err := validateCollection(collection); err != nil {
+		return fmt.Errorf("invalid collection: %w", err)
+	}
+
+	var previous []string
+
+	manifestPath := storage.FindFile(dirPath, ManifestName)
+	if _, err := os.Stat(manifestPath); err == nil {
+		format = storage.FormatForPath(manifestPath)
+
+		manifest, err := readManifest(manifestPath)
+		if err != nil {
+			return err
+		}
+		previous = manifest.Items
+	} else {
+		manifestPath = filepath.Join(dirPath, ManifestName+storage.Extension(format))
+	}
+	ext := storage.Extension(format)
+
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return fmt.Errorf("failed to create collection directory: %w", err)
+	}
+
+	manifest := collectionManifest{
+		Name:        collection.Name,
+		Description: collection.Description,
+		EnvName:     collection.EnvName,
+		Items:       make([]string, 0, len(collection.Items)),
+	}
+
+	written := make(map[string]bool)
+	used := make(map[string]bool)
+	for _, item := range collection.Items {
+		relPath := requestFilePath(item, ext, used)
+		manifest.Items = append(manifest.Items, relPath)
+		written[strings.ToLower(relPath)] = true
+
+		requestItem := *item
+		if dir := path.Dir(relPath); dir == item.Folder || (dir == "." && item.Folder == "") {
+			requestItem.Folder = ""
+		}
+
+		data, err := storage.Marshal(&requestItem, format)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request %q: %w", item.Name, err)
+		}
+
+		filePath := filepath.Join(dirPath, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return fmt.Errorf("failed to create folder directory: %w", err)
+		}
+		if err := writeIfChanged(filePath, data); err != nil {
+			return fmt.Errorf("failed to write request file: %w", err)
+		}
+	}
+
+	data, err := storage.Marshal(&manifest, format)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collection manifest: %w", err)
+	}
+	if err := writeIfChanged(manifestPath, data); err != nil {
+		return fmt.Errorf("failed to write collection manifest: %w", err)
+	}
+
+	return removeStaleFiles(dirPath, previous, written)
+}
+
+// requestFilePath returns a unique path for the file of a request, made from its folder
+// and name. Names that differ only in case get a suffix, for case-insensitive file systems.
+func requestFilePath(item *types.RequestItem, ext string, used map[string]bool) string {
+	var segments []string
+	for _, segment := range strings.Split(item.Folder, "/") {
+		if segment = storage.FileName(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	name := storage.FileName(item.Name)
+	if name == "" {
+		name = "request"
+	}
+
+	// The manifest is not a request file.
+	used[strings.ToLower(ManifestName+ext)] = true
+
+	return storage.UniquePath(strings.Join(segments, "/"), name, ext, used)
+}
+
+func writeIfChanged(filePath string, data []byte) error {
+	if existing, err := os.ReadFile(filePath); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return storage.WriteFileAtomic(filePath, data, 0644)
+}
+
+// removeStaleFiles removes the request files of the previous manifest that were not
+// written, and the folder directories they leave empty. Other files in the directory
+// are kept. Written paths are compared case-insensitively, as on case-insensitive file
+// systems a renamed file may be the one that was written.
+func removeStaleFiles(dirPath string, previous []string, written map[string]bool) error {
+	for _, relPath := range previous {
+		cleaned, err := cleanRequestPath(relPath)
+		if err != nil || written[strings.ToLower(cleaned)] {
+			continue
+		}
+
+		filePath := filepath.Join(dirPath, filepath.FromSlash(cleaned))
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove request file: %w", err)
+		}
+
+		for dir := filepath.Dir(filePath); dir != dirPath; dir = filepath.Dir(dir) {
+			if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 {
+				break
+			}
+			if err := os.Remove(dir); err != nil {
+				return fmt.Errorf("failed to remove folder directory: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package collections
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationExecuteCollection_FromDir(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{
+				Name: "Health",
+				Request: &types.Request{
+					Method: http.MethodGet,
+					URL:    "{{base_url}}/health",
+				},
+			},
+			{
+				Name:   "Echo",
+				Folder: "Echo",
+				Request: &types.Request{
+					Method: http.MethodPost,
+					URL:    "{{base_url}}/echo",
+					Body: &types.RequestBody{
+						Type:    "application/json",
+						Content: []byte(`{"key": "value"}`),
+					},
+				},
+			},
+		},
+	}
+
+	dirPath := filepath.Join(dir, "test")
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatYAML); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := LoadCollectionFromDir(dirPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	env := environment.NewEnvironment("test")
+	env.Set("base_url", http_server.GetServerURL())
+	resolver, err := core.NewVariableResolver(env, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(core.NewHTTPClient(10*time.Second, 30*time.Second, false), resolver)
+	result, err := executor.ExecuteCollection(loaded, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Statistics.Success != 2 {
+		t.Errorf("expected success 2, got %d", result.Statistics.Success)
+	}
+}
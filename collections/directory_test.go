@@ -0,0 +1,387 @@
+package collections
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func createDirTestCollection() *Collection {
+	return &Collection{
+		Name:        "Test Collection",
+		Description: "Test description",
+		EnvName:     "dev",
+		Items: []*types.RequestItem{
+			{
+				Name: "Login",
+				Request: &types.Request{
+					Method: "POST",
+					URL:    "http://example.com/login",
+					Body: &types.RequestBody{
+						Type:    "application/json",
+						Content: []byte(`{"user": "admin"}`),
+					},
+				},
+			},
+			{
+				Name:   "List users",
+				Folder: "Users",
+				Request: &types.Request{
+					Method: "GET",
+					URL:    "http://example.com/users",
+				},
+			},
+			{
+				Name:   "Delete user",
+				Folder: "Users/Admin",
+				Request: &types.Request{
+					Method: "DELETE",
+					URL:    "http://example.com/users/1",
+				},
+			},
+			{
+				Name: "Health",
+				Request: &types.Request{
+					Method: "GET",
+					URL:    "http://example.com/health",
+				},
+			},
+		},
+	}
+}
+
+func TestUnitSaveCollectionToDir_RoundTrip(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	dirPath := filepath.Join(dir, "test")
+	if err := SaveCollectionToDir(createDirTestCollection(), dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"collection.json", "Login.json", "Health.json", "Users/List users.json", "Users/Admin/Delete user.json"} {
+		if _, err := os.Stat(filepath.Join(dirPath, filepath.FromSlash(name))); err != nil {
+			t.Errorf("expected file %s: %v", name, err)
+		}
+	}
+
+	if !IsCollectionDir(dirPath) {
+		t.Fatal("expected directory to be a collection directory")
+	}
+
+	collection, err := LoadCollectionFromDir(dirPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if collection.Name != "Test Collection" || collection.Description != "Test description" || collection.EnvName != "dev" {
+		t.Errorf("unexpected collection settings: %+v", collection)
+	}
+
+	expected := []struct{ name, folder string }{
+		{"Login", ""},
+		{"List users", "Users"},
+		{"Delete user", "Users/Admin"},
+		{"Health", ""},
+	}
+	if len(collection.Items) != len(expected) {
+		t.Fatalf("expected %d items, got %d", len(expected), len(collection.Items))
+	}
+	for i, item := range collection.Items {
+		if item.Name != expected[i].name || item.Folder != expected[i].folder {
+			t.Errorf("item %d: expected %s in %q, got %s in %q", i, expected[i].name, expected[i].folder, item.Name, item.Folder)
+		}
+	}
+
+	if string(collection.Items[0].Request.Body.Content) != `{"user": "admin"}` {
+		t.Errorf("unexpected body: %s", collection.Items[0].Request.Body.Content)
+	}
+}
+
+func TestUnitSaveCollectionToDir_Deterministic(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	dirPath := filepath.Join(dir, "test")
+	if err := SaveCollectionToDir(createDirTestCollection(), dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, err := os.ReadFile(filepath.Join(dirPath, "Login.json"))
+	if err != nil {
+		t.Fatalf("failed to read request file: %v", err)
+	}
+
+	collection, err := LoadCollectionFromDir(dirPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := os.ReadFile(filepath.Join(dirPath, "Login.json"))
+	if err != nil {
+		t.Fatalf("failed to read request file: %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("expected unchanged file, got:\n%s\nwant:\n%s", second, first)
+	}
+}
+
+func TestUnitSaveCollectionToDir_RemovesStaleFiles(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	dirPath := filepath.Join(dir, "test")
+	collection := createDirTestCollection()
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	readme := filepath.Join(dirPath, "README.md")
+	if err := os.WriteFile(readme, []byte("notes"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	collection.Items = collection.Items[:1]
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dirPath, "Users")); !os.IsNotExist(err) {
+		t.Error("expected empty folder directory to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dirPath, "Health.json")); !os.IsNotExist(err) {
+		t.Error("expected stale request file to be removed")
+	}
+	if _, err := os.Stat(readme); err != nil {
+		t.Errorf("expected other files to be kept: %v", err)
+	}
+}
+
+func TestUnitSaveCollectionToDir_KeepsUnlistedFiles(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	dirPath := filepath.Join(dir, "test")
+	collection := createDirTestCollection()
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Files the manifest does not list, such as fixtures, are not request files of the save.
+	fixture := filepath.Join(dirPath, "fixtures", "user.json")
+	if err := os.MkdirAll(filepath.Dir(fixture), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(fixture, []byte(`{"id": 1}`), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(fixture); err != nil {
+		t.Errorf("expected unlisted file to be kept: %v", err)
+	}
+}
+
+func TestUnitSaveCollectionToDir_FolderNames(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	request := &types.Request{Method: "GET", URL: "http://example.com"}
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{Name: "Time", Folder: "a:b", Request: request},
+			{Name: "List", Folder: "Users/Admin", Request: request},
+		},
+	}
+
+	dirPath := filepath.Join(dir, "test")
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := LoadCollectionFromDir(dirPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Items[0].Folder != "a:b" || loaded.Items[1].Folder != "Users/Admin" {
+		t.Errorf("expected folders to round-trip, got %q and %q", loaded.Items[0].Folder, loaded.Items[1].Folder)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dirPath, "Users", "Admin", "List.json"))
+	if err != nil {
+		t.Fatalf("failed to read request file: %v", err)
+	}
+	if bytes.Contains(data, []byte(`"folder"`)) {
+		t.Errorf("expected the folder to be taken from the directory, got:\n%s", data)
+	}
+}
+
+func TestUnitSaveCollectionToDir_YAMLManifestKept(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	dirPath := filepath.Join(dir, "test")
+	if err := SaveCollectionToDir(createDirTestCollection(), dirPath, storage.FormatYAML); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SaveCollectionToDir(createDirTestCollection(), dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dirPath, "collection.json")); !os.IsNotExist(err) {
+		t.Error("expected no JSON manifest")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dirPath, "Login.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read request file: %v", err)
+	}
+	if !strings.Contains(string(data), `text: '{"user": "admin"}'`) {
+		t.Errorf("expected textual body, got:\n%s", data)
+	}
+}
+
+func TestUnitSaveCollectionToDir_FileNames(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	request := &types.Request{Method: "GET", URL: "http://example.com"}
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{Name: "Get a/b?", Request: request},
+			{Name: "get A/B?", Request: request},
+			{Name: "collection", Request: request},
+			{Name: "Nested", Folder: "../Up", Request: request},
+		},
+	}
+
+	dirPath := filepath.Join(dir, "test")
+	if err := SaveCollectionToDir(collection, dirPath, storage.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, err := requestFiles(dirPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Get a_b_.json", "Up/Nested.json", "collection-2.json", "get A_B_-2.json"}
+	if strings.Join(files, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected files %v, got %v", expected, files)
+	}
+
+	loaded, err := LoadCollectionFromDir(dirPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded.Items) != 4 || loaded.Items[1].Name != "get A/B?" {
+		t.Errorf("unexpected items: %+v", loaded.Items)
+	}
+}
+
+func TestUnitLoadCollectionFromDir_UnlistedAndMissingFiles(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	files := map[string]string{
+		"collection.yaml": "name: Test Collection\nitems:\n  - b.yaml\n  - missing.yaml\n",
+		"a.yaml":          "name: A\nrequest:\n  method: GET\n  url: http://example.com/a\n",
+		"b.yaml":          "name: B\nrequest:\n  method: GET\n  url: http://example.com/b\n",
+		"Folder/c.json":   `{"name": "C", "request": {"method": "GET", "url": "http://example.com/c"}}`,
+		".git/d.json":     `{"name": "D"}`,
+	}
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	collection, err := LoadCollectionFromDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, item := range collection.Items {
+		names = append(names, item.Folder+"/"+item.Name)
+	}
+	if strings.Join(names, ",") != "/B,Folder/C,/A" {
+		t.Errorf("unexpected items: %v", names)
+	}
+}
+
+func TestUnitLoadCollectionFromDir_InvalidPath(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	manifest := `{"name": "Test Collection", "items": ["../outside.json"]}`
+	if err := os.WriteFile(filepath.Join(dir, "collection.json"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if _, err := LoadCollectionFromDir(dir); err == nil {
+		t.Fatal("expected error for path outside the collection directory")
+	}
+}
+
+func TestUnitLoadCollectionFromDir_NoManifest(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	if IsCollectionDir(dir) {
+		t.Error("expected directory without manifest not to be a collection directory")
+	}
+
+	if _, err := LoadCollectionFromDir(dir); err == nil {
+		t.Fatal("expected error for missing manifest")
+	}
+}
@@ -5,16 +5,24 @@ package collections
 
 import (
 	"fmt"
+	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/logging"
+	"github.com/KonnorFrik/getman/snapshot"
 	"github.com/KonnorFrik/getman/types"
+	"google.golang.org/grpc/codes"
 )
 
 // CollectionExecutor executes collections of HTTP requests.
 type CollectionExecutor struct {
 	httpClient       *core.HTTPClient
 	variableResolver *core.VariableResolver
+	snapshots        *snapshot.Store
+	snapshotOptions  *snapshot.Options
+	logger           *slog.Logger
 }
 
 // NewCollectionExecutor creates a new CollectionExecutor instance.
@@ -22,9 +30,37 @@ func NewCollectionExecutor(httpClient *core.HTTPClient, variableResolver *core.V
 	return &CollectionExecutor{
 		httpClient:       httpClient,
 		variableResolver: variableResolver,
+		logger:           logging.Discard(),
 	}
 }
 
+// SetLogger sets the logger of the executor. Every executed request is logged, at the
+// warn level when it fails with an error, and so is the outcome of every run. A nil
+// logger disables logging.
+func (ce *CollectionExecutor) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = logging.Discard()
+	}
+
+	ce.logger = logger
+}
+
+// WithLogger returns a copy of the executor logging to logger, such as a logger that
+// also records the entries of a single run.
+func (ce *CollectionExecutor) WithLogger(logger *slog.Logger) *CollectionExecutor {
+	executor := *ce
+	executor.SetLogger(logger)
+	return &executor
+}
+
+// SetSnapshots enables snapshot testing of collection runs: the response body of every
+// executed item is checked against its snapshot in store, and a mismatch fails the
+// execution. A nil store disables snapshot testing.
+func (ce *CollectionExecutor) SetSnapshots(store *snapshot.Store, opts *snapshot.Options) {
+	ce.snapshots = store
+	ce.snapshotOptions = opts
+}
+
 // ExecuteCollection executes all requests in a collection.
 func (ce *CollectionExecutor) ExecuteCollection(collection *Collection, environment string) (*types.ExecutionResult, error) {
 	return ce.ExecuteCollectionSelective(collection, environment, nil)
@@ -40,36 +76,52 @@ func (ce *CollectionExecutor) ExecuteCollectionAsync(collection *Collection, env
 	go func() {
 		defer close(ch)
 		itemsToExecute := collection.Items
+		logger := ce.logger.With("collection", collection.Name, "environment", environment)
+
+		var snapshotPaths []string
+		if ce.snapshots != nil {
+			snapshotPaths = ce.snapshotPaths(collection, itemsToExecute)
+		}
 
-		for _, item := range itemsToExecute {
+		for i, item := range itemsToExecute {
 			req := item.Request
 			resolvedReq, err := ce.resolveRequest(req)
 
 			if err != nil {
 				execution := &types.RequestExecution{
+					Name:      item.Name,
 					Request:   req,
 					Error:     fmt.Sprintf("failed to resolve variables: %v", err),
 					Duration:  0,
 					Timestamp: time.Now(),
 				}
+				logExecution(logger, execution)
 				ch <- execution
 				continue
 			}
 
 			execStartTime := time.Now()
-			response, err := ce.httpClient.Execute(resolvedReq)
+			exchange, err := ce.httpClient.ExecuteExchange(resolvedReq, nil)
 			execDuration := time.Since(execStartTime)
 			execution := &types.RequestExecution{
-				Request:   resolvedReq,
-				Duration:  execDuration,
-				Timestamp: time.Now(),
+				Name:       item.Name,
+				Request:    resolvedReq,
+				Unresolved: req,
+				Response:   exchange.Response,
+				Events:     exchange.Events,
+				Messages:   exchange.Messages,
+				Duration:   execDuration,
+				Timestamp:  time.Now(),
 			}
 
 			if err != nil {
 				execution.Error = err.Error()
+			}
+
+			logExecution(logger, execution)
 
-			} else {
-				execution.Response = response
+			if snapshotPaths != nil {
+				ce.checkSnapshot(logger, execution, snapshotPaths[i])
 			}
 
 			ch <- execution
@@ -81,8 +133,6 @@ func (ce *CollectionExecutor) ExecuteCollectionAsync(collection *Collection, env
 
 // ExecuteCollectionSelective executes only the specified requests from a collection.
 func (ce *CollectionExecutor) ExecuteCollectionSelective(collection *Collection, environment string, itemNames []string) (*types.ExecutionResult, error) {
-	startTime := time.Now()
-
 	var itemsToExecute []*types.RequestItem
 	if len(itemNames) == 0 {
 		itemsToExecute = collection.Items
@@ -100,6 +150,87 @@ func (ce *CollectionExecutor) ExecuteCollectionSelective(collection *Collection,
 		}
 	}
 
+	return ce.executeRequests(collection, environment, itemsToExecute, true, true), nil
+}
+
+// checkSnapshots checks the responses of a collection run against their snapshots
+// and counts successful executions whose snapshot does not match as failed.
+func (ce *CollectionExecutor) checkSnapshots(logger *slog.Logger, collection *Collection, items []*types.RequestItem, result *types.ExecutionResult) {
+	if ce.snapshots == nil {
+		return
+	}
+
+	paths := ce.snapshotPaths(collection, items)
+	for i, execution := range result.Requests {
+		if ce.checkSnapshot(logger, execution, paths[i]) {
+			result.Statistics.Success--
+			result.Statistics.Failed++
+		}
+	}
+}
+
+// checkSnapshot checks the response of an execution against the snapshot at path. It
+// reports whether an otherwise successful execution failed the check. The check of a
+// failed execution is skipped, so that error responses are never recorded; a response
+// whose body was not kept in full fails the check.
+func (ce *CollectionExecutor) checkSnapshot(logger *slog.Logger, execution *types.RequestExecution, path string) bool {
+	response := execution.Response
+
+	switch {
+	case execution.Error != "" || response == nil || !IsSuccessful(execution.Request, response):
+		execution.Snapshot = &types.SnapshotResult{Status: types.SnapshotSkipped, Path: path}
+		return false
+	case response.Truncated || response.BodyFile != "":
+		execution.Snapshot = &types.SnapshotResult{Path: path, Error: "the response body was not kept in full"}
+	default:
+		execution.Snapshot = ce.snapshots.CheckPath(path, response.Body, ce.snapshotOptions)
+	}
+
+	switch {
+	case execution.Snapshot.Error != "":
+		logger.Warn("snapshot check failed", "item", execution.Name, "error", execution.Snapshot.Error)
+	case execution.Snapshot.Status == types.SnapshotMismatched:
+		logger.Warn("snapshot mismatch", "item", execution.Name, "path", execution.Snapshot.Path,
+			"mismatches", len(execution.Snapshot.Mismatches))
+	}
+
+	return execution.Snapshot.Status == types.SnapshotMismatched || execution.Snapshot.Error != ""
+}
+
+// snapshotPaths returns the snapshot paths of items. The paths are those of the items
+// within the whole collection, so that running a selection keeps them.
+func (ce *CollectionExecutor) snapshotPaths(collection *Collection, items []*types.RequestItem) []string {
+	collectionPaths := ce.snapshots.Paths(collection.Name, collection.Items)
+	itemPaths := make(map[*types.RequestItem]string, len(collectionPaths))
+	for i, item := range collection.Items {
+		itemPaths[item] = collectionPaths[i]
+	}
+
+	paths := make([]string, len(items))
+	for i, item := range items {
+		if paths[i] = itemPaths[item]; paths[i] == "" {
+			paths[i] = ce.snapshots.Path(collection.Name, item)
+		}
+	}
+
+	return paths
+}
+
+// ExecuteRequests executes the requests of items in order and collects the results
+// under the given collection and environment names, as ExecuteCollection does, but
+// without snapshot checks. With resolve false the requests are sent as they are,
+// which replays requests that were already resolved.
+func (ce *CollectionExecutor) ExecuteRequests(collectionName, environment string, items []*types.RequestItem, resolve bool) *types.ExecutionResult {
+	return ce.executeRequests(&Collection{Name: collectionName}, environment, items, resolve, false)
+}
+
+// executeRequests executes the requests of items like ExecuteRequests. With snapshots
+// set the responses are checked against the snapshots of the items in collection.
+func (ce *CollectionExecutor) executeRequests(collection *Collection, environment string, items []*types.RequestItem, resolve, snapshots bool) *types.ExecutionResult {
+	collectionName := collection.Name
+	startTime := time.Now()
+	logger := ce.logger.With("collection", collectionName, "environment", environment)
+
 	var (
 		executions                []*types.RequestExecution
 		totalDuration             time.Duration
@@ -108,24 +239,31 @@ func (ce *CollectionExecutor) ExecuteCollectionSelective(collection *Collection,
 		firstTime                 = true
 	)
 
-	for _, item := range itemsToExecute {
+	for _, item := range items {
 		req := item.Request
+		resolvedReq := req
+		var err error
+
+		if resolve {
+			resolvedReq, err = ce.resolveRequest(req)
+		}
 
-		resolvedReq, err := ce.resolveRequest(req)
 		if err != nil {
 			execution := &types.RequestExecution{
+				Name:      item.Name,
 				Request:   req,
 				Error:     fmt.Sprintf("failed to resolve variables: %v", err),
 				Duration:  0,
 				Timestamp: time.Now(),
 			}
+			logExecution(logger, execution)
 			executions = append(executions, execution)
 			failedCount++
 			continue
 		}
 
 		execStartTime := time.Now()
-		response, err := ce.httpClient.Execute(resolvedReq)
+		exchange, err := ce.httpClient.ExecuteExchange(resolvedReq, nil)
 		execDuration := time.Since(execStartTime)
 		totalDuration += execDuration
 
@@ -143,23 +281,29 @@ func (ce *CollectionExecutor) ExecuteCollectionSelective(collection *Collection,
 		}
 
 		execution := &types.RequestExecution{
+			Name:      item.Name,
 			Request:   resolvedReq,
+			Response:  exchange.Response,
+			Events:    exchange.Events,
+			Messages:  exchange.Messages,
 			Duration:  execDuration,
 			Timestamp: time.Now(),
 		}
 
+		if resolve {
+			execution.Unresolved = req
+		}
+
 		if err != nil {
 			execution.Error = err.Error()
 			failedCount++
+		} else if IsSuccessful(resolvedReq, exchange.Response) {
+			successCount++
 		} else {
-			execution.Response = response
-			if response.StatusCode >= 200 && response.StatusCode < 300 {
-				successCount++
-			} else {
-				failedCount++
-			}
+			failedCount++
 		}
 
+		logExecution(logger, execution)
 		executions = append(executions, execution)
 	}
 
@@ -170,7 +314,7 @@ func (ce *CollectionExecutor) ExecuteCollectionSelective(collection *Collection,
 	}
 
 	result := &types.ExecutionResult{
-		CollectionName: collection.Name,
+		CollectionName: collectionName,
 		Environment:    environment,
 		StartTime:      startTime,
 		EndTime:        endTime,
@@ -183,10 +327,122 @@ func (ce *CollectionExecutor) ExecuteCollectionSelective(collection *Collection,
 			AvgTime: avgTime,
 			MinTime: minTime,
 			MaxTime: maxTime,
+			Timings: aggregateTimings(executions),
 		},
 	}
 
-	return result, nil
+	if snapshots {
+		ce.checkSnapshots(logger, collection, items, result)
+	}
+
+	logger.Info("requests executed", "total", result.Statistics.Total, "success", result.Statistics.Success,
+		"failed", result.Statistics.Failed, "duration", result.TotalDuration)
+
+	return result
+}
+
+// NewRequestResult wraps the execution of a single request in an ExecutionResult,
+// with the statistics ExecuteCollection would give for it.
+func NewRequestResult(collectionName, environment string, execution *types.RequestExecution) *types.ExecutionResult {
+	stats := &types.Statistics{
+		Total:   1,
+		AvgTime: execution.Duration,
+		MinTime: execution.Duration,
+		MaxTime: execution.Duration,
+		Timings: aggregateTimings([]*types.RequestExecution{execution}),
+	}
+
+	if execution.Error == "" && execution.Response != nil && IsSuccessful(execution.Request, execution.Response) {
+		stats.Success = 1
+	} else {
+		stats.Failed = 1
+	}
+
+	startTime := execution.Timestamp.Add(-execution.Duration)
+
+	return &types.ExecutionResult{
+		CollectionName: collectionName,
+		Environment:    environment,
+		StartTime:      startTime,
+		EndTime:        execution.Timestamp,
+		TotalDuration:  execution.Duration,
+		Requests:       []*types.RequestExecution{execution},
+		Statistics:     stats,
+	}
+}
+
+// logExecution logs an executed request: executions failed with an error at the
+// warn level, others at the debug level.
+func logExecution(logger *slog.Logger, execution *types.RequestExecution) {
+	req := execution.Request
+
+	if execution.Error != "" {
+		logger.Warn("request failed", "item", execution.Name, "method", req.Method, "url", req.URL, "error", execution.Error)
+		return
+	}
+
+	status := any(execution.Response.StatusCode)
+	if execution.Response.GRPCCode != nil {
+		status = execution.Response.Status
+	}
+
+	logger.Debug("request executed", "item", execution.Name, "method", req.Method, "url", req.URL,
+		"status", status, "duration", execution.Duration)
+}
+
+// IsSuccessful reports whether a response counts as a successful execution.
+// Besides 2xx statuses this includes 101 Switching Protocols of a completed WebSocket exchange
+// and the OK status of a gRPC call.
+func IsSuccessful(req *types.Request, response *types.Response) bool {
+	if response.GRPCCode != nil {
+		return *response.GRPCCode == int(codes.OK)
+	}
+
+	if response.StatusCode == http.StatusSwitchingProtocols {
+		return true
+	}
+
+	return response.StatusCode >= 200 && response.StatusCode < 300
+}
+
+// aggregateTimings averages phase timings over all executions that have them.
+// It returns nil when no execution has timings.
+func aggregateTimings(executions []*types.RequestExecution) *types.TimingStatistics {
+	var (
+		stats types.TimingStatistics
+		count time.Duration
+	)
+
+	for _, execution := range executions {
+		if execution.Response == nil || execution.Response.Timings == nil {
+			continue
+		}
+
+		timings := execution.Response.Timings
+		stats.AvgDNSLookup += timings.DNSLookup
+		stats.AvgTCPConnect += timings.TCPConnect
+		stats.AvgTLSHandshake += timings.TLSHandshake
+		stats.AvgTimeToFirstByte += timings.TimeToFirstByte
+		stats.AvgContentTransfer += timings.ContentTransfer
+
+		if timings.ConnectionReused {
+			stats.ReusedConnections++
+		}
+
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	stats.AvgDNSLookup /= count
+	stats.AvgTCPConnect /= count
+	stats.AvgTLSHandshake /= count
+	stats.AvgTimeToFirstByte /= count
+	stats.AvgContentTransfer /= count
+
+	return &stats
 }
 
 func (ce *CollectionExecutor) resolveRequest(req *types.Request) (*types.Request, error) {
@@ -201,13 +457,25 @@ func (ce *CollectionExecutor) resolveRequest(req *types.Request) (*types.Request
 	}
 
 	resolvedReq := &types.Request{
-		Method:  req.Method,
-		URL:     resolvedURL,
-		Headers: resolvedHeaders,
-		Body:    req.Body,
-		Auth:    req.Auth,
-		Timeout: req.Timeout,
-		Cookies: req.Cookies,
+		Method:       req.Method,
+		URL:          resolvedURL,
+		Headers:      resolvedHeaders,
+		Body:         req.Body,
+		Auth:         req.Auth,
+		Timeout:      req.Timeout,
+		Cookies:      req.Cookies,
+		Proxy:        req.Proxy,
+		TLS:          req.TLS,
+		ResponseBody: req.ResponseBody,
+		Stream:       req.Stream,
+		GRPC:         req.GRPC,
+	}
+
+	if req.WebSocket != nil {
+		resolvedReq.WebSocket, err = ce.variableResolver.ResolveWebSocket(req.WebSocket)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if req.Body != nil && len(req.Body.Content) > 0 {
@@ -219,7 +487,18 @@ func (ce *CollectionExecutor) resolveRequest(req *types.Request) (*types.Request
 			Type:        req.Body.Type,
 			Content:     []byte(resolvedBodyContent),
 			ContentType: req.Body.ContentType,
+			GraphQL:     req.Body.GraphQL,
+		}
+	}
+
+	if req.Body != nil && req.Body.GraphQL != nil {
+		resolvedGraphQL, err := ce.variableResolver.ResolveGraphQL(req.Body.GraphQL)
+		if err != nil {
+			return nil, err
 		}
+		resolvedBody := *resolvedReq.Body
+		resolvedBody.GraphQL = resolvedGraphQL
+		resolvedReq.Body = &resolvedBody
 	}
 
 	if req.Auth != nil {
@@ -2,10 +2,12 @@ package collections
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/grpc_server"
 	"github.com/KonnorFrik/getman/testutil/http_server"
 	"github.com/KonnorFrik/getman/types"
 	"github.com/KonnorFrik/getman/environment"
@@ -302,3 +304,194 @@ func TestIntegrationExecuteCollectionSelective(t *testing.T) {
 	}
 }
 
+
+func TestIntegrationExecuteCollection_WebSocket(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	env := environment.NewEnvironment("global")
+	env.Set("token", "wstoken")
+	env.Set("greeting", "hello websocket")
+	resolver, err := core.NewVariableResolver(env, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+
+	collection := &Collection{
+		Name: "WebSocket Collection",
+		Items: []*types.RequestItem{
+			{
+				Name: "Echo",
+				Request: &types.Request{
+					URL:  "ws" + strings.TrimPrefix(http_server.GetServerURL(), "http") + "/ws/echo?greet=1",
+					Auth: &types.Auth{Type: "bearer", Token: "{{token}}"},
+					WebSocket: &types.WebSocketSettings{
+						Timeout: 5 * time.Second,
+						Steps: []types.WebSocketStep{
+							{Expect: "Bearer wstoken"},
+							{Send: "{{greeting}}", Expect: "{{greeting}}"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	execution := result.Requests[0]
+	if execution.Error != "" {
+		t.Fatalf("unexpected execution error: %s", execution.Error)
+	}
+
+	if result.Statistics.Success != 1 {
+		t.Errorf("expected success 1, got %d", result.Statistics.Success)
+	}
+
+	if len(execution.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(execution.Messages))
+	}
+
+	if execution.Messages[1].Data != "hello websocket" || execution.Messages[2].Data != "hello websocket" {
+		t.Errorf("expected resolved message to be echoed, got %+v %+v", execution.Messages[1], execution.Messages[2])
+	}
+}
+
+func TestIntegrationExecuteCollection_GraphQL(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	env := environment.NewEnvironment("global")
+	env.Set("userId", "42")
+	resolver, err := core.NewVariableResolver(env, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+
+	query, err := core.NewRequestBuilder().
+		URL(http_server.GetServerURL() + "/graphql").
+		GraphQL("query User($id: ID!) { user(id: $id) { name } }", "User", map[string]any{"id": "{{userId}}"}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	failing, err := core.NewRequestBuilder().
+		URL(http_server.GetServerURL() + "/graphql").
+		GraphQL("query { fail }", "", nil).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection := &Collection{
+		Name: "GraphQL Collection",
+		Items: []*types.RequestItem{
+			{Name: "Query", Request: query},
+			{Name: "Failing", Request: failing},
+		},
+	}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Statistics.Success != 1 || result.Statistics.Failed != 1 {
+		t.Errorf("expected 1 success and 1 failure, got %d and %d", result.Statistics.Success, result.Statistics.Failed)
+	}
+
+	first := result.Requests[0]
+	if first.Error != "" {
+		t.Fatalf("unexpected execution error: %s", first.Error)
+	}
+
+	if !strings.Contains(string(first.Response.Body), `"id":"42"`) {
+		t.Errorf("expected resolved variable to be sent, got %s", string(first.Response.Body))
+	}
+
+	second := result.Requests[1]
+	if !strings.Contains(second.Error, "query failed") {
+		t.Errorf("expected GraphQL error to be reported, got %q", second.Error)
+	}
+
+	if second.Response == nil || second.Response.StatusCode != http.StatusOK {
+		t.Errorf("expected response with status 200 to be kept, got %+v", second.Response)
+	}
+}
+
+func TestIntegrationExecuteCollection_GRPC(t *testing.T) {
+	server, err := grpc_server.StartGRPCServer(true)
+	if err != nil {
+		t.Fatalf("failed to start gRPC server: %v", err)
+	}
+	defer server.Close()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	env := environment.NewEnvironment("global")
+	env.Set("name", "collection")
+	resolver, err := core.NewVariableResolver(env, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+
+	collection := &Collection{
+		Name: "gRPC Collection",
+		Items: []*types.RequestItem{
+			{
+				Name: "SayHello",
+				Request: &types.Request{
+					URL:  server.URL(grpc_server.SayHelloMethod),
+					Body: &types.RequestBody{Type: "json", Content: []byte(`{"name": "{{name}}"}`)},
+				},
+			},
+			{
+				Name:    "Fail",
+				Request: &types.Request{URL: server.URL(grpc_server.FailMethod)},
+			},
+		},
+	}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Statistics.Success != 1 || result.Statistics.Failed != 1 {
+		t.Errorf("expected 1 success and 1 failure, got %d and %d", result.Statistics.Success, result.Statistics.Failed)
+	}
+
+	first := result.Requests[0]
+	if first.Error != "" {
+		t.Fatalf("unexpected execution error: %s", first.Error)
+	}
+
+	if !strings.Contains(string(first.Response.Body), "Hello, collection") {
+		t.Errorf("expected resolved variable in the message, got %s", string(first.Response.Body))
+	}
+
+	second := result.Requests[1]
+	if second.Response == nil || second.Response.GRPCCode == nil || *second.Response.GRPCCode != 5 {
+		t.Errorf("expected NotFound status to be recorded, got %+v", second.Response)
+	}
+}
@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +10,9 @@ import (
 
 	"github.com/KonnorFrik/getman/core"
 	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/logging"
+	"github.com/KonnorFrik/getman/snapshot"
+	"github.com/KonnorFrik/getman/testutil/helper"
 	"github.com/KonnorFrik/getman/types"
 )
 
@@ -312,6 +316,14 @@ func TestUnitExecuteCollection_Statistics(t *testing.T) {
 	if result.Statistics.MaxTime <= 0 {
 		t.Errorf("expected max time to be positive, got %v", result.Statistics.MaxTime)
 	}
+
+	if result.Statistics.Timings == nil {
+		t.Fatal("expected timing statistics to be set")
+	}
+
+	if result.Statistics.Timings.ReusedConnections != 1 {
+		t.Errorf("expected 1 reused connection, got %d", result.Statistics.Timings.ReusedConnections)
+	}
 }
 
 func TestUnitExecuteCollectionSelective_AllItems(t *testing.T) {
@@ -1064,6 +1076,10 @@ func TestUnitExecuteCollectionAsync_WithVariables(t *testing.T) {
 			t.Errorf("expected resolved URL '%s/api/users', got %s", server.URL, execution.Request.URL)
 		}
 
+		if execution.Unresolved != collection.Items[0].Request {
+			t.Error("expected the unresolved request to be kept")
+		}
+
 		if execution.Error != "" {
 			t.Errorf("unexpected error: %s", execution.Error)
 		}
@@ -1131,3 +1147,450 @@ func TestUnitExecuteCollectionAsync_StatusCodeFailure(t *testing.T) {
 		t.Fatal("timeout waiting for execution result")
 	}
 }
+
+func TestUnitAggregateTimings(t *testing.T) {
+	executions := []*types.RequestExecution{
+		{
+			Response: &types.Response{
+				Timings: &types.Timings{
+					DNSLookup:        10 * time.Millisecond,
+					TimeToFirstByte:  100 * time.Millisecond,
+					ContentTransfer:  20 * time.Millisecond,
+					ConnectionReused: false,
+				},
+			},
+		},
+		{
+			Response: &types.Response{
+				Timings: &types.Timings{
+					TimeToFirstByte:  50 * time.Millisecond,
+					ContentTransfer:  10 * time.Millisecond,
+					ConnectionReused: true,
+				},
+			},
+		},
+		{Error: "request failed"},
+		{Response: &types.Response{}},
+	}
+
+	stats := aggregateTimings(executions)
+	if stats == nil {
+		t.Fatal("expected timing statistics to be set")
+	}
+
+	if stats.AvgDNSLookup != 5*time.Millisecond {
+		t.Errorf("expected avg DNS lookup 5ms, got %v", stats.AvgDNSLookup)
+	}
+
+	if stats.AvgTimeToFirstByte != 75*time.Millisecond {
+		t.Errorf("expected avg TTFB 75ms, got %v", stats.AvgTimeToFirstByte)
+	}
+
+	if stats.AvgContentTransfer != 15*time.Millisecond {
+		t.Errorf("expected avg content transfer 15ms, got %v", stats.AvgContentTransfer)
+	}
+
+	if stats.ReusedConnections != 1 {
+		t.Errorf("expected 1 reused connection, got %d", stats.ReusedConnections)
+	}
+}
+
+func TestUnitAggregateTimings_NoTimings(t *testing.T) {
+	if stats := aggregateTimings([]*types.RequestExecution{{Error: "failed"}}); stats != nil {
+		t.Errorf("expected nil timing statistics, got %+v", stats)
+	}
+}
+
+func TestUnitExecuteCollection_StreamEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("event: ping\ndata: one\n\nevent: ping\ndata: two\n\n"))
+	}))
+	defer server.Close()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	env := environment.NewEnvironment("global")
+	resolver, err := core.NewVariableResolver(env, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+
+	collection := &Collection{
+		Name: "Stream Collection",
+		Items: []*types.RequestItem{
+			{
+				Name: "Events",
+				Request: &types.Request{
+					Method: http.MethodGet,
+					URL:    server.URL,
+					Stream: &types.StreamSettings{MaxEvents: 5},
+				},
+			},
+		},
+	}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	execution := result.Requests[0]
+	if execution.Error != "" {
+		t.Fatalf("unexpected execution error: %s", execution.Error)
+	}
+
+	if len(execution.Events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(execution.Events))
+	}
+
+	if execution.Events[1].Data != "two" {
+		t.Errorf("expected second event data 'two', got %q", execution.Events[1].Data)
+	}
+}
+
+func TestUnitExecuteRequests_WithoutResolve(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	env := environment.NewEnvironment("global")
+	env.Set("id", "42")
+	resolver, err := core.NewVariableResolver(env, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+	requests := []*types.Request{{Method: http.MethodGet, URL: server.URL + "/users/{{id}}"}}
+	items := []*types.RequestItem{{Name: "Get user", Request: requests[0]}}
+
+	resolved := executor.ExecuteRequests("replay", "dev", items, true)
+	if resolved.CollectionName != "replay" || resolved.Environment != "dev" || resolved.Statistics.Success != 1 {
+		t.Errorf("unexpected result: %+v", resolved)
+	}
+	if resolved.Requests[0].Unresolved != requests[0] {
+		t.Error("expected the unresolved request to be kept")
+	}
+	if resolved.Requests[0].Name != "Get user" {
+		t.Errorf("expected the item name to be kept, got %q", resolved.Requests[0].Name)
+	}
+
+	asSent := executor.ExecuteRequests("replay", "dev", items, false)
+	if asSent.Requests[0].Unresolved != nil {
+		t.Error("expected no unresolved request without resolving")
+	}
+
+	if len(paths) != 2 || paths[0] != "/users/42" || paths[1] != "/users/{{id}}" {
+		t.Errorf("unexpected request paths: %v", paths)
+	}
+}
+
+func TestUnitNewRequestResult(t *testing.T) {
+	now := time.Now()
+	execution := &types.RequestExecution{
+		Request:   &types.Request{Method: http.MethodGet, URL: "http://example.com"},
+		Response:  &types.Response{StatusCode: http.StatusNotFound},
+		Duration:  time.Second,
+		Timestamp: now,
+	}
+
+	result := NewRequestResult("", "dev", execution)
+
+	if result.Environment != "dev" || len(result.Requests) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Statistics.Total != 1 || result.Statistics.Failed != 1 || result.Statistics.MaxTime != time.Second {
+		t.Errorf("unexpected statistics: %+v", result.Statistics)
+	}
+	if !result.StartTime.Equal(now.Add(-time.Second)) || !result.EndTime.Equal(now) {
+		t.Errorf("unexpected times: %v - %v", result.StartTime, result.EndTime)
+	}
+}
+
+func TestUnitExecuteCollection_Snapshots(t *testing.T) {
+	body := `{"id": 1}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+	executor.SetSnapshots(snapshot.NewStore(dir), nil)
+
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{Name: "Test Request", Request: &types.Request{Method: http.MethodGet, URL: server.URL}},
+		},
+	}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Name != "Test Request" {
+		t.Errorf("expected the item name to be kept, got %q", result.Requests[0].Name)
+	}
+	if result.Requests[0].Snapshot == nil || result.Requests[0].Snapshot.Status != types.SnapshotCreated {
+		t.Fatalf("expected created snapshot, got %+v", result.Requests[0].Snapshot)
+	}
+	if result.Statistics.Success != 1 {
+		t.Errorf("expected success 1, got %d", result.Statistics.Success)
+	}
+
+	body = `{"id": 2}`
+
+	result, err = executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot.Status != types.SnapshotMismatched {
+		t.Errorf("expected mismatched snapshot, got %+v", result.Requests[0].Snapshot)
+	}
+	if result.Statistics.Success != 0 || result.Statistics.Failed != 1 {
+		t.Errorf("expected the mismatch to fail the request, got %+v", result.Statistics)
+	}
+
+	executor.SetSnapshots(nil, nil)
+
+	result, err = executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot != nil || result.Statistics.Success != 1 {
+		t.Errorf("expected no snapshot check when disabled, got %+v", result.Requests[0].Snapshot)
+	}
+}
+
+func TestUnitExecuteCollection_SnapshotsSameName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Query().Get("id")))
+	}))
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+	executor.SetSnapshots(snapshot.NewStore(dir), nil)
+
+	first := &types.RequestItem{Name: "Get", Request: &types.Request{Method: http.MethodGet, URL: server.URL + "?id=1"}}
+	second := &types.RequestItem{Name: "Get", Request: &types.Request{Method: http.MethodGet, URL: server.URL + "?id=2"}}
+	collection := &Collection{Name: "Test Collection", Items: []*types.RequestItem{first, second}}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot.Path == result.Requests[1].Snapshot.Path {
+		t.Fatalf("expected distinct snapshots, got %q twice", result.Requests[0].Snapshot.Path)
+	}
+
+	result, err = executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, execution := range result.Requests {
+		if execution.Snapshot.Status != types.SnapshotMatched {
+			t.Errorf("expected matched snapshot, got %+v", execution.Snapshot)
+		}
+	}
+
+	// A selection of items keeps the paths the items have in the whole collection.
+	if paths := executor.snapshotPaths(collection, []*types.RequestItem{second}); paths[0] != result.Requests[1].Snapshot.Path {
+		t.Errorf("expected the path %q of the item in its collection, got %q", result.Requests[1].Snapshot.Path, paths[0])
+	}
+}
+
+func TestUnitExecuteCollection_SnapshotsOfFailures(t *testing.T) {
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id": 1, "name": "a long enough body"}`))
+	}))
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store := snapshot.NewStore(dir)
+	executor := NewCollectionExecutor(httpClient, resolver)
+	executor.SetSnapshots(store, nil)
+
+	item := &types.RequestItem{Name: "Get", Request: &types.Request{Method: http.MethodGet, URL: server.URL}}
+	truncated := &types.RequestItem{Name: "Truncated", Request: &types.Request{
+		Method:       http.MethodGet,
+		URL:          server.URL,
+		ResponseBody: &types.ResponseBodySettings{MaxSize: 4},
+	}}
+	unreachable := &types.RequestItem{Name: "Unreachable", Request: &types.Request{Method: http.MethodGet, URL: "http://127.0.0.1:1"}}
+	collection := &Collection{Name: "Test Collection", Items: []*types.RequestItem{item, truncated, unreachable}}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, execution := range []*types.RequestExecution{result.Requests[0], result.Requests[1], result.Requests[2]} {
+		if execution.Snapshot == nil || execution.Snapshot.Status != types.SnapshotSkipped {
+			t.Errorf("expected a skipped snapshot check for %s, got %+v", execution.Name, execution.Snapshot)
+		}
+	}
+	if _, err := store.Load(collection.Name, item); err == nil {
+		t.Error("expected no snapshot to be recorded from an error response")
+	}
+	if result.Statistics.Failed != 3 {
+		t.Errorf("expected 3 failures, got %+v", result.Statistics)
+	}
+
+	status = http.StatusOK
+
+	result, err = executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requests[0].Snapshot.Status != types.SnapshotCreated {
+		t.Errorf("expected created snapshot, got %+v", result.Requests[0].Snapshot)
+	}
+	if snapshot := result.Requests[1].Snapshot; snapshot.Error == "" || snapshot.Status != "" {
+		t.Errorf("expected the truncated body to fail the check, got %+v", snapshot)
+	}
+	if _, err := store.Load(collection.Name, truncated); err == nil {
+		t.Error("expected no snapshot to be recorded from a truncated body")
+	}
+	if result.Statistics.Success != 1 || result.Statistics.Failed != 2 {
+		t.Errorf("expected 1 success and 2 failures, got %+v", result.Statistics)
+	}
+}
+
+func TestUnitExecuteCollectionAsync_Snapshots(t *testing.T) {
+	body := `{"id": 1}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+	executor.SetSnapshots(snapshot.NewStore(dir), nil)
+
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{Name: "Test Request", Request: &types.Request{Method: http.MethodGet, URL: server.URL}},
+		},
+	}
+
+	execution := <-executor.ExecuteCollectionAsync(collection, "test")
+	if execution.Snapshot == nil || execution.Snapshot.Status != types.SnapshotCreated {
+		t.Fatalf("expected created snapshot, got %+v", execution.Snapshot)
+	}
+
+	body = `{"id": 2}`
+
+	execution = <-executor.ExecuteCollectionAsync(collection, "test")
+	if execution.Snapshot == nil || execution.Snapshot.Status != types.SnapshotMismatched {
+		t.Errorf("expected mismatched snapshot, got %+v", execution.Snapshot)
+	}
+}
+
+func TestUnitExecuteCollection_Logging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := NewCollectionExecutor(httpClient, resolver)
+
+	collection := &Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{Name: "ok", Request: &types.Request{Method: http.MethodGet, URL: server.URL + "?token=abc"}},
+			{Name: "unreachable", Request: &types.Request{Method: http.MethodGet, URL: "http://127.0.0.1:1"}},
+		},
+	}
+
+	recorder := logging.NewRecorder(slog.LevelDebug)
+	runExecutor := executor.WithLogger(slog.New(recorder))
+
+	if _, err := runExecutor.ExecuteCollection(collection, "test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := recorder.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+	if entries[0].Level != "debug" || !strings.Contains(entries[0].Message, "token=***") || !strings.Contains(entries[0].Message, "status=200") {
+		t.Errorf("unexpected entry for the executed request: %+v", entries[0])
+	}
+	if entries[1].Level != "warn" || !strings.HasPrefix(entries[1].Message, "request failed") {
+		t.Errorf("unexpected entry for the failed request: %+v", entries[1])
+	}
+	if entries[2].Level != "info" || !strings.Contains(entries[2].Message, `collection="Test Collection"`) ||
+		!strings.Contains(entries[2].Message, "success=1 failed=1") {
+		t.Errorf("unexpected entry for the run: %+v", entries[2])
+	}
+
+	if _, err := executor.ExecuteCollection(collection, "test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorder.Entries()) != 3 {
+		t.Error("expected the original executor to keep its logger")
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+const bodyChunkSize = 32 * 1024
+
+// ChunkHandler is called for every chunk of the response body as it is read.
+// The chunk is only valid for the duration of the call. Returning an error aborts reading.
+type ChunkHandler func(chunk []byte) error
+
+// ExecuteOptions controls how the response body is consumed.
+type ExecuteOptions struct {
+	// MaxBodySize limits the number of body bytes kept in memory. Zero sets no limit of
+	// its own, so the request limit or else the client default applies; a negative value
+	// disables the limit.
+	MaxBodySize int64
+	// Sink receives the whole body instead of keeping it in memory.
+	Sink io.Writer
+	// OnChunk is called for every chunk read from the body.
+	OnChunk ChunkHandler
+}
+
+type bodyOptions struct {
+	limit   int64
+	sink    io.Writer
+	onChunk ChunkHandler
+	file    string
+	closer  io.Closer
+}
+
+type bodyResult struct {
+	data      []byte
+	size      int64
+	truncated bool
+}
+
+// SetMaxBodySize sets the default number of body bytes kept in memory. It applies to
+// requests whose ExecuteOptions and ResponseBody settings have a limit of zero. Bytes
+// beyond the limit are read and counted but discarded. Zero or a negative value keeps
+// whole bodies.
+func (hc *HTTPClient) SetMaxBodySize(size int64) {
+	hc.maxBodySize.Store(size)
+}
+
+// GetMaxBodySize returns the default number of body bytes kept in memory.
+func (hc *HTTPClient) GetMaxBodySize() int64 {
+	return hc.maxBodySize.Load()
+}
+
+func (hc *HTTPClient) bodyOptions(req *types.Request, opts *ExecuteOptions) (*bodyOptions, error) {
+	result := &bodyOptions{
+		limit: hc.maxBodySize.Load(),
+	}
+
+	if req.ResponseBody != nil && req.ResponseBody.MaxSize != 0 {
+		result.limit = req.ResponseBody.MaxSize
+	}
+
+	if opts != nil {
+		if opts.MaxBodySize != 0 {
+			result.limit = opts.MaxBodySize
+		}
+
+		result.sink = opts.Sink
+		result.onChunk = opts.OnChunk
+	}
+
+	if result.sink == nil && req.ResponseBody != nil && req.ResponseBody.OutputFile != "" {
+		file, err := os.Create(req.ResponseBody.OutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to create output file: %w", errors.ErrStorageError, err)
+		}
+
+		result.sink = file
+		result.file = req.ResponseBody.OutputFile
+		result.closer = file
+	}
+
+	return result, nil
+}
+
+func (bo *bodyOptions) close() error {
+	if bo.closer == nil {
+		return nil
+	}
+
+	err := bo.closer.Close()
+	bo.closer = nil
+
+	if err != nil {
+		return fmt.Errorf("%w: failed to close output file: %w", errors.ErrStorageError, err)
+	}
+
+	return nil
+}
+
+// remove closes and removes the output file, which is left incomplete when the
+// response body could not be read.
+func (bo *bodyOptions) remove() {
+	if bo.file == "" {
+		return
+	}
+
+	bo.close()
+	os.Remove(bo.file)
+}
+
+// readBody reads the whole body, passing chunks to the handler and the sink.
+// Without a sink, at most opts.limit bytes are kept in memory, the rest is only counted.
+func readBody(r io.Reader, opts *bodyOptions) (*bodyResult, error) {
+	var result bodyResult
+	buf := make([]byte, bodyChunkSize)
+
+	for {
+		n, err := r.Read(buf)
+
+		if n > 0 {
+			if err := result.consume(buf[:n], opts); err != nil {
+				return nil, err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// consume counts the chunk and passes it to the chunk handler and the sink,
+// or keeps it in memory within the limit when there is no sink.
+func (br *bodyResult) consume(chunk []byte, opts *bodyOptions) error {
+	br.size += int64(len(chunk))
+
+	if opts.onChunk != nil {
+		if err := opts.onChunk(chunk); err != nil {
+			return err
+		}
+	}
+
+	if opts.sink != nil {
+		if _, err := opts.sink.Write(chunk); err != nil {
+			return fmt.Errorf("failed to write response body: %w", err)
+		}
+
+		return nil
+	}
+
+	br.appendLimited(chunk, opts.limit)
+	return nil
+}
+
+func (br *bodyResult) appendLimited(chunk []byte, limit int64) {
+	if limit <= 0 {
+		br.data = append(br.data, chunk...)
+		return
+	}
+
+	remaining := limit - int64(len(br.data))
+
+	if remaining <= 0 {
+		br.truncated = true
+		return
+	}
+
+	if int64(len(chunk)) > remaining {
+		chunk = chunk[:remaining]
+		br.truncated = true
+	}
+
+	br.data = append(br.data, chunk...)
+}
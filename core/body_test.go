@@ -0,0 +1,304 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func newLargeBodyServer(size int) *httptest.Server {
+	body := strings.Repeat("a", size)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestUnitSetMaxBodySize_Concurrent(t *testing.T) {
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{Method: http.MethodGet, URL: "http://example.com"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.SetMaxBodySize(1024)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := client.bodyOptions(req, nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if client.GetMaxBodySize() != 1024 {
+		t.Errorf("expected a limit of 1024, got %d", client.GetMaxBodySize())
+	}
+}
+
+func TestUnitExecute_MaxBodySize(t *testing.T) {
+	server := newLargeBodyServer(100 * 1024)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.SetMaxBodySize(1024)
+
+	resp, err := client.Execute(&types.Request{Method: http.MethodGet, URL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Body) != 1024 {
+		t.Errorf("expected 1024 bytes in memory, got %d", len(resp.Body))
+	}
+
+	if !resp.Truncated {
+		t.Error("expected response to be marked as truncated")
+	}
+
+	if resp.Size != 100*1024 {
+		t.Errorf("expected size %d, got %d", 100*1024, resp.Size)
+	}
+}
+
+func TestUnitExecute_MaxBodySize_NotExceeded(t *testing.T) {
+	server := newLargeBodyServer(512)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.SetMaxBodySize(1024)
+
+	resp, err := client.Execute(&types.Request{Method: http.MethodGet, URL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Truncated {
+		t.Error("expected response not to be truncated")
+	}
+
+	if len(resp.Body) != 512 || resp.Size != 512 {
+		t.Errorf("expected full 512 byte body, got %d bytes (size %d)", len(resp.Body), resp.Size)
+	}
+}
+
+func TestUnitExecute_RequestMaxBodySizeOverride(t *testing.T) {
+	server := newLargeBodyServer(4096)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.SetMaxBodySize(1024)
+
+	req := &types.Request{
+		Method:       http.MethodGet,
+		URL:          server.URL,
+		ResponseBody: &types.ResponseBodySettings{MaxSize: 10},
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Body) != 10 {
+		t.Errorf("expected 10 bytes in memory, got %d", len(resp.Body))
+	}
+
+	resp, err = client.ExecuteWithOptions(req, &ExecuteOptions{MaxBodySize: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Body) != 4096 || resp.Truncated {
+		t.Errorf("expected unlimited body, got %d bytes (truncated %v)", len(resp.Body), resp.Truncated)
+	}
+}
+
+func TestUnitExecuteWithOptions_Sink(t *testing.T) {
+	server := newLargeBodyServer(100 * 1024)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	var sink bytes.Buffer
+
+	resp, err := client.ExecuteWithOptions(&types.Request{Method: http.MethodGet, URL: server.URL}, &ExecuteOptions{Sink: &sink})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sink.Len() != 100*1024 {
+		t.Errorf("expected sink to receive %d bytes, got %d", 100*1024, sink.Len())
+	}
+
+	if len(resp.Body) != 0 {
+		t.Errorf("expected body not to be kept in memory, got %d bytes", len(resp.Body))
+	}
+
+	if resp.Size != 100*1024 {
+		t.Errorf("expected size %d, got %d", 100*1024, resp.Size)
+	}
+}
+
+func TestUnitExecute_OutputFile(t *testing.T) {
+	server := newLargeBodyServer(64 * 1024)
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	filePath := filepath.Join(dir, "download.bin")
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method:       http.MethodGet,
+		URL:          server.URL,
+		ResponseBody: &types.ResponseBodySettings{OutputFile: filePath},
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.BodyFile != filePath {
+		t.Errorf("expected body file %s, got %s", filePath, resp.BodyFile)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("expected output file to exist: %v", err)
+	}
+
+	if info.Size() != 64*1024 || resp.Size != 64*1024 {
+		t.Errorf("expected %d bytes, got file %d, size %d", 64*1024, info.Size(), resp.Size)
+	}
+}
+
+func TestUnitExecuteWithOptions_OutputFileRemovedOnError(t *testing.T) {
+	server := newLargeBodyServer(64 * 1024)
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	filePath := filepath.Join(dir, "download.bin")
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method:       http.MethodGet,
+		URL:          server.URL,
+		ResponseBody: &types.ResponseBodySettings{OutputFile: filePath},
+	}
+
+	read := 0
+	opts := &ExecuteOptions{
+		OnChunk: func(chunk []byte) error {
+			if read += len(chunk); read > bodyChunkSize {
+				return errors.New("stop")
+			}
+			return nil
+		},
+	}
+
+	if _, err := client.ExecuteWithOptions(req, opts); err == nil {
+		t.Fatal("expected callback error")
+	}
+
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Errorf("expected the partial output file to be removed, got %v", err)
+	}
+}
+
+func TestUnitExecute_OutputFile_InvalidPath(t *testing.T) {
+	server := newLargeBodyServer(16)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method:       http.MethodGet,
+		URL:          server.URL,
+		ResponseBody: &types.ResponseBodySettings{OutputFile: "/nonexistent/dir/file.bin"},
+	}
+
+	if _, err := client.Execute(req); err == nil {
+		t.Fatal("expected error for invalid output file path")
+	}
+}
+
+func TestUnitExecuteWithOptions_OnChunk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 0; i < 3; i++ {
+			w.Write([]byte("chunk"))
+			flusher.Flush()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	var (
+		received bytes.Buffer
+		calls    int
+	)
+
+	opts := &ExecuteOptions{
+		OnChunk: func(chunk []byte) error {
+			calls++
+			received.Write(chunk)
+			return nil
+		},
+	}
+
+	resp, err := client.ExecuteWithOptions(&types.Request{Method: http.MethodGet, URL: server.URL}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received.String() != "chunkchunkchunk" {
+		t.Errorf("expected all chunks to be delivered, got %q", received.String())
+	}
+
+	if calls < 2 {
+		t.Errorf("expected several chunk callbacks, got %d", calls)
+	}
+
+	if string(resp.Body) != "chunkchunkchunk" {
+		t.Errorf("expected body to be kept without sink, got %q", string(resp.Body))
+	}
+}
+
+func TestUnitExecuteWithOptions_OnChunkError(t *testing.T) {
+	server := newLargeBodyServer(1024)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	errStop := errors.New("stop")
+	opts := &ExecuteOptions{
+		OnChunk: func(chunk []byte) error {
+			return errStop
+		},
+	}
+
+	_, err := client.ExecuteWithOptions(&types.Request{Method: http.MethodGet, URL: server.URL}, opts)
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+}
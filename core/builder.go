@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/KonnorFrik/getman/types"
@@ -29,6 +30,7 @@ const (
 	bodyTypeXML = "xml"
 	bodyTypeRaw = "raw"
 	bodyTypeBinary = "binary"
+	bodyTypeGraphQL = "graphql"
 
 	authTypeBasic = "basic"
 	authTypeBearer = "bearer"
@@ -125,6 +127,25 @@ func (b *RequestBuilder) BodyBinary(data []byte, contentType string) *RequestBui
 	return b
 }
 
+// GraphQL sets the request body as a GraphQL operation. Variables may reference
+// environment variables in their string values. The method defaults to POST.
+func (b *RequestBuilder) GraphQL(query, operationName string, variables map[string]any) *RequestBuilder {
+	if b.method == "" {
+		b.method = http.MethodPost
+	}
+
+	b.body = &types.RequestBody{
+		Type:        bodyTypeGraphQL,
+		ContentType: "application/json",
+		GraphQL: &types.GraphQLBody{
+			Query:         query,
+			OperationName: operationName,
+			Variables:     variables,
+		},
+	}
+	return b
+}
+
 // AuthBasic sets Basic authentication credentials.
 func (b *RequestBuilder) AuthBasic(username, password string) *RequestBuilder {
 	b.auth = &types.Auth{
@@ -436,3 +436,29 @@ func TestUnitRequestBuilder_AuthAPIKey_QueryLocation(t *testing.T) {
 		t.Errorf("expected location 'query', got %s", req.Auth.Location)
 	}
 }
+
+func TestUnitRequestBuilder_GraphQL(t *testing.T) {
+	builder := NewRequestBuilder()
+	variables := map[string]any{"id": "{{userId}}"}
+
+	req, err := builder.URL("http://example.com/graphql").GraphQL("query User($id: ID!) { user(id: $id) { name } }", "User", variables).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Method != "POST" {
+		t.Errorf("expected method to default to 'POST', got %s", req.Method)
+	}
+
+	if req.Body == nil || req.Body.Type != "graphql" || req.Body.GraphQL == nil {
+		t.Fatalf("expected graphql body, got %+v", req.Body)
+	}
+
+	if req.Body.GraphQL.OperationName != "User" {
+		t.Errorf("expected operation name 'User', got %s", req.Body.GraphQL.OperationName)
+	}
+
+	if req.Body.ContentType != "application/json" {
+		t.Errorf("expected content type 'application/json', got %s", req.Body.ContentType)
+	}
+}
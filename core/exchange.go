@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"log/slog"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Exchange holds everything captured while executing a single request.
+type Exchange struct {
+	Response *types.Response
+	Events   []*types.SSEEvent
+	Messages []*types.WebSocketMessage
+}
+
+// ExecuteExchange executes a request of any supported kind: WebSocket (ws and wss URLs),
+// gRPC (grpc and grpcs URLs), Server-Sent Events (requests with stream settings) or plain HTTP. The options only apply
+// to plain HTTP requests. The returned exchange is never nil and keeps the response, events
+// or messages captured before an error. A GraphQL response with an "errors" array is
+// reported as an error even when its status is successful.
+func (hc *HTTPClient) ExecuteExchange(req *types.Request, opts *ExecuteOptions) (*Exchange, error) {
+	var (
+		exchange = &Exchange{}
+		err      error
+	)
+
+	hc.logger.Debug("sending request", "method", req.Method, "url", req.URL, headerGroup(req.Headers))
+
+	switch {
+	case IsWebSocket(req):
+		exchange.Response, exchange.Messages, err = hc.ExecuteWebSocket(req)
+	case IsGRPC(req):
+		exchange.Response, err = hc.ExecuteGRPC(req)
+	case req.Stream != nil:
+		exchange.Response, exchange.Events, err = hc.ExecuteWithEvents(req)
+	default:
+		exchange.Response, err = hc.ExecuteWithOptions(req, opts)
+		if err == nil && IsGraphQL(req) {
+			err = graphQLError(exchange.Response)
+		}
+	}
+
+	if err != nil {
+		hc.logger.Debug("request failed", "method", req.Method, "url", req.URL, "error", err)
+	} else {
+		hc.logger.Debug("request completed", "method", req.Method, "url", req.URL,
+			"status", exchange.Response.StatusCode, "duration", exchange.Response.Duration, "size", exchange.Response.Size)
+	}
+
+	return exchange, err
+}
+
+// headerGroup returns the request headers as a log attribute, so that handlers can
+// redact them one by one.
+func headerGroup(headers map[string]string) slog.Attr {
+	attrs := make([]any, 0, len(headers))
+	for name, value := range headers {
+		attrs = append(attrs, slog.String(name, value))
+	}
+
+	return slog.Group("headers", attrs...)
+}
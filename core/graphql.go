@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// GraphQLIntrospectionQuery is the standard introspection query returning the schema
+// of a GraphQL server. Use it with RequestBuilder.GraphQL to fetch the schema.
+const GraphQLIntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType { kind name }
+      }
+    }
+  }
+}`
+
+// IsGraphQL reports whether the request body is a GraphQL operation.
+func IsGraphQL(req *types.Request) bool {
+	return req.Body != nil && req.Body.GraphQL != nil && strings.ToLower(req.Body.Type) == bodyTypeGraphQL
+}
+
+// RequestContent returns the bytes sent as the body of the request: the encoded
+// query, variables and operation name of a GraphQL request, the content otherwise.
+func RequestContent(req *types.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	if IsGraphQL(req) {
+		if req.Body.GraphQL.VariablesText != "" {
+			return nil, fmt.Errorf("%w: GraphQL variables are not valid JSON: %s", errors.ErrInvalidRequest, req.Body.GraphQL.VariablesText)
+		}
+
+		content, err := json.Marshal(req.Body.GraphQL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to encode GraphQL body: %v", errors.ErrInvalidRequest, err)
+		}
+
+		return content, nil
+	}
+
+	return req.Body.Content, nil
+}
+
+// GraphQLErrors returns the messages of the "errors" array of a GraphQL response body.
+// It returns nil when the body is not JSON or has no errors.
+func GraphQLErrors(body []byte) []string {
+	var payload struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	var messages []string
+	for _, e := range payload.Errors {
+		message := e.Message
+		if message == "" {
+			message = "unknown error"
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+// graphQLError turns the errors of a GraphQL response into an execution error.
+func graphQLError(resp *types.Response) error {
+	if resp == nil {
+		return nil
+	}
+
+	messages := GraphQLErrors(resp.Body)
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: GraphQL errors: %s", errors.ErrRequestFailed, strings.Join(messages, "; "))
+}
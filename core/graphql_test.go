@@ -0,0 +1,181 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitRequestContent_GraphQL(t *testing.T) {
+	req := &types.Request{
+		Body: &types.RequestBody{
+			Type: "graphql",
+			GraphQL: &types.GraphQLBody{
+				Query:         "query { me { id } }",
+				OperationName: "Me",
+				Variables:     map[string]any{"limit": 5},
+			},
+		},
+	}
+
+	content, err := RequestContent(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(content, &payload); err != nil {
+		t.Fatalf("expected JSON payload, got %s", string(content))
+	}
+
+	if payload["query"] != "query { me { id } }" || payload["operationName"] != "Me" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+
+	if payload["variables"].(map[string]any)["limit"] != float64(5) {
+		t.Errorf("expected variables to be encoded, got %v", payload["variables"])
+	}
+}
+
+func TestUnitGraphQLErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []string
+	}{
+		{"errors", `{"data": null, "errors": [{"message": "not found"}, {"message": ""}]}`, []string{"not found", "unknown error"}},
+		{"no errors", `{"data": {"me": {"id": 1}}}`, nil},
+		{"empty errors", `{"data": {}, "errors": []}`, nil},
+		{"not JSON", `<html></html>`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GraphQLErrors([]byte(tt.body))
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnitResolveGraphQL(t *testing.T) {
+	global := environment.NewEnvironment("global")
+	global.Set("userId", "42")
+	global.Set("tag", "go")
+
+	resolver, err := NewVariableResolver(global, nil)
+	if err != nil {
+		t.Fatalf("failed to create resolver: %v", err)
+	}
+
+	body := &types.GraphQLBody{
+		Query: "query { user(id: $id) { name } }",
+		Variables: map[string]any{
+			"id":     "{{userId}}",
+			"limit":  10,
+			"filter": map[string]any{"tags": []any{"{{tag}}", "static"}},
+		},
+	}
+
+	resolved, err := resolver.ResolveGraphQL(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resolved.Variables["id"] != "42" || resolved.Variables["limit"] != 10 {
+		t.Errorf("unexpected resolved variables: %v", resolved.Variables)
+	}
+
+	tags := resolved.Variables["filter"].(map[string]any)["tags"].([]any)
+	if tags[0] != "go" || tags[1] != "static" {
+		t.Errorf("expected nested variables to be resolved, got %v", tags)
+	}
+
+	if body.Variables["id"] != "{{userId}}" {
+		t.Error("expected original variables to stay unresolved")
+	}
+
+	body.Variables["id"] = "{{missing}}"
+	if _, err := resolver.ResolveGraphQL(body); err == nil {
+		t.Error("expected error for missing variable")
+	}
+}
+
+func TestUnitResolveGraphQL_VariablesText(t *testing.T) {
+	global := environment.NewEnvironment("global")
+	global.Set("userId", "42")
+
+	resolver, err := NewVariableResolver(global, nil)
+	if err != nil {
+		t.Fatalf("failed to create resolver: %v", err)
+	}
+
+	body := &types.GraphQLBody{Query: "query { user(id: $id) { name } }", VariablesText: `{"id": {{userId}}}`}
+
+	resolved, err := resolver.ResolveGraphQL(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resolved.VariablesText != "" || resolved.Variables["id"] != float64(42) {
+		t.Errorf("expected the variables text to be parsed once resolved, got %+v", resolved)
+	}
+
+	req := &types.Request{Body: &types.RequestBody{Type: "graphql", GraphQL: &types.GraphQLBody{VariablesText: `{"id": }`}}}
+	if _, err := RequestContent(req); err == nil {
+		t.Error("expected error for variables that are not valid JSON")
+	}
+}
+
+func TestUnitExecuteExchange_GraphQLErrors(t *testing.T) {
+	var received map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &received)
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": null, "errors": [{"message": "field 'nope' not found"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, 5*time.Second, false)
+	req := &types.Request{
+		Method: http.MethodPost,
+		URL:    server.URL,
+		Body: &types.RequestBody{
+			Type:    "graphql",
+			GraphQL: &types.GraphQLBody{Query: "{ nope }"},
+		},
+	}
+
+	exchange, err := client.ExecuteExchange(req, nil)
+	if err == nil {
+		t.Fatal("expected GraphQL errors to be reported as an error")
+	}
+
+	if !strings.Contains(err.Error(), "field 'nope' not found") {
+		t.Errorf("expected error to contain the GraphQL message, got: %v", err)
+	}
+
+	if exchange.Response == nil || exchange.Response.StatusCode != http.StatusOK {
+		t.Fatalf("expected response with status 200 to be kept, got %+v", exchange.Response)
+	}
+
+	if received["query"] != "{ nope }" {
+		t.Errorf("expected query to be sent, got %v", received)
+	}
+}
@@ -0,0 +1,328 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	grpcSchemePlain = "grpc"
+	grpcSchemeTLS   = "grpcs"
+)
+
+// IsGRPC reports whether the request URL uses the grpc or grpcs scheme.
+func IsGRPC(req *types.Request) bool {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case grpcSchemePlain, grpcSchemeTLS:
+		return true
+	}
+
+	return false
+}
+
+// ExecuteGRPC calls a unary gRPC method. The URL has the form grpc://host:port/package.Service/Method
+// (grpcs for TLS) and the request body holds the request message as JSON. The method is looked up
+// in the descriptor set of the request gRPC settings or, without one, through server reflection.
+// Headers and auth are sent as metadata. The response holds the gRPC status code, header metadata
+// as headers, trailer metadata as trailers and the response message rendered as JSON.
+// A status other than OK is returned as an error together with the response.
+func (hc *HTTPClient) ExecuteGRPC(req *types.Request) (*types.Response, error) {
+	startTime := time.Now()
+
+	target, fullMethod, useTLS, err := parseGRPCURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The HTTP request is only built to apply headers and auth the same way as for HTTP requests.
+	httpReq, err := hc.buildHTTPRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build gRPC request: %w", err)
+	}
+
+	md := metadata.MD{}
+	for k, v := range httpReq.Header {
+		if strings.EqualFold(k, "Content-Type") {
+			continue
+		}
+		md.Append(strings.ToLower(k), v...)
+	}
+
+	ctx := context.Background()
+	if hc.client.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hc.client.Timeout)
+		defer cancel()
+	}
+
+	creds := insecure.NewCredentials()
+	if useTLS {
+		config := tlsConfig(req.TLS)
+		if config == nil {
+			config = &tls.Config{}
+		}
+		creds = credentials.NewTLS(config)
+	}
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrRequestFailed, err)
+	}
+	defer conn.Close()
+
+	var files *protoregistry.Files
+	if req.GRPC != nil && req.GRPC.DescriptorSet != "" {
+		files, err = LoadDescriptorSet(req.GRPC.DescriptorSet)
+	} else {
+		files, err = reflectFiles(ctx, conn, serviceName(fullMethod))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := findMethod(files, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("%w: streaming method %s is not supported", errors.ErrInvalidRequest, fullMethod)
+	}
+
+	input := dynamicpb.NewMessage(method.Input())
+	if req.Body != nil && len(req.Body.Content) > 0 {
+		if err := protojson.Unmarshal(req.Body.Content, input); err != nil {
+			return nil, fmt.Errorf("%w: failed to build %s from JSON: %v", errors.ErrInvalidRequest, method.Input().FullName(), err)
+		}
+	}
+
+	var header, trailer metadata.MD
+	output := dynamicpb.NewMessage(method.Output())
+
+	err = conn.Invoke(metadata.NewOutgoingContext(ctx, md), fullMethod, input, output, grpc.Header(&header), grpc.Trailer(&trailer))
+	st := status.Convert(err)
+	code := int(st.Code())
+
+	response := &types.Response{
+		GRPCCode: &code,
+		Status:   st.Code().String(),
+		Headers:  metadataMap(header),
+		Trailers: metadataMap(trailer),
+		Duration: time.Since(startTime),
+	}
+
+	if st.Code() != codes.OK {
+		if st.Message() != "" {
+			response.Status += ": " + st.Message()
+		}
+
+		return response, fmt.Errorf("%w: gRPC status %s", errors.ErrRequestFailed, response.Status)
+	}
+
+	body, err := protojson.MarshalOptions{
+		Multiline: true,
+		Indent:    "  ",
+		Resolver:  dynamicpb.NewTypes(files),
+	}.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render gRPC response as JSON: %w", err)
+	}
+
+	response.Body = body
+	response.Size = int64(proto.Size(output))
+
+	return response, nil
+}
+
+// LoadDescriptorSet reads a serialized FileDescriptorSet, as produced by
+// protoc --descriptor_set_out --include_imports.
+func LoadDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse descriptor set: %v", errors.ErrInvalidArgument, err)
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid descriptor set: %v", errors.ErrInvalidArgument, err)
+	}
+
+	return files, nil
+}
+
+func parseGRPCURL(rawURL string) (target, fullMethod string, useTLS bool, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", false, fmt.Errorf("%w: %v", errors.ErrInvalidURL, err)
+	}
+
+	if u.Host == "" {
+		return "", "", false, fmt.Errorf("%w: gRPC URL %q has no host", errors.ErrInvalidURL, rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false, fmt.Errorf("%w: gRPC URL %q must have the path /package.Service/Method", errors.ErrInvalidURL, rawURL)
+	}
+
+	return u.Host, "/" + parts[0] + "/" + parts[1], strings.EqualFold(u.Scheme, grpcSchemeTLS), nil
+}
+
+func serviceName(fullMethod string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service
+}
+
+func findMethod(files *protoregistry.Files, fullMethod string) (protoreflect.MethodDescriptor, error) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("%w: service %s not found", errors.ErrInvalidRequest, service)
+	}
+
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a service", errors.ErrInvalidRequest, service)
+	}
+
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, fmt.Errorf("%w: method %s not found in service %s", errors.ErrInvalidRequest, method, service)
+	}
+
+	return methodDesc, nil
+}
+
+// reflectFiles loads the file defining the service and all its dependencies through
+// the gRPC server reflection service (grpc.reflection.v1).
+func reflectFiles(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: server reflection failed: %v", errors.ErrRequestFailed, err)
+	}
+	defer stream.CloseSend()
+
+	loaded := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	fetch := func(req *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return fmt.Errorf("%s", errResp.GetErrorMessage())
+		}
+
+		for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(data, file); err != nil {
+				return err
+			}
+			loaded[file.GetName()] = file
+		}
+
+		return nil
+	}
+
+	err = fetch(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: server reflection failed for %s: %v", errors.ErrRequestFailed, service, err)
+	}
+
+	// Servers usually send dependencies along, fetch whatever is still missing.
+	for missing := missingDependencies(loaded); len(missing) > 0; missing = missingDependencies(loaded) {
+		for _, name := range missing {
+			err := fetch(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: server reflection failed for %s: %v", errors.ErrRequestFailed, name, err)
+			}
+
+			if _, ok := loaded[name]; !ok {
+				return nil, fmt.Errorf("%w: server reflection did not return %s", errors.ErrRequestFailed, name)
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, file := range loaded {
+		set.File = append(set.File, file)
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid descriptors from server reflection: %v", errors.ErrRequestFailed, err)
+	}
+
+	return files, nil
+}
+
+func missingDependencies(loaded map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+	seen := make(map[string]bool)
+
+	for _, file := range loaded {
+		for _, dep := range file.GetDependency() {
+			if _, ok := loaded[dep]; !ok && !seen[dep] {
+				seen[dep] = true
+				missing = append(missing, dep)
+			}
+		}
+	}
+
+	return missing
+}
+
+func metadataMap(md metadata.MD) map[string][]string {
+	result := make(map[string][]string, len(md))
+	for k, v := range md {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/grpc_server"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationExecuteGRPC_Reflection(t *testing.T) {
+	server, err := grpc_server.StartGRPCServer(true)
+	if err != nil {
+		t.Fatalf("failed to start gRPC server: %v", err)
+	}
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		URL:     server.URL(grpc_server.SayHelloMethod),
+		Headers: map[string]string{"X-Request-Id": "42"},
+		Auth:    &types.Auth{Type: "bearer", Token: "grpctoken"},
+		Body:    &types.RequestBody{Type: "json", Content: []byte(`{"name": "getman", "count": 2}`)},
+	}
+
+	resp, err := client.ExecuteGRPC(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.GRPCCode == nil || *resp.GRPCCode != 0 || resp.Status != "OK" {
+		t.Errorf("expected status OK, got %v %s", resp.GRPCCode, resp.Status)
+	}
+
+	if resp.StatusCode != 0 {
+		t.Errorf("expected no HTTP status code, got %d", resp.StatusCode)
+	}
+
+	var reply map[string]any
+	if err := json.Unmarshal(resp.Body, &reply); err != nil {
+		t.Fatalf("expected JSON response, got %s", string(resp.Body))
+	}
+
+	if reply["message"] != "Hello, getman" {
+		t.Errorf("unexpected message: %v", reply["message"])
+	}
+
+	if tags, ok := reply["tags"].([]any); !ok || len(tags) != 2 {
+		t.Errorf("expected 2 tags, got %v", reply["tags"])
+	}
+
+	if reply["authorization"] != "Bearer grpctoken" {
+		t.Errorf("expected auth to be sent as metadata, got %v", reply["authorization"])
+	}
+
+	if got := resp.Headers["x-greeter"]; len(got) != 1 || got[0] != "test" {
+		t.Errorf("expected x-greeter header, got %v", resp.Headers)
+	}
+
+	if got := resp.Trailers["x-trailer"]; len(got) != 1 || got[0] != "done" {
+		t.Errorf("expected x-trailer trailer, got %v", resp.Trailers)
+	}
+}
+
+func TestIntegrationExecuteGRPC_DescriptorSet(t *testing.T) {
+	server, err := grpc_server.StartGRPCServer(false)
+	if err != nil {
+		t.Fatalf("failed to start gRPC server: %v", err)
+	}
+	defer server.Close()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	data, err := server.DescriptorSet()
+	if err != nil {
+		t.Fatalf("failed to build descriptor set: %v", err)
+	}
+
+	setPath := filepath.Join(dir, "greeter.pb")
+	if err := os.WriteFile(setPath, data, 0644); err != nil {
+		t.Fatalf("failed to write descriptor set: %v", err)
+	}
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		URL:  server.URL(grpc_server.SayHelloMethod),
+		Body: &types.RequestBody{Type: "json", Content: []byte(`{"name": "set"}`)},
+	}
+
+	if _, err := client.ExecuteGRPC(req); err == nil {
+		t.Fatal("expected error without reflection and descriptor set")
+	}
+
+	req.GRPC = &types.GRPCSettings{DescriptorSet: setPath}
+
+	resp, err := client.ExecuteGRPC(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(resp.Body), "Hello, set") {
+		t.Errorf("unexpected response body: %s", string(resp.Body))
+	}
+}
+
+func TestIntegrationExecuteGRPC_ErrorStatus(t *testing.T) {
+	server, err := grpc_server.StartGRPCServer(true)
+	if err != nil {
+		t.Fatalf("failed to start gRPC server: %v", err)
+	}
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{URL: server.URL(grpc_server.FailMethod)}
+
+	resp, err := client.ExecuteGRPC(req)
+	if err == nil {
+		t.Fatal("expected error for NOT_FOUND status")
+	}
+
+	if resp == nil || resp.GRPCCode == nil || *resp.GRPCCode != 5 || resp.Status != "NotFound: no such greeting" {
+		t.Fatalf("expected NotFound response, got %+v", resp)
+	}
+
+	if got := resp.Trailers["x-trailer"]; len(got) != 1 || got[0] != "failed" {
+		t.Errorf("expected trailers to be kept on error, got %v", resp.Trailers)
+	}
+}
+
+func TestIntegrationExecuteGRPC_UnknownMethod(t *testing.T) {
+	server, err := grpc_server.StartGRPCServer(true)
+	if err != nil {
+		t.Fatalf("failed to start gRPC server: %v", err)
+	}
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{URL: server.URL("/" + grpc_server.ServiceName + "/Missing")}
+
+	if _, err := client.ExecuteGRPC(req); err == nil || !strings.Contains(err.Error(), "method Missing not found") {
+		t.Errorf("expected unknown method error, got: %v", err)
+	}
+}
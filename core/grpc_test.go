@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestUnitIsGRPC(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"grpc://localhost:50051/pkg.Service/Method", true},
+		{"GRPCS://example.com/pkg.Service/Method", true},
+		{"http://example.com", false},
+		{"ws://example.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsGRPC(&types.Request{URL: tt.url}); got != tt.expected {
+			t.Errorf("IsGRPC(%q) = %v, expected %v", tt.url, got, tt.expected)
+		}
+	}
+}
+
+func TestUnitParseGRPCURL(t *testing.T) {
+	target, method, useTLS, err := parseGRPCURL("grpcs://example.com:443/pkg.v1.Service/Get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if target != "example.com:443" || method != "/pkg.v1.Service/Get" || !useTLS {
+		t.Errorf("unexpected result: %s %s %v", target, method, useTLS)
+	}
+
+	if serviceName(method) != "pkg.v1.Service" {
+		t.Errorf("expected service 'pkg.v1.Service', got %s", serviceName(method))
+	}
+
+	for _, invalid := range []string{
+		"grpc:///pkg.Service/Method",
+		"grpc://localhost:50051/pkg.Service",
+		"grpc://localhost:50051/pkg.Service/Method/extra",
+		"grpc://localhost:50051",
+	} {
+		if _, _, _, err := parseGRPCURL(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestUnitMissingDependencies(t *testing.T) {
+	loaded := map[string]*descriptorpb.FileDescriptorProto{
+		"a.proto": {Name: proto.String("a.proto"), Dependency: []string{"b.proto", "c.proto"}},
+		"b.proto": {Name: proto.String("b.proto"), Dependency: []string{"c.proto"}},
+	}
+
+	missing := missingDependencies(loaded)
+	if len(missing) != 1 || missing[0] != "c.proto" {
+		t.Errorf("expected [c.proto], got %v", missing)
+	}
+}
+
+func TestUnitLoadDescriptorSet_Invalid(t *testing.T) {
+	if _, err := LoadDescriptorSet("/nonexistent/set.pb"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -5,26 +5,44 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/logging"
 	"github.com/KonnorFrik/getman/types"
 )
 
 // HTTPClient provides functionality for executing HTTP requests.
 type HTTPClient struct {
-	client     *http.Client
-	autoManage bool
+	client      *http.Client
+	autoManage  bool
+	proxy       atomic.Pointer[types.ProxySettings]
+	maxBodySize atomic.Int64
+	logger      *slog.Logger
+
+	insecureOnce   sync.Once
+	insecureClient *http.Client
 }
 
 // NewHTTPClient creates a new HTTPClient with the specified timeouts and cookie management settings.
 func NewHTTPClient(connectTimeout, readTimeout time.Duration, autoManageCookies bool) *HTTPClient {
+	hc := &HTTPClient{
+		autoManage: autoManageCookies,
+		logger:     logging.Discard(),
+	}
+
 	transport := &http.Transport{
+		Proxy:                 hc.proxyFunc,
 		ResponseHeaderTimeout: connectTimeout,
 	}
 
@@ -35,17 +53,25 @@ func NewHTTPClient(connectTimeout, readTimeout time.Duration, autoManageCookies
 		}
 	}
 
-	client := &http.Client{
+	hc.client = &http.Client{
 		Transport:     transport,
 		Jar:           jar,
 		Timeout:       readTimeout,
 		CheckRedirect: nil,
 	}
 
-	return &HTTPClient{
-		client:     client,
-		autoManage: autoManageCookies,
+	return hc
+}
+
+// SetLogger sets the logger of the client. Requests are logged at the debug level,
+// with secrets redacted by handlers built with the logging package. A nil logger
+// disables logging.
+func (hc *HTTPClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = logging.Discard()
 	}
+
+	hc.logger = logger
 }
 
 type cookieJarImpl struct {
@@ -62,6 +88,12 @@ func (j *cookieJarImpl) Cookies(u *url.URL) []*http.Cookie {
 
 // Execute performs an HTTP request and returns the response.
 func (hc *HTTPClient) Execute(req *types.Request) (*types.Response, error) {
+	return hc.ExecuteWithOptions(req, nil)
+}
+
+// ExecuteWithOptions performs an HTTP request and returns the response.
+// The options control how the response body is consumed; nil options behave like Execute.
+func (hc *HTTPClient) ExecuteWithOptions(req *types.Request, opts *ExecuteOptions) (*types.Response, error) {
 	startTime := time.Now()
 
 	httpReq, err := hc.buildHTTPRequest(req)
@@ -69,17 +101,32 @@ func (hc *HTTPClient) Execute(req *types.Request) (*types.Response, error) {
 		return nil, fmt.Errorf("failed to build HTTP request: %w", err)
 	}
 
-	httpResp, err := hc.client.Do(httpReq)
+	tracer := newTimingTracer(startTime)
+	httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), tracer.clientTrace()))
+
+	bodyOpts, err := hc.bodyOptions(req, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer bodyOpts.close()
+
+	httpResp, err := hc.clientFor(req).Do(httpReq)
 	if err != nil {
+		bodyOpts.remove()
 		return nil, fmt.Errorf("%w: %v", errors.ErrRequestFailed, err)
 	}
 	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(httpResp.Body)
+	body, err := readBody(httpResp.Body, bodyOpts)
 	if err != nil {
+		bodyOpts.remove()
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if err := bodyOpts.close(); err != nil {
+		return nil, err
+	}
+
 	duration := time.Since(startTime)
 
 	headers := make(map[string][]string)
@@ -91,21 +138,41 @@ func (hc *HTTPClient) Execute(req *types.Request) (*types.Response, error) {
 		StatusCode: httpResp.StatusCode,
 		Status:     httpResp.Status,
 		Headers:    headers,
-		Body:       body,
+		Body:       body.data,
 		Duration:   duration,
-		Size:       int64(len(body)),
+		Size:       body.size,
+		Truncated:  body.truncated,
+		BodyFile:   bodyOpts.file,
+		Timings:    tracer.timings(startTime.Add(duration)),
 	}
 
 	return response, nil
 }
 
+// NewHTTPRequest builds the net/http request that is sent for req, with headers,
+// body and auth applied the same way as in Execute. Variables must already be resolved.
+func NewHTTPRequest(req *types.Request) (*http.Request, error) {
+	var hc HTTPClient
+	return hc.buildHTTPRequest(req)
+}
+
 func (hc *HTTPClient) buildHTTPRequest(req *types.Request) (*http.Request, error) {
+	content, err := RequestContent(req)
+	if err != nil {
+		return nil, err
+	}
+
 	var bodyReader io.Reader
-	if req.Body != nil && len(req.Body.Content) > 0 {
-		bodyReader = bytes.NewReader(req.Body.Content)
+	if len(content) > 0 {
+		bodyReader = bytes.NewReader(content)
+	}
+
+	if err := ValidateProxy(req.Proxy); err != nil {
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequest(req.Method, req.URL, bodyReader)
+	ctx := withProxy(context.Background(), req.Proxy)
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidURL, err)
 	}
@@ -116,6 +183,8 @@ func (hc *HTTPClient) buildHTTPRequest(req *types.Request) (*http.Request, error
 
 	if req.Body != nil && req.Body.ContentType != "" {
 		httpReq.Header.Set("Content-Type", req.Body.ContentType)
+	} else if IsGraphQL(req) {
+		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
 	if req.Auth != nil {
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"bytes"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/KonnorFrik/getman/logging"
 	"github.com/KonnorFrik/getman/testutil/http_server"
 	"github.com/KonnorFrik/getman/types"
 )
@@ -301,3 +304,41 @@ func TestIntegrationExecute_ResponseBody(t *testing.T) {
 		t.Error("expected response to have body")
 	}
 }
+
+func TestIntegrationExecuteExchange_Logging(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "debug", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.SetLogger(logger)
+
+	req := &types.Request{
+		Method:  http.MethodGet,
+		URL:     http_server.GetServerURL() + "/echo?api_key=abc123",
+		Headers: map[string]string{"Authorization": "Bearer abc123", "Accept": "application/json"},
+	}
+
+	if _, err := client.ExecuteExchange(req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "abc123") {
+		t.Errorf("expected secrets to be redacted, got %q", output)
+	}
+	if !strings.Contains(output, "msg=\"sending request\"") || !strings.Contains(output, "headers.Accept=application/json") {
+		t.Errorf("expected the sent request to be logged, got %q", output)
+	}
+	if !strings.Contains(output, "msg=\"request completed\"") || !strings.Contains(output, "status=200") {
+		t.Errorf("expected the response to be logged, got %q", output)
+	}
+}
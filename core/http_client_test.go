@@ -1,8 +1,11 @@
 package core
 
 import (
+	"bytes"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -525,3 +528,31 @@ func TestUnitCookieJar(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestUnitSetLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := client.ExecuteExchange(&types.Request{Method: http.MethodGet, URL: server.URL}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "request completed") {
+		t.Errorf("expected the request to be logged, got %q", buf.String())
+	}
+
+	buf.Reset()
+	client.SetLogger(nil)
+
+	if _, err := client.ExecuteExchange(&types.Request{Method: http.MethodGet, URL: server.URL}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no logs with a nil logger, got %q", buf.String())
+	}
+}
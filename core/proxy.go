@@ -0,0 +1,207 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	proxySchemeHTTP    = "http"
+	proxySchemeHTTPS   = "https"
+	proxySchemeSOCKS5  = "socks5"
+	proxySchemeSOCKS5H = "socks5h"
+)
+
+type proxyContextKey struct{}
+
+// SetProxy sets the default proxy settings used for requests without their own proxy settings.
+// A nil value restores the default behavior of taking the proxy from the environment.
+func (hc *HTTPClient) SetProxy(proxy *types.ProxySettings) error {
+	if err := ValidateProxy(proxy); err != nil {
+		return err
+	}
+
+	hc.proxy.Store(proxy)
+	return nil
+}
+
+// GetProxy returns the default proxy settings.
+func (hc *HTTPClient) GetProxy() *types.ProxySettings {
+	return hc.proxy.Load()
+}
+
+// ValidateProxy checks that the proxy URL is parsable and uses a supported scheme.
+func ValidateProxy(proxy *types.ProxySettings) error {
+	if proxy == nil || proxy.URL == "" {
+		return nil
+	}
+
+	_, err := parseProxyURL(proxy.URL)
+	return err
+}
+
+func withProxy(ctx context.Context, proxy *types.ProxySettings) context.Context {
+	if proxy == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, proxyContextKey{}, proxy)
+}
+
+func (hc *HTTPClient) proxyFunc(req *http.Request) (*url.URL, error) {
+	settings := hc.proxy.Load()
+
+	if reqSettings, ok := req.Context().Value(proxyContextKey{}).(*types.ProxySettings); ok {
+		settings = reqSettings
+	}
+
+	return ResolveProxy(settings, req.URL)
+}
+
+// ResolveProxy returns the proxy URL that should be used to reach the target URL.
+// A nil URL means that the request is sent directly.
+func ResolveProxy(settings *types.ProxySettings, target *url.URL) (*url.URL, error) {
+	if settings == nil {
+		return environmentProxy(target)
+	}
+
+	if settings.Disabled || MatchNoProxy(settings.NoProxy, target) {
+		return nil, nil
+	}
+
+	if settings.URL == "" {
+		if settings.IgnoreEnvironment {
+			return nil, nil
+		}
+
+		return environmentProxy(target)
+	}
+
+	proxyURL, err := parseProxyURL(settings.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Username != "" {
+		proxyURL.User = url.UserPassword(settings.Username, settings.Password)
+	}
+
+	return proxyURL, nil
+}
+
+func environmentProxy(target *url.URL) (*url.URL, error) {
+	return httpproxy.FromEnvironment().ProxyFunc()(target)
+}
+
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || proxyURL.Host == "" {
+		// Allow "host:port" without a scheme, the same way environment variables do.
+		proxyURL, err = url.Parse(proxySchemeHTTP + "://" + rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid proxy URL %q: %v", errors.ErrInvalidArgument, rawURL, err)
+		}
+	}
+
+	switch strings.ToLower(proxyURL.Scheme) {
+	case proxySchemeHTTP, proxySchemeHTTPS, proxySchemeSOCKS5, proxySchemeSOCKS5H:
+	default:
+		return nil, fmt.Errorf("%w: unsupported proxy scheme %q", errors.ErrInvalidArgument, proxyURL.Scheme)
+	}
+
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("%w: proxy URL %q has no host", errors.ErrInvalidArgument, rawURL)
+	}
+
+	return proxyURL, nil
+}
+
+// MatchNoProxy reports whether the target URL matches one of the no_proxy patterns.
+// Supported patterns are "*", host names (matching the host and its subdomains),
+// ".domain" (subdomains only), "host:port", IP addresses and CIDR ranges.
+func MatchNoProxy(patterns []string, target *url.URL) bool {
+	if target == nil {
+		return false
+	}
+
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+
+	if port == "" {
+		switch strings.ToLower(target.Scheme) {
+		case "https", "wss":
+			port = "443"
+		case "http", "ws":
+			port = "80"
+		}
+	}
+
+	ip := net.ParseIP(host)
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if pattern == "" {
+			continue
+		}
+
+		if pattern == "*" {
+			return true
+		}
+
+		if _, network, err := net.ParseCIDR(pattern); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		patternHost, patternPort := pattern, ""
+
+		if h, p, err := net.SplitHostPort(pattern); err == nil {
+			patternHost, patternPort = h, p
+		}
+
+		if patternPort != "" && patternPort != port {
+			continue
+		}
+
+		if patternIP := net.ParseIP(patternHost); patternIP != nil {
+			if ip != nil && patternIP.Equal(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(patternHost, "*.") {
+			patternHost = patternHost[1:]
+		}
+
+		if strings.HasPrefix(patternHost, ".") {
+			if strings.HasSuffix(host, patternHost) {
+				return true
+			}
+
+			continue
+		}
+
+		if host == patternHost || strings.HasSuffix(host, "."+patternHost) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/testutil/proxy_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationProxy_HTTP(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	proxy, err := proxy_server.StartProxyServer("", "")
+	if err != nil {
+		t.Fatalf("failed to start proxy server: %v", err)
+	}
+	defer proxy.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	if err := client.SetProxy(&types.ProxySettings{URL: proxy.URL()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    http_server.GetServerURL() + "/health",
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", resp.StatusCode)
+	}
+
+	if len(resp.Headers["X-Proxied-By"]) == 0 {
+		t.Error("expected response to go through the proxy")
+	}
+
+	targets := proxy.Targets()
+	if len(targets) != 1 || !strings.HasSuffix(targets[0], "/health") {
+		t.Errorf("unexpected proxy targets: %v", targets)
+	}
+}
+
+func TestIntegrationProxy_Auth(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	proxy, err := proxy_server.StartProxyServer("proxyuser", "proxypass")
+	if err != nil {
+		t.Fatalf("failed to start proxy server: %v", err)
+	}
+	defer proxy.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    http_server.GetServerURL() + "/health",
+		Proxy:  &types.ProxySettings{URL: proxy.URL()},
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusProxyAuthRequired {
+		t.Errorf("expected status code 407 without credentials, got %d", resp.StatusCode)
+	}
+
+	req.Proxy.Username = "proxyuser"
+	req.Proxy.Password = "proxypass"
+
+	resp, err = client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200 with credentials, got %d", resp.StatusCode)
+	}
+}
+
+func TestIntegrationProxy_Connect(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+
+	proxy, err := proxy_server.StartProxyServer("proxyuser", "proxypass")
+	if err != nil {
+		t.Fatalf("failed to start proxy server: %v", err)
+	}
+	defer proxy.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    server.URL,
+		Proxy: &types.ProxySettings{
+			URL:      proxy.URL(),
+			Username: "proxyuser",
+			Password: "proxypass",
+		},
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.Body) != "secure" {
+		t.Errorf("expected body 'secure', got %s", string(resp.Body))
+	}
+
+	targets := proxy.Targets()
+	if len(targets) != 1 || targets[0] != strings.TrimPrefix(server.URL, "https://") {
+		t.Errorf("expected CONNECT to %s, got %v", server.URL, targets)
+	}
+}
+
+func TestIntegrationProxy_SOCKS5(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	proxy, err := proxy_server.StartSOCKS5Server("socksuser", "sockspass")
+	if err != nil {
+		t.Fatalf("failed to start SOCKS5 server: %v", err)
+	}
+	defer proxy.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    http_server.GetServerURL() + "/health",
+		Proxy: &types.ProxySettings{
+			URL:      proxy.URL(),
+			Username: "socksuser",
+			Password: "sockspass",
+		},
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.Body) != "OK" {
+		t.Errorf("expected body 'OK', got %s", string(resp.Body))
+	}
+
+	if len(proxy.Targets()) != 1 {
+		t.Errorf("expected one SOCKS5 connection, got %v", proxy.Targets())
+	}
+}
+
+func TestIntegrationProxy_NoProxy(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	proxy, err := proxy_server.StartProxyServer("", "")
+	if err != nil {
+		t.Fatalf("failed to start proxy server: %v", err)
+	}
+	defer proxy.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	err = client.SetProxy(&types.ProxySettings{
+		URL:     proxy.URL(),
+		NoProxy: []string{"localhost"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    http_server.GetServerURL() + "/health",
+	}
+
+	if _, err := client.Execute(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(proxy.Targets()) != 0 {
+		t.Errorf("expected request to bypass the proxy, got %v", proxy.Targets())
+	}
+}
@@ -0,0 +1,218 @@
+package core
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitMatchNoProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		target   string
+		expected bool
+	}{
+		{name: "wildcard", patterns: []string{"*"}, target: "http://example.com", expected: true},
+		{name: "exact host", patterns: []string{"example.com"}, target: "http://example.com/path", expected: true},
+		{name: "subdomain of host", patterns: []string{"example.com"}, target: "http://api.example.com", expected: true},
+		{name: "suffix is not a subdomain", patterns: []string{"example.com"}, target: "http://badexample.com", expected: false},
+		{name: "leading dot excludes apex", patterns: []string{".example.com"}, target: "http://example.com", expected: false},
+		{name: "leading dot matches subdomain", patterns: []string{".example.com"}, target: "http://api.example.com", expected: true},
+		{name: "star dot pattern", patterns: []string{"*.example.com"}, target: "http://api.example.com", expected: true},
+		{name: "host with port", patterns: []string{"example.com:8080"}, target: "http://example.com:8080", expected: true},
+		{name: "host with other port", patterns: []string{"example.com:8080"}, target: "http://example.com:9090", expected: false},
+		{name: "default port", patterns: []string{"example.com:443"}, target: "https://example.com", expected: true},
+		{name: "ip", patterns: []string{"127.0.0.1"}, target: "http://127.0.0.1:3000", expected: true},
+		{name: "cidr", patterns: []string{"10.0.0.0/8"}, target: "http://10.1.2.3", expected: true},
+		{name: "cidr miss", patterns: []string{"10.0.0.0/8"}, target: "http://192.168.1.1", expected: false},
+		{name: "case insensitive", patterns: []string{" Example.COM "}, target: "http://EXAMPLE.com", expected: true},
+		{name: "no patterns", patterns: nil, target: "http://example.com", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.target)
+			if err != nil {
+				t.Fatalf("failed to parse target: %v", err)
+			}
+
+			if got := MatchNoProxy(tt.patterns, target); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnitResolveProxy_Explicit(t *testing.T) {
+	target, _ := url.Parse("http://example.com")
+	settings := &types.ProxySettings{
+		URL:      "socks5://proxy.local:1080",
+		Username: "user",
+		Password: "pass",
+	}
+
+	proxyURL, err := ResolveProxy(settings, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL == nil {
+		t.Fatal("expected proxy URL to be set")
+	}
+
+	if proxyURL.Scheme != "socks5" || proxyURL.Host != "proxy.local:1080" {
+		t.Errorf("unexpected proxy URL: %s", proxyURL)
+	}
+
+	if proxyURL.User.Username() != "user" {
+		t.Errorf("expected username 'user', got %s", proxyURL.User.Username())
+	}
+}
+
+func TestUnitResolveProxy_WithoutScheme(t *testing.T) {
+	target, _ := url.Parse("http://example.com")
+
+	proxyURL, err := ResolveProxy(&types.ProxySettings{URL: "proxy.local:3128"}, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL == nil || proxyURL.String() != "http://proxy.local:3128" {
+		t.Errorf("unexpected proxy URL: %v", proxyURL)
+	}
+}
+
+func TestUnitResolveProxy_NoProxy(t *testing.T) {
+	target, _ := url.Parse("http://internal.corp")
+	settings := &types.ProxySettings{
+		URL:     "http://proxy.local:3128",
+		NoProxy: []string{"corp"},
+	}
+
+	proxyURL, err := ResolveProxy(settings, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL != nil {
+		t.Errorf("expected direct connection, got %s", proxyURL)
+	}
+}
+
+func TestUnitResolveProxy_Disabled(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://env-proxy.local:3128")
+	target, _ := url.Parse("http://example.com")
+
+	proxyURL, err := ResolveProxy(&types.ProxySettings{URL: "http://proxy.local:3128", Disabled: true}, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL != nil {
+		t.Errorf("expected direct connection, got %s", proxyURL)
+	}
+}
+
+func TestUnitResolveProxy_Environment(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://env-proxy.local:3128")
+	t.Setenv("NO_PROXY", "skip.example.com")
+	target, _ := url.Parse("http://example.com")
+
+	proxyURL, err := ResolveProxy(nil, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL == nil || proxyURL.Host != "env-proxy.local:3128" {
+		t.Errorf("expected proxy from environment, got %v", proxyURL)
+	}
+
+	skipped, _ := url.Parse("http://skip.example.com")
+	proxyURL, err = ResolveProxy(&types.ProxySettings{}, skipped)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL != nil {
+		t.Errorf("expected NO_PROXY to be honored, got %s", proxyURL)
+	}
+}
+
+func TestUnitResolveProxy_IgnoreEnvironment(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://env-proxy.local:3128")
+	target, _ := url.Parse("http://example.com")
+
+	proxyURL, err := ResolveProxy(&types.ProxySettings{IgnoreEnvironment: true}, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxyURL != nil {
+		t.Errorf("expected direct connection, got %s", proxyURL)
+	}
+}
+
+func TestUnitValidateProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   *types.ProxySettings
+		wantErr bool
+	}{
+		{name: "nil", proxy: nil, wantErr: false},
+		{name: "empty url", proxy: &types.ProxySettings{}, wantErr: false},
+		{name: "http", proxy: &types.ProxySettings{URL: "http://proxy:3128"}, wantErr: false},
+		{name: "https", proxy: &types.ProxySettings{URL: "https://proxy:3128"}, wantErr: false},
+		{name: "socks5h", proxy: &types.ProxySettings{URL: "socks5h://proxy:1080"}, wantErr: false},
+		{name: "unsupported scheme", proxy: &types.ProxySettings{URL: "ftp://proxy:21"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProxy(tt.proxy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUnitSetProxy_Invalid(t *testing.T) {
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+
+	if err := client.SetProxy(&types.ProxySettings{URL: "ftp://proxy:21"}); err == nil {
+		t.Fatal("expected error for unsupported proxy scheme")
+	}
+
+	if client.GetProxy() != nil {
+		t.Error("expected proxy to stay unset")
+	}
+}
+
+func TestUnitSetProxy_Concurrent(t *testing.T) {
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	target, _ := url.Parse("http://example.com")
+	settings := &types.ProxySettings{URL: "http://proxy:8080"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.SetProxy(settings)
+		}()
+		go func() {
+			defer wg.Done()
+			client.proxyFunc(&http.Request{URL: target})
+		}()
+	}
+	wg.Wait()
+
+	if client.GetProxy() != settings {
+		t.Error("expected the proxy settings to be set")
+	}
+}
@@ -4,12 +4,14 @@ Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor
 package core
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/KonnorFrik/getman/environment"
 	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
 )
 
 // VariableResolver resolves variables from global and local environments.
@@ -93,6 +95,108 @@ func (vr *VariableResolver) ResolveMap(m map[string]string) (map[string]string,
 	return result, nil
 }
 
+// ResolveWebSocket resolves variables in the messages and expected replies of a WebSocket script.
+func (vr *VariableResolver) ResolveWebSocket(settings *types.WebSocketSettings) (*types.WebSocketSettings, error) {
+	if settings == nil {
+		return nil, nil
+	}
+
+	resolved := &types.WebSocketSettings{
+		Timeout: settings.Timeout,
+		Steps:   make([]types.WebSocketStep, len(settings.Steps)),
+	}
+
+	for i, step := range settings.Steps {
+		send, err := vr.Resolve(step.Send)
+		if err != nil {
+			return nil, err
+		}
+
+		expect, err := vr.Resolve(step.Expect)
+		if err != nil {
+			return nil, err
+		}
+
+		step.Send = send
+		step.Expect = expect
+		resolved.Steps[i] = step
+	}
+
+	return resolved, nil
+}
+
+// ResolveGraphQL resolves variables in the string values of GraphQL operation variables,
+// including values nested in objects and lists. The query itself is left unchanged.
+// VariablesText is resolved as text and parsed into Variables when it is valid JSON.
+func (vr *VariableResolver) ResolveGraphQL(body *types.GraphQLBody) (*types.GraphQLBody, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	resolved := &types.GraphQLBody{
+		Query:         body.Query,
+		OperationName: body.OperationName,
+	}
+
+	if body.Variables != nil {
+		variables, err := vr.resolveValue(body.Variables)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved.Variables = variables.(map[string]any)
+	}
+
+	if body.VariablesText != "" {
+		text, err := vr.Resolve(body.VariablesText)
+		if err != nil {
+			return nil, err
+		}
+
+		var variables map[string]any
+		if err := json.Unmarshal([]byte(text), &variables); err != nil {
+			resolved.VariablesText = text
+		} else {
+			resolved.Variables = variables
+		}
+	}
+
+	return resolved, nil
+}
+
+func (vr *VariableResolver) resolveValue(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return vr.Resolve(v)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved, err := vr.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = resolved
+		}
+
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := vr.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = resolved
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
 // SetLocal sets the local environment for variable resolution.
 func (vr *VariableResolver) SetLocal(local *environment.Environment) {
 	vr.local = local
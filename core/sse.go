@@ -0,0 +1,385 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+const (
+	sseContentType      = "text/event-stream"
+	sseDefaultEventType = "message"
+	sseEventsBuffer     = 16
+)
+
+// StreamOptions controls when an event stream is stopped.
+// Without MaxDuration the client read timeout is used as MaxDuration, so that a stream
+// waiting for MaxEvents or Until still ends when the server stops sending.
+type StreamOptions struct {
+	// MaxEvents stops the stream after this many events.
+	MaxEvents int
+	// MaxDuration stops the stream after this much time since the request started.
+	MaxDuration time.Duration
+	// Until stops the stream after the first event for which it returns true.
+	// The matching event is still delivered.
+	Until func(event *types.SSEEvent) bool
+}
+
+// EventStream delivers Server-Sent Events of a running request.
+// The Events channel is closed when the stream ends or a stop condition is reached.
+type EventStream struct {
+	// Response holds the status and headers. Body, size and timings are filled in once the stream ends.
+	Response *types.Response
+	// Events delivers parsed events in the order they are received.
+	Events <-chan *types.SSEEvent
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+	err    error
+}
+
+// Close stops the stream and waits until the connection is released.
+func (s *EventStream) Close() {
+	s.once.Do(s.cancel)
+	<-s.done
+}
+
+// Wait blocks until the stream ends and returns the final response.
+// Stopping because of a stop condition or Close is not an error.
+func (s *EventStream) Wait() (*types.Response, error) {
+	<-s.done
+	return s.Response, s.err
+}
+
+// StreamOptionsFromSettings converts request stream settings into stream options.
+func StreamOptionsFromSettings(settings *types.StreamSettings) *StreamOptions {
+	if settings == nil {
+		return &StreamOptions{}
+	}
+
+	opts := &StreamOptions{
+		MaxEvents:   settings.MaxEvents,
+		MaxDuration: settings.MaxDuration,
+	}
+
+	if settings.UntilEvent != "" || settings.UntilData != "" {
+		untilEvent, untilData := settings.UntilEvent, settings.UntilData
+
+		opts.Until = func(event *types.SSEEvent) bool {
+			if untilEvent != "" && event.Event == untilEvent {
+				return true
+			}
+
+			return untilData != "" && strings.Contains(event.Data, untilData)
+		}
+	}
+
+	return opts
+}
+
+// ExecuteStream performs a request whose response is a Server-Sent Events stream
+// and delivers parsed events on a channel. Responses that are not event streams
+// (or are not successful) end the stream immediately with their body kept in the response.
+func (hc *HTTPClient) ExecuteStream(req *types.Request, opts *StreamOptions) (*EventStream, error) {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+
+	startTime := time.Now()
+
+	httpReq, err := hc.buildHTTPRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HTTP request: %w", err)
+	}
+
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", sseContentType)
+	}
+
+	if httpReq.Header.Get("Cache-Control") == "" {
+		httpReq.Header.Set("Cache-Control", "no-cache")
+	}
+
+	maxDuration := opts.MaxDuration
+	if maxDuration <= 0 {
+		maxDuration = hc.client.Timeout
+	}
+
+	bodyOpts, err := hc.bodyOptions(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	if maxDuration > 0 {
+		ctx, cancel = context.WithTimeout(httpReq.Context(), maxDuration)
+	} else {
+		ctx, cancel = context.WithCancel(httpReq.Context())
+	}
+
+	tracer := newTimingTracer(startTime)
+	ctx = httptrace.WithClientTrace(ctx, tracer.clientTrace())
+	httpReq = httpReq.WithContext(ctx)
+
+	// The overall client timeout would cut long-lived streams, the stream relies on maxDuration instead.
+	streamClient := *hc.clientFor(req)
+	streamClient.Timeout = 0
+
+	httpResp, err := streamClient.Do(httpReq)
+	if err != nil {
+		cancel()
+		bodyOpts.close()
+		return nil, fmt.Errorf("%w: %v", errors.ErrRequestFailed, err)
+	}
+
+	headers := make(map[string][]string)
+	for k, v := range httpResp.Header {
+		headers[k] = v
+	}
+
+	events := make(chan *types.SSEEvent, sseEventsBuffer)
+	stream := &EventStream{
+		Response: &types.Response{
+			StatusCode: httpResp.StatusCode,
+			Status:     httpResp.Status,
+			Headers:    headers,
+		},
+		Events: events,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(stream.done)
+		defer close(events)
+		defer cancel()
+		defer httpResp.Body.Close()
+		defer bodyOpts.close()
+
+		var (
+			body *bodyResult
+			err  error
+		)
+
+		if isEventStream(httpResp) {
+			body, err = consumeEvents(ctx, httpResp.Body, bodyOpts, opts, events, cancel)
+		} else {
+			body, err = readBody(httpResp.Body, bodyOpts)
+		}
+
+		if err == nil {
+			err = bodyOpts.close()
+		}
+
+		// Errors caused by our own cancellation (stop condition, deadline, Close) are expected.
+		if err != nil && ctx.Err() == nil {
+			stream.err = fmt.Errorf("failed to read event stream: %w", err)
+		}
+
+		duration := time.Since(startTime)
+		stream.Response.Duration = duration
+		stream.Response.Timings = tracer.timings(startTime.Add(duration))
+		stream.Response.BodyFile = bodyOpts.file
+
+		if body != nil {
+			stream.Response.Body = body.data
+			stream.Response.Size = body.size
+			stream.Response.Truncated = body.truncated
+		}
+	}()
+
+	return stream, nil
+}
+
+// ExecuteWithEvents performs a request and, if it has stream settings, consumes its
+// Server-Sent Events until a stop condition is reached. Other requests behave like Execute.
+func (hc *HTTPClient) ExecuteWithEvents(req *types.Request) (*types.Response, []*types.SSEEvent, error) {
+	if req.Stream == nil {
+		resp, err := hc.Execute(req)
+		return resp, nil, err
+	}
+
+	stream, err := hc.ExecuteStream(req, StreamOptionsFromSettings(req.Stream))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var events []*types.SSEEvent
+	for event := range stream.Events {
+		events = append(events, event)
+	}
+
+	resp, err := stream.Wait()
+	if err != nil {
+		return nil, events, err
+	}
+
+	return resp, events, nil
+}
+
+func isEventStream(resp *http.Response) bool {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false
+	}
+
+	return strings.HasPrefix(strings.ToLower(resp.Header.Get("Content-Type")), sseContentType)
+}
+
+// captureReader counts the bytes read and keeps a limited copy of them.
+type captureReader struct {
+	r      io.Reader
+	result *bodyResult
+	opts   *bodyOptions
+}
+
+func (cr *captureReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+
+	if n > 0 {
+		if err := cr.result.consume(p[:n], cr.opts); err != nil {
+			return n, err
+		}
+	}
+
+	return n, err
+}
+
+func consumeEvents(
+	ctx context.Context,
+	body io.Reader,
+	bodyOpts *bodyOptions,
+	opts *StreamOptions,
+	events chan<- *types.SSEEvent,
+	stop context.CancelFunc,
+) (*bodyResult, error) {
+	result := &bodyResult{}
+	reader := bufio.NewReader(&captureReader{r: body, result: result, opts: bodyOpts})
+	parser := &sseParser{}
+	count := 0
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if len(line) > 0 || err == nil {
+			if event := parser.feedLine(strings.TrimRight(line, "\r\n")); event != nil {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return result, nil
+				}
+
+				count++
+
+				if (opts.MaxEvents > 0 && count >= opts.MaxEvents) || (opts.Until != nil && opts.Until(event)) {
+					stop()
+					return result, nil
+				}
+			}
+		}
+
+		if err == io.EOF {
+			// A stream may end without a trailing blank line; dispatch what is pending.
+			if event := parser.feedLine(""); event != nil {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+				}
+			}
+
+			return result, nil
+		}
+
+		if err != nil {
+			return result, err
+		}
+	}
+}
+
+// sseParser implements the event stream interpretation rules of the HTML specification.
+type sseParser struct {
+	data      strings.Builder
+	hasData   bool
+	eventType string
+	lastID    string
+	retry     time.Duration
+}
+
+// feedLine processes a single line without its line terminator and returns
+// an event when the line completes one.
+func (p *sseParser) feedLine(line string) *types.SSEEvent {
+	if line == "" {
+		return p.dispatch()
+	}
+
+	if strings.HasPrefix(line, ":") {
+		return nil
+	}
+
+	field, value, found := strings.Cut(line, ":")
+	if found {
+		value = strings.TrimPrefix(value, " ")
+	}
+
+	switch field {
+	case "event":
+		p.eventType = value
+	case "data":
+		if p.hasData {
+			p.data.WriteByte('\n')
+		}
+		p.data.WriteString(value)
+		p.hasData = true
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			p.lastID = value
+		}
+	case "retry":
+		if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+			p.retry = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	return nil
+}
+
+func (p *sseParser) dispatch() *types.SSEEvent {
+	defer func() {
+		p.data.Reset()
+		p.hasData = false
+		p.eventType = ""
+	}()
+
+	if !p.hasData {
+		return nil
+	}
+
+	eventType := p.eventType
+	if eventType == "" {
+		eventType = sseDefaultEventType
+	}
+
+	return &types.SSEEvent{
+		ID:    p.lastID,
+		Event: eventType,
+		Data:  p.data.String(),
+		Retry: p.retry,
+	}
+}
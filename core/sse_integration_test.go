@@ -0,0 +1,71 @@
+package core
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationExecuteWithEvents_UntilEvent(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    http_server.GetServerURL() + "/sse?count=4&hold=1",
+		Stream: &types.StreamSettings{UntilEvent: "done"},
+	}
+
+	resp, events, err := client.ExecuteWithEvents(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", resp.StatusCode)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+
+	last := events[3]
+	if last.Event != "done" || last.ID != "4" || last.Data != `{"n": 4}` {
+		t.Errorf("unexpected last event: %+v", last)
+	}
+
+	if last.Retry != time.Second {
+		t.Errorf("expected retry 1s, got %v", last.Retry)
+	}
+}
+
+func TestIntegrationExecuteWithEvents_StreamEnd(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    http_server.GetServerURL() + "/sse?count=2",
+		Stream: &types.StreamSettings{MaxEvents: 10},
+	}
+
+	_, events, err := client.ExecuteWithEvents(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Errorf("expected 2 events, got %d", len(events))
+	}
+}
@@ -0,0 +1,296 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitSSEParser_FeedLine(t *testing.T) {
+	parser := &sseParser{}
+	lines := []string{
+		": comment",
+		"retry: 2500",
+		"id: 1",
+		"event: update",
+		"data: first line",
+		"data:second line",
+		"",
+		"data: plain",
+		"",
+		"event: empty",
+		"",
+	}
+
+	var events []*types.SSEEvent
+	for _, line := range lines {
+		if event := parser.feedLine(line); event != nil {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	first := events[0]
+	if first.ID != "1" || first.Event != "update" || first.Data != "first line\nsecond line" {
+		t.Errorf("unexpected first event: %+v", first)
+	}
+
+	if first.Retry != 2500*time.Millisecond {
+		t.Errorf("expected retry 2.5s, got %v", first.Retry)
+	}
+
+	second := events[1]
+	if second.Event != "message" {
+		t.Errorf("expected default event type 'message', got %s", second.Event)
+	}
+
+	if second.ID != "1" {
+		t.Errorf("expected last event ID to persist, got %q", second.ID)
+	}
+}
+
+func newSSEServer(count int, hold bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+
+		for i := 1; i <= count; i++ {
+			fmt.Fprintf(w, "id: %d\r\nevent: tick\r\ndata: %d\r\n\r\n", i, i)
+			flusher.Flush()
+		}
+
+		if hold {
+			<-r.Context().Done()
+		}
+	}))
+}
+
+func TestUnitExecuteStream_MaxEvents(t *testing.T) {
+	server := newSSEServer(5, true)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	stream, err := client.ExecuteStream(&types.Request{Method: http.MethodGet, URL: server.URL}, &StreamOptions{MaxEvents: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var events []*types.SSEEvent
+	for event := range stream.Events {
+		events = append(events, event)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	if events[1].Data != "2" {
+		t.Errorf("expected second event data '2', got %q", events[1].Data)
+	}
+
+	resp, err := stream.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", resp.StatusCode)
+	}
+
+	if resp.Size == 0 || !strings.Contains(string(resp.Body), "data: 1") {
+		t.Errorf("expected raw stream to be captured, got %q", string(resp.Body))
+	}
+}
+
+func TestUnitExecuteStream_Until(t *testing.T) {
+	server := newSSEServer(5, true)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	opts := &StreamOptions{
+		Until: func(event *types.SSEEvent) bool {
+			return event.ID == "3"
+		},
+	}
+
+	stream, err := client.ExecuteStream(&types.Request{Method: http.MethodGet, URL: server.URL}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	count := 0
+	for range stream.Events {
+		count++
+	}
+
+	if count != 3 {
+		t.Errorf("expected 3 events including the matching one, got %d", count)
+	}
+}
+
+func TestUnitExecuteStream_MaxDuration(t *testing.T) {
+	server := newSSEServer(1, true)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	start := time.Now()
+
+	stream, err := client.ExecuteStream(&types.Request{Method: http.MethodGet, URL: server.URL}, &StreamOptions{MaxDuration: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	count := 0
+	for range stream.Events {
+		count++
+	}
+
+	if _, err := stream.Wait(); err != nil {
+		t.Fatalf("expected deadline not to be reported as error, got %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 event, got %d", count)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected stream to stop after max duration, took %v", elapsed)
+	}
+}
+
+func TestUnitExecuteStream_StallingServer(t *testing.T) {
+	server := newSSEServer(1, true)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 200*time.Millisecond, false)
+	start := time.Now()
+
+	stream, err := client.ExecuteStream(&types.Request{Method: http.MethodGet, URL: server.URL}, &StreamOptions{MaxEvents: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	count := 0
+	for range stream.Events {
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 event, got %d", count)
+	}
+
+	req := &types.Request{Method: http.MethodGet, URL: server.URL, Stream: &types.StreamSettings{UntilEvent: "done"}}
+	if _, events, err := client.ExecuteWithEvents(req); err != nil || len(events) != 1 {
+		t.Errorf("expected 1 event without error, got %d, %v", len(events), err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the client timeout to end the streams, took %v", elapsed)
+	}
+}
+
+func TestUnitExecuteStream_Close(t *testing.T) {
+	server := newSSEServer(1, true)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	stream, err := client.ExecuteStream(&types.Request{Method: http.MethodGet, URL: server.URL}, &StreamOptions{MaxEvents: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	<-stream.Events
+	stream.Close()
+
+	if _, ok := <-stream.Events; ok {
+		t.Error("expected events channel to be closed")
+	}
+}
+
+func TestUnitExecuteStream_NotEventStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	stream, err := client.ExecuteStream(&types.Request{Method: http.MethodGet, URL: server.URL}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := <-stream.Events; ok {
+		t.Error("expected no events")
+	}
+
+	resp, err := stream.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusNotFound || string(resp.Body) != "not found" {
+		t.Errorf("unexpected response: %d %q", resp.StatusCode, string(resp.Body))
+	}
+}
+
+func TestUnitExecuteWithEvents(t *testing.T) {
+	server := newSSEServer(3, false)
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    server.URL,
+		Stream: &types.StreamSettings{UntilData: "2"},
+	}
+
+	resp, events, err := client.ExecuteWithEvents(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp == nil {
+		t.Fatal("expected response to be set")
+	}
+
+	if len(events) != 2 {
+		t.Errorf("expected 2 events, got %d", len(events))
+	}
+}
+
+func TestUnitStreamOptionsFromSettings(t *testing.T) {
+	opts := StreamOptionsFromSettings(&types.StreamSettings{
+		MaxEvents:  5,
+		UntilEvent: "done",
+	})
+
+	if opts.MaxEvents != 5 {
+		t.Errorf("expected max events 5, got %d", opts.MaxEvents)
+	}
+
+	if opts.Until == nil {
+		t.Fatal("expected predicate to be set")
+	}
+
+	if !opts.Until(&types.SSEEvent{Event: "done"}) {
+		t.Error("expected predicate to match event type")
+	}
+
+	if opts.Until(&types.SSEEvent{Event: "tick"}) {
+		t.Error("expected predicate not to match other event types")
+	}
+
+	if StreamOptionsFromSettings(&types.StreamSettings{}).Until != nil {
+		t.Error("expected no predicate without until settings")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// timingTracer collects phase timestamps reported by net/http/httptrace.
+type timingTracer struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+	reused       bool
+}
+
+func newTimingTracer(start time.Time) *timingTracer {
+	return &timingTracer{
+		start: start,
+	}
+}
+
+func (t *timingTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mark(&t.dnsStart, false)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mark(&t.dnsDone, true)
+		},
+		ConnectStart: func(string, string) {
+			t.mark(&t.connectStart, false)
+		},
+		ConnectDone: func(string, string, error) {
+			t.mark(&t.connectDone, true)
+		},
+		TLSHandshakeStart: func() {
+			t.mark(&t.tlsStart, false)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mark(&t.tlsDone, true)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.reused = info.Reused
+		},
+		GotFirstResponseByte: func() {
+			t.mark(&t.firstByte, false)
+		},
+	}
+}
+
+// mark records the current time. Start marks keep the first occurrence and done marks
+// keep the last one, so that retried dials (e.g. several resolved addresses) are covered.
+func (t *timingTracer) mark(field *time.Time, overwrite bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if overwrite || field.IsZero() {
+		*field = time.Now()
+	}
+}
+
+func (t *timingTracer) timings(end time.Time) *types.Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := &types.Timings{
+		DNSLookup:        phase(t.dnsStart, t.dnsDone),
+		TCPConnect:       phase(t.connectStart, t.connectDone),
+		TLSHandshake:     phase(t.tlsStart, t.tlsDone),
+		TimeToFirstByte:  phase(t.start, t.firstByte),
+		ContentTransfer:  phase(t.firstByte, end),
+		Total:            end.Sub(t.start),
+		ConnectionReused: t.reused,
+	}
+
+	return timings
+}
+
+func phase(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+
+	return end.Sub(start)
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitTimingTracer_Timings(t *testing.T) {
+	start := time.Now()
+	tracer := newTimingTracer(start)
+	tracer.dnsStart = start
+	tracer.dnsDone = start.Add(10 * time.Millisecond)
+	tracer.connectStart = start.Add(10 * time.Millisecond)
+	tracer.connectDone = start.Add(30 * time.Millisecond)
+	tracer.firstByte = start.Add(100 * time.Millisecond)
+
+	timings := tracer.timings(start.Add(150 * time.Millisecond))
+
+	if timings.DNSLookup != 10*time.Millisecond {
+		t.Errorf("expected DNS lookup 10ms, got %v", timings.DNSLookup)
+	}
+
+	if timings.TCPConnect != 20*time.Millisecond {
+		t.Errorf("expected TCP connect 20ms, got %v", timings.TCPConnect)
+	}
+
+	if timings.TLSHandshake != 0 {
+		t.Errorf("expected no TLS handshake, got %v", timings.TLSHandshake)
+	}
+
+	if timings.TimeToFirstByte != 100*time.Millisecond {
+		t.Errorf("expected TTFB 100ms, got %v", timings.TimeToFirstByte)
+	}
+
+	if timings.ContentTransfer != 50*time.Millisecond {
+		t.Errorf("expected content transfer 50ms, got %v", timings.ContentTransfer)
+	}
+
+	if timings.Total != 150*time.Millisecond {
+		t.Errorf("expected total 150ms, got %v", timings.Total)
+	}
+}
+
+func TestUnitExecute_Timings(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	client.client.Transport.(*http.Transport).TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+	req := &types.Request{
+		Method: http.MethodGet,
+		URL:    server.URL,
+	}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Timings == nil {
+		t.Fatal("expected timings to be set")
+	}
+
+	if resp.Timings.TCPConnect <= 0 {
+		t.Error("expected TCP connect time to be measured")
+	}
+
+	if resp.Timings.TLSHandshake <= 0 {
+		t.Error("expected TLS handshake time to be measured")
+	}
+
+	if resp.Timings.TimeToFirstByte < 10*time.Millisecond {
+		t.Errorf("expected TTFB to include server delay, got %v", resp.Timings.TimeToFirstByte)
+	}
+
+	if resp.Timings.ConnectionReused {
+		t.Error("expected first connection not to be reused")
+	}
+
+	resp, err = client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.Timings.ConnectionReused {
+		t.Error("expected second request to reuse the connection")
+	}
+
+	if resp.Timings.TLSHandshake != 0 {
+		t.Errorf("expected no TLS handshake on reused connection, got %v", resp.Timings.TLSHandshake)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// tlsConfig returns the TLS configuration of a request, or nil for the default one.
+func tlsConfig(settings *types.TLSSettings) *tls.Config {
+	if settings == nil || !settings.InsecureSkipVerify {
+		return nil
+	}
+
+	// Skipping the verification is asked for explicitly, e.g. by curl --insecure.
+	return &tls.Config{InsecureSkipVerify: true}
+}
+
+// clientFor returns the client that sends req. Requests with InsecureSkipVerify
+// share a second client that accepts any server certificate; it uses the same
+// proxy settings, cookie jar and timeouts as the default one.
+func (hc *HTTPClient) clientFor(req *types.Request) *http.Client {
+	config := tlsConfig(req.TLS)
+	if config == nil {
+		return hc.client
+	}
+
+	hc.insecureOnce.Do(func() {
+		client := *hc.client
+
+		if transport, ok := hc.client.Transport.(*http.Transport); ok {
+			insecureTransport := transport.Clone()
+			insecureTransport.TLSClientConfig = config
+			client.Transport = insecureTransport
+		}
+
+		hc.insecureClient = &client
+	})
+
+	return hc.insecureClient
+}
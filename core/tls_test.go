@@ -0,0 +1,38 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitExecute_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{Method: http.MethodGet, URL: server.URL}
+
+	if _, err := client.Execute(req); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected")
+	}
+
+	req.TLS = &types.TLSSettings{InsecureSkipVerify: true}
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if client.clientFor(&types.Request{}) != client.client {
+		t.Error("expected requests without TLS settings to use the default client")
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package core
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsSchemeWS  = "ws"
+	wsSchemeWSS = "wss"
+
+	wsDefaultTimeout = 10 * time.Second
+	wsCloseTimeout   = time.Second
+	wsMaxErrorBody   = 1024
+)
+
+// IsWebSocket reports whether the request URL uses the ws or wss scheme.
+func IsWebSocket(req *types.Request) bool {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case wsSchemeWS, wsSchemeWSS:
+		return true
+	}
+
+	return false
+}
+
+// ExecuteWebSocket opens a WebSocket connection and runs the request script.
+// Headers, auth, cookies and proxy settings are applied to the opening handshake.
+// The transcript holds every message sent and received, also when a step fails.
+func (hc *HTTPClient) ExecuteWebSocket(req *types.Request) (*types.Response, []*types.WebSocketMessage, error) {
+	startTime := time.Now()
+
+	handshake := *req
+	handshake.Method = http.MethodGet
+	handshake.Body = nil
+
+	httpReq, err := hc.buildHTTPRequest(&handshake)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build WebSocket handshake: %w", err)
+	}
+
+	proxy := hc.proxy.Load()
+	if req.Proxy != nil {
+		proxy = req.Proxy
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy: func(r *http.Request) (*url.URL, error) {
+			return ResolveProxy(proxy, r.URL)
+		},
+		HandshakeTimeout: hc.client.Timeout,
+		Jar:              hc.client.Jar,
+		TLSClientConfig:  tlsConfig(req.TLS),
+	}
+
+	conn, httpResp, err := dialer.Dial(httpReq.URL.String(), httpReq.Header)
+	if err != nil {
+		if httpResp != nil {
+			response := webSocketResponse(httpResp, startTime)
+			response.Body, _ = io.ReadAll(io.LimitReader(httpResp.Body, wsMaxErrorBody))
+			response.Size = int64(len(response.Body))
+			return response, nil, fmt.Errorf("%w: WebSocket handshake failed with status %s", errors.ErrRequestFailed, httpResp.Status)
+		}
+
+		return nil, nil, fmt.Errorf("%w: %v", errors.ErrRequestFailed, err)
+	}
+	defer conn.Close()
+
+	session := &webSocketSession{
+		conn:    conn,
+		timeout: hc.webSocketTimeout(req.WebSocket),
+	}
+
+	if req.WebSocket != nil {
+		err = session.run(req.WebSocket.Steps)
+	}
+
+	session.close()
+
+	response := webSocketResponse(httpResp, startTime)
+	response.Size = session.received
+
+	return response, session.messages, err
+}
+
+// webSocketTimeout returns the default time to wait for an expected reply.
+func (hc *HTTPClient) webSocketTimeout(settings *types.WebSocketSettings) time.Duration {
+	if settings != nil && settings.Timeout > 0 {
+		return settings.Timeout
+	}
+
+	if hc.client.Timeout > 0 {
+		return hc.client.Timeout
+	}
+
+	return wsDefaultTimeout
+}
+
+func webSocketResponse(httpResp *http.Response, startTime time.Time) *types.Response {
+	headers := make(map[string][]string)
+	for k, v := range httpResp.Header {
+		headers[k] = v
+	}
+
+	return &types.Response{
+		StatusCode: httpResp.StatusCode,
+		Status:     httpResp.Status,
+		Headers:    headers,
+		Duration:   time.Since(startTime),
+	}
+}
+
+// webSocketSession runs a script over an open connection and records the transcript.
+type webSocketSession struct {
+	conn     *websocket.Conn
+	timeout  time.Duration
+	messages []*types.WebSocketMessage
+	received int64
+}
+
+func (s *webSocketSession) run(steps []types.WebSocketStep) error {
+	for i, step := range steps {
+		if step.Send != "" {
+			if err := s.send(step.Send, step.Binary); err != nil {
+				return fmt.Errorf("%w: step %d: failed to send message: %v", errors.ErrRequestFailed, i+1, err)
+			}
+		}
+
+		if step.Expect == "" && !step.Receive {
+			continue
+		}
+
+		timeout := step.Timeout
+		if timeout <= 0 {
+			timeout = s.timeout
+		}
+
+		if err := s.expect(step.Expect, timeout); err != nil {
+			return fmt.Errorf("%w: step %d: %v", errors.ErrRequestFailed, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *webSocketSession) send(data string, binary bool) error {
+	messageType := websocket.TextMessage
+	if binary {
+		messageType = websocket.BinaryMessage
+	}
+
+	if err := s.conn.WriteMessage(messageType, []byte(data)); err != nil {
+		return err
+	}
+
+	s.record(types.WebSocketSent, binary, data)
+	return nil
+}
+
+// expect reads messages until one contains the pattern. Messages that do not
+// match are kept in the transcript.
+func (s *webSocketSession) expect(pattern string, timeout time.Duration) error {
+	if err := s.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if stderrors.As(err, &netErr) && netErr.Timeout() {
+				if pattern == "" {
+					return fmt.Errorf("no reply received within %v", timeout)
+				}
+
+				return fmt.Errorf("expected reply %q not received within %v", pattern, timeout)
+			}
+
+			return fmt.Errorf("failed to read message: %v", err)
+		}
+
+		s.received += int64(len(data))
+		s.record(types.WebSocketReceived, messageType == websocket.BinaryMessage, string(data))
+
+		if strings.Contains(string(data), pattern) {
+			return nil
+		}
+	}
+}
+
+func (s *webSocketSession) record(direction string, binary bool, data string) {
+	s.messages = append(s.messages, &types.WebSocketMessage{
+		Direction: direction,
+		Binary:    binary,
+		Data:      data,
+		Time:      time.Now(),
+	})
+}
+
+// close sends a normal closure frame. Errors are ignored because the connection
+// may already be broken when a step failed.
+func (s *webSocketSession) close() {
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsCloseTimeout))
+}
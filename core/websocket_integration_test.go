@@ -0,0 +1,73 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func echoWebSocketURL(query string) string {
+	return "ws" + strings.TrimPrefix(http_server.GetServerURL(), "http") + "/ws/echo" + query
+}
+
+func TestIntegrationExecuteWebSocket_Echo(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		URL: echoWebSocketURL(""),
+		WebSocket: &types.WebSocketSettings{
+			Steps: []types.WebSocketStep{
+				{Send: "first", Expect: "first"},
+				{Send: "second", Binary: true, Receive: true},
+			},
+		},
+	}
+
+	_, messages, err := client.ExecuteWebSocket(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(messages) != 4 {
+		t.Fatalf("expected 4 messages, got %d", len(messages))
+	}
+
+	last := messages[3]
+	if last.Direction != types.WebSocketReceived || !last.Binary || last.Data != "second" {
+		t.Errorf("expected binary echo of 'second', got %+v", last)
+	}
+}
+
+func TestIntegrationExecuteWebSocket_APIKeyQuery(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	client := NewHTTPClient(10*time.Second, 30*time.Second, false)
+	req := &types.Request{
+		URL:  echoWebSocketURL("?greet=1"),
+		Auth: &types.Auth{Type: "apikey", KeyName: "api_key", APIKey: "testapikey", Location: "query"},
+		WebSocket: &types.WebSocketSettings{
+			Steps: []types.WebSocketStep{{Expect: "api_key=testapikey"}},
+		},
+	}
+
+	_, messages, err := client.ExecuteWebSocket(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(messages) != 1 || !strings.Contains(messages[0].Data, "welcome") {
+		t.Errorf("expected greeting message, got %+v", messages)
+	}
+}
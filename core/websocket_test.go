@@ -0,0 +1,230 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/types"
+	"github.com/gorilla/websocket"
+)
+
+// newWebSocketServer starts a server that replies to every message with "ack:<message>".
+// Messages equal to "silent" get no reply.
+func newWebSocketServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("Unauthorized"))
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if string(data) == "silent" {
+				continue
+			}
+
+			conn.WriteMessage(websocket.TextMessage, []byte("ack:"+string(data)))
+		}
+	}))
+}
+
+func webSocketURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestUnitIsWebSocket(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"ws://example.com/socket", true},
+		{"WSS://example.com/socket", true},
+		{"http://example.com", false},
+		{"https://example.com", false},
+		{"://bad", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsWebSocket(&types.Request{URL: tt.url}); got != tt.expected {
+			t.Errorf("IsWebSocket(%q) = %v, expected %v", tt.url, got, tt.expected)
+		}
+	}
+}
+
+func TestUnitExecuteWebSocket_Script(t *testing.T) {
+	server := newWebSocketServer(t)
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, 5*time.Second, false)
+	req := &types.Request{
+		URL:  webSocketURL(server),
+		Auth: &types.Auth{Type: "bearer", Token: "secret"},
+		WebSocket: &types.WebSocketSettings{
+			Steps: []types.WebSocketStep{
+				{Send: "hello", Expect: "ack:hello"},
+				{Send: "silent"},
+				{Send: "world", Receive: true},
+			},
+		},
+	}
+
+	resp, messages, err := client.ExecuteWebSocket(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("expected status code 101, got %d", resp.StatusCode)
+	}
+
+	expected := []struct {
+		direction string
+		data      string
+	}{
+		{types.WebSocketSent, "hello"},
+		{types.WebSocketReceived, "ack:hello"},
+		{types.WebSocketSent, "silent"},
+		{types.WebSocketSent, "world"},
+		{types.WebSocketReceived, "ack:world"},
+	}
+
+	if len(messages) != len(expected) {
+		t.Fatalf("expected %d messages, got %d", len(expected), len(messages))
+	}
+
+	for i, e := range expected {
+		if messages[i].Direction != e.direction || messages[i].Data != e.data {
+			t.Errorf("message %d: expected %s %q, got %s %q", i, e.direction, e.data, messages[i].Direction, messages[i].Data)
+		}
+	}
+
+	if resp.Size != int64(len("ack:hello")+len("ack:world")) {
+		t.Errorf("expected size to count received bytes, got %d", resp.Size)
+	}
+}
+
+func TestUnitExecuteWebSocket_ExpectTimeout(t *testing.T) {
+	server := newWebSocketServer(t)
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, 5*time.Second, false)
+	req := &types.Request{
+		URL:     webSocketURL(server),
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+		WebSocket: &types.WebSocketSettings{
+			Timeout: 100 * time.Millisecond,
+			Steps: []types.WebSocketStep{
+				{Send: "ping", Expect: "ack:ping"},
+				{Send: "silent", Expect: "never"},
+			},
+		},
+	}
+
+	_, messages, err := client.ExecuteWebSocket(req)
+	if err == nil {
+		t.Fatal("expected error for missing reply")
+	}
+
+	if !strings.Contains(err.Error(), "step 2") || !strings.Contains(err.Error(), `"never"`) {
+		t.Errorf("expected error to name the failed step and expectation, got: %v", err)
+	}
+
+	if len(messages) != 3 {
+		t.Errorf("expected transcript up to the failure (3 messages), got %d", len(messages))
+	}
+}
+
+func TestUnitExecuteWebSocket_HandshakeRejected(t *testing.T) {
+	server := newWebSocketServer(t)
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, 5*time.Second, false)
+	req := &types.Request{URL: webSocketURL(server)}
+
+	resp, _, err := client.ExecuteWebSocket(req)
+	if err == nil {
+		t.Fatal("expected error for rejected handshake")
+	}
+
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected handshake response with status 401, got %+v", resp)
+	}
+
+	if string(resp.Body) != "Unauthorized" {
+		t.Errorf("expected handshake body to be kept, got %q", string(resp.Body))
+	}
+}
+
+func TestUnitExecuteExchange_WebSocket(t *testing.T) {
+	server := newWebSocketServer(t)
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, 5*time.Second, false)
+	req := &types.Request{
+		URL:       webSocketURL(server),
+		Auth:      &types.Auth{Type: "bearer", Token: "secret"},
+		WebSocket: &types.WebSocketSettings{Steps: []types.WebSocketStep{{Send: "x", Expect: "ack:x"}}},
+	}
+
+	exchange, err := client.ExecuteExchange(req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(exchange.Messages) != 2 || exchange.Events != nil {
+		t.Errorf("unexpected exchange: %+v", exchange)
+	}
+}
+
+func TestUnitResolveWebSocket(t *testing.T) {
+	global := environment.NewEnvironment("global")
+	global.Set("name", "alice")
+
+	resolver, err := NewVariableResolver(global, nil)
+	if err != nil {
+		t.Fatalf("failed to create resolver: %v", err)
+	}
+
+	settings := &types.WebSocketSettings{
+		Timeout: time.Second,
+		Steps:   []types.WebSocketStep{{Send: `{"user": "{{name}}"}`, Expect: "hi {{name}}", Receive: true}},
+	}
+
+	resolved, err := resolver.ResolveWebSocket(settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	step := resolved.Steps[0]
+	if step.Send != `{"user": "alice"}` || step.Expect != "hi alice" || !step.Receive {
+		t.Errorf("unexpected resolved step: %+v", step)
+	}
+
+	if settings.Steps[0].Send != `{"user": "{{name}}"}` {
+		t.Error("expected original settings to stay unresolved")
+	}
+
+	settings.Steps[0].Send = "{{missing}}"
+	if _, err := resolver.ResolveWebSocket(settings); err == nil {
+		t.Error("expected error for missing variable")
+	}
+}
@@ -0,0 +1,314 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package diff
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Kind is the kind of a change.
+type Kind string
+
+const (
+	// Unchanged marks a request that gave the same response in both runs.
+	Unchanged Kind = "unchanged"
+	// Added marks a value or request present only in the second document or run.
+	Added Kind = "added"
+	// Removed marks a value or request present only in the first document or run.
+	Removed Kind = "removed"
+	// Changed marks a value or request that differs between the two.
+	Changed Kind = "changed"
+)
+
+// Options controls what is compared.
+type Options struct {
+	// IgnorePaths lists JSON body paths whose values are not compared, such as
+	// timestamps and generated IDs, e.g. "$.updated_at" or "$.items[*].id".
+	// A path also ignores everything under it.
+	IgnorePaths []string
+	// IgnoreHeaders lists response headers that are not compared, such as Date.
+	IgnoreHeaders []string
+}
+
+// Change is a difference between two JSON bodies. Before is nil for added values
+// and After for removed ones.
+type Change struct {
+	Path   string `json:"path"`
+	Kind   Kind   `json:"kind"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// HeaderChange is a difference between the values of a response header.
+type HeaderChange struct {
+	Name   string   `json:"name"`
+	Kind   Kind     `json:"kind"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// ResponseDiff is the difference between two responses. Bodies that are not both
+// JSON are compared as a whole, reported as a single change of the "$" path.
+// GRPCCodeBefore and GRPCCodeAfter hold the status codes of gRPC calls.
+type ResponseDiff struct {
+	StatusBefore   int            `json:"status_before"`
+	StatusAfter    int            `json:"status_after"`
+	GRPCCodeBefore *int           `json:"grpc_code_before,omitempty"`
+	GRPCCodeAfter  *int           `json:"grpc_code_after,omitempty"`
+	DurationBefore time.Duration  `json:"duration_before"`
+	DurationAfter  time.Duration  `json:"duration_after"`
+	Headers        []HeaderChange `json:"headers,omitempty"`
+	Body           []Change       `json:"body,omitempty"`
+}
+
+// StatusChanged reports whether the HTTP or gRPC status codes differ.
+func (d *ResponseDiff) StatusChanged() bool {
+	if (d.GRPCCodeBefore == nil) != (d.GRPCCodeAfter == nil) {
+		return true
+	}
+
+	if d.GRPCCodeBefore != nil && *d.GRPCCodeBefore != *d.GRPCCodeAfter {
+		return true
+	}
+
+	return d.StatusBefore != d.StatusAfter
+}
+
+// LatencyDelta returns how much slower the second response was.
+func (d *ResponseDiff) LatencyDelta() time.Duration {
+	return d.DurationAfter - d.DurationBefore
+}
+
+// Equal reports whether the responses have the same status, headers and body.
+// Latency is not taken into account.
+func (d *ResponseDiff) Equal() bool {
+	return !d.StatusChanged() && len(d.Headers) == 0 && len(d.Body) == 0
+}
+
+// ItemDiff is the difference between the executions of a request in two runs.
+// Response is nil unless both executions have a response.
+type ItemDiff struct {
+	Name           string        `json:"name"`
+	Kind           Kind          `json:"kind"`
+	ErrorBefore    string        `json:"error_before,omitempty"`
+	ErrorAfter     string        `json:"error_after,omitempty"`
+	DurationBefore time.Duration `json:"duration_before"`
+	DurationAfter  time.Duration `json:"duration_after"`
+	Response       *ResponseDiff `json:"response,omitempty"`
+}
+
+// LatencyDelta returns how much slower the request was in the second run.
+func (d *ItemDiff) LatencyDelta() time.Duration {
+	return d.DurationAfter - d.DurationBefore
+}
+
+// ResultDiff is the difference between two execution results.
+type ResultDiff struct {
+	DurationBefore time.Duration `json:"duration_before"`
+	DurationAfter  time.Duration `json:"duration_after"`
+	Items          []*ItemDiff   `json:"items"`
+}
+
+// LatencyDelta returns how much longer the second run took.
+func (d *ResultDiff) LatencyDelta() time.Duration {
+	return d.DurationAfter - d.DurationBefore
+}
+
+// Equal reports whether every request behaved the same in both runs.
+func (d *ResultDiff) Equal() bool {
+	for _, item := range d.Items {
+		if item.Kind != Unchanged {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Responses compares two responses.
+func Responses(before, after *types.Response, opts *Options) *ResponseDiff {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	return &ResponseDiff{
+		StatusBefore:   before.StatusCode,
+		StatusAfter:    after.StatusCode,
+		GRPCCodeBefore: before.GRPCCode,
+		GRPCCodeAfter:  after.GRPCCode,
+		DurationBefore: before.Duration,
+		DurationAfter:  after.Duration,
+		Headers:        headerChanges(before.Headers, after.Headers, opts.IgnoreHeaders),
+		Body:           bodyChanges(before.Body, after.Body, opts),
+	}
+}
+
+// Results compares two execution results, such as two runs of a collection.
+// Requests are matched by the name of their collection item when both runs know it,
+// and otherwise by method and URL, before variables were resolved when that is
+// known, so runs against different environments can be compared. Items follow the
+// order of the second run, with requests missing from it at the end.
+func Results(before, after *types.ExecutionResult, opts *Options) *ResultDiff {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	d := &ResultDiff{
+		DurationBefore: before.TotalDuration,
+		DurationAfter:  after.TotalDuration,
+	}
+
+	matched := make([]bool, len(before.Requests))
+
+	for _, execution := range after.Requests {
+		name := executionName(execution)
+		index := -1
+
+		for i, candidate := range before.Requests {
+			if !matched[i] && sameRequest(candidate, execution) {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			d.Items = append(d.Items, &ItemDiff{Name: name, Kind: Added, ErrorAfter: execution.Error, DurationAfter: execution.Duration})
+			continue
+		}
+
+		matched[index] = true
+		d.Items = append(d.Items, executions(name, before.Requests[index], execution, opts))
+	}
+
+	for i, execution := range before.Requests {
+		if !matched[i] {
+			d.Items = append(d.Items, &ItemDiff{Name: executionName(execution), Kind: Removed, ErrorBefore: execution.Error, DurationBefore: execution.Duration})
+		}
+	}
+
+	return d
+}
+
+func executions(name string, before, after *types.RequestExecution, opts *Options) *ItemDiff {
+	item := &ItemDiff{
+		Name:           name,
+		Kind:           Unchanged,
+		ErrorBefore:    before.Error,
+		ErrorAfter:     after.Error,
+		DurationBefore: before.Duration,
+		DurationAfter:  after.Duration,
+	}
+
+	if before.Response != nil && after.Response != nil {
+		item.Response = Responses(before.Response, after.Response, opts)
+	}
+
+	if before.Error != after.Error || (before.Response == nil) != (after.Response == nil) ||
+		item.Response != nil && !item.Response.Equal() {
+		item.Kind = Changed
+	}
+
+	return item
+}
+
+// sameRequest reports whether two executions of different runs are of the same
+// request: of the same collection item, or when an item name is missing, of the
+// same method and URL.
+func sameRequest(before, after *types.RequestExecution) bool {
+	if before.Name != "" && after.Name != "" {
+		return before.Name == after.Name
+	}
+
+	return requestName(before) == requestName(after)
+}
+
+// executionName names the request of an execution after its collection item, or
+// its method and URL when the item is not known.
+func executionName(execution *types.RequestExecution) string {
+	if execution.Name != "" {
+		return execution.Name
+	}
+
+	return requestName(execution)
+}
+
+// requestName identifies the request of an execution by its method and URL.
+func requestName(execution *types.RequestExecution) string {
+	req := execution.Request
+	if execution.Unresolved != nil {
+		req = execution.Unresolved
+	}
+
+	if req == nil {
+		return ""
+	}
+
+	return req.Method + " " + req.URL
+}
+
+func headerChanges(before, after map[string][]string, ignore []string) []HeaderChange {
+	beforeHeaders := canonicalHeaders(before, ignore)
+	afterHeaders := canonicalHeaders(after, ignore)
+
+	names := make([]string, 0, len(beforeHeaders)+len(afterHeaders))
+	for name := range beforeHeaders {
+		names = append(names, name)
+	}
+	for name := range afterHeaders {
+		if _, ok := beforeHeaders[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []HeaderChange
+
+	for _, name := range names {
+		beforeValues, inBefore := beforeHeaders[name]
+		afterValues, inAfter := afterHeaders[name]
+
+		switch {
+		case !inAfter:
+			changes = append(changes, HeaderChange{Name: name, Kind: Removed, Before: beforeValues})
+		case !inBefore:
+			changes = append(changes, HeaderChange{Name: name, Kind: Added, After: afterValues})
+		case !slices.Equal(beforeValues, afterValues):
+			changes = append(changes, HeaderChange{Name: name, Kind: Changed, Before: beforeValues, After: afterValues})
+		}
+	}
+
+	return changes
+}
+
+func canonicalHeaders(headers map[string][]string, ignore []string) map[string][]string {
+	canonical := make(map[string][]string, len(headers))
+
+	for name, values := range headers {
+		name = http.CanonicalHeaderKey(name)
+		if !slices.ContainsFunc(ignore, func(ignored string) bool { return strings.EqualFold(ignored, name) }) {
+			canonical[name] = append(canonical[name], values...)
+		}
+	}
+
+	return canonical
+}
+
+func bodyChanges(before, after []byte, opts *Options) []Change {
+	if changes, err := JSON(before, after, opts); err == nil {
+		return changes
+	}
+
+	if bytes.Equal(before, after) {
+		return nil
+	}
+
+	return []Change{{Path: "$", Kind: Changed, Before: string(before), After: string(after)}}
+}
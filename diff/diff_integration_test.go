@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationResponses_Echo(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+
+	execute := func(path string) *types.Response {
+		t.Helper()
+
+		resp, err := httpClient.Execute(&types.Request{Method: http.MethodGet, URL: http_server.GetServerURL() + path})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return resp
+	}
+
+	first := execute("/echo?page=1")
+	second := execute("/echo?page=2")
+
+	d := Responses(first, second, &Options{IgnoreHeaders: []string{"Date"}})
+	if d.StatusChanged() || len(d.Headers) != 0 {
+		t.Errorf("expected same status and headers, got %+v", d)
+	}
+	if len(d.Body) != 1 || d.Body[0].Path != "$.query.page[0]" {
+		t.Errorf("expected the page query to differ, got %+v", d.Body)
+	}
+
+	d = Responses(first, second, &Options{IgnoreHeaders: []string{"Date"}, IgnorePaths: []string{"$.query"}})
+	if !d.Equal() {
+		t.Errorf("expected equal responses with ignored query, got %+v", d.Body)
+	}
+}
@@ -0,0 +1,231 @@
+package diff
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitResponses_Equal(t *testing.T) {
+	resp := &types.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string][]string{"Content-Type": {"application/json"}},
+		Body:       []byte(`{"id": 1}`),
+		Duration:   100 * time.Millisecond,
+	}
+	other := *resp
+	other.Duration = 150 * time.Millisecond
+
+	d := Responses(resp, &other, nil)
+	if !d.Equal() {
+		t.Errorf("expected equal responses, got %+v", d)
+	}
+	if d.LatencyDelta() != 50*time.Millisecond {
+		t.Errorf("expected latency delta of 50ms, got %v", d.LatencyDelta())
+	}
+}
+
+func TestUnitResponses_Changes(t *testing.T) {
+	before := &types.Response{
+		StatusCode: http.StatusOK,
+		Headers: map[string][]string{
+			"Content-Type": {"application/json"},
+			"X-Old":        {"1"},
+			"Date":         {"Mon"},
+		},
+		Body: []byte(`{"name": "a"}`),
+	}
+	after := &types.Response{
+		StatusCode: http.StatusInternalServerError,
+		Headers: map[string][]string{
+			"content-type": {"text/plain"},
+			"X-New":        {"2"},
+			"Date":         {"Tue"},
+		},
+		Body: []byte(`{"name": "b"}`),
+	}
+
+	d := Responses(before, after, &Options{IgnoreHeaders: []string{"date"}})
+
+	if !d.StatusChanged() || d.Equal() {
+		t.Error("expected status change")
+	}
+
+	if len(d.Headers) != 3 {
+		t.Fatalf("expected 3 header changes, got %+v", d.Headers)
+	}
+	if d.Headers[0].Name != "Content-Type" || d.Headers[0].Kind != Changed {
+		t.Errorf("unexpected change: %+v", d.Headers[0])
+	}
+	if d.Headers[1].Name != "X-New" || d.Headers[1].Kind != Added {
+		t.Errorf("unexpected change: %+v", d.Headers[1])
+	}
+	if d.Headers[2].Name != "X-Old" || d.Headers[2].Kind != Removed {
+		t.Errorf("unexpected change: %+v", d.Headers[2])
+	}
+
+	if len(d.Body) != 1 || d.Body[0].Path != "$.name" {
+		t.Errorf("unexpected body changes: %+v", d.Body)
+	}
+}
+
+func TestUnitResponses_TextBody(t *testing.T) {
+	before := &types.Response{StatusCode: http.StatusOK, Body: []byte("hello")}
+	after := &types.Response{StatusCode: http.StatusOK, Body: []byte("world")}
+
+	d := Responses(before, after, nil)
+	if len(d.Body) != 1 || d.Body[0].Path != "$" || d.Body[0].Before != "hello" || d.Body[0].After != "world" {
+		t.Errorf("unexpected body changes: %+v", d.Body)
+	}
+
+	if d := Responses(before, before, nil); !d.Equal() {
+		t.Errorf("expected equal text bodies, got %+v", d.Body)
+	}
+}
+
+func TestUnitResponses_GRPC(t *testing.T) {
+	ok, unavailable := 0, 14
+	before := &types.Response{GRPCCode: &ok, Status: "OK"}
+	after := &types.Response{GRPCCode: &unavailable, Status: "Unavailable"}
+
+	if d := Responses(before, after, nil); !d.StatusChanged() {
+		t.Error("expected a changed gRPC status")
+	}
+
+	if d := Responses(before, &types.Response{GRPCCode: &ok, Status: "OK"}, nil); !d.Equal() {
+		t.Errorf("expected equal gRPC responses, got %+v", d)
+	}
+}
+
+func execution(method, url string, status int, duration time.Duration) *types.RequestExecution {
+	return &types.RequestExecution{
+		Request:  &types.Request{Method: method, URL: url},
+		Response: &types.Response{StatusCode: status, Body: []byte(`{}`)},
+		Duration: duration,
+	}
+}
+
+func TestUnitResults(t *testing.T) {
+	before := &types.ExecutionResult{
+		TotalDuration: time.Second,
+		Requests: []*types.RequestExecution{
+			execution("GET", "/users", 200, 100*time.Millisecond),
+			execution("GET", "/orders", 200, 100*time.Millisecond),
+			execution("DELETE", "/users/1", 204, 100*time.Millisecond),
+		},
+	}
+
+	failed := execution("GET", "/orders", 0, 0)
+	failed.Response = nil
+	failed.Error = "connection refused"
+
+	after := &types.ExecutionResult{
+		TotalDuration: 2 * time.Second,
+		Requests: []*types.RequestExecution{
+			execution("GET", "/users", 200, 300*time.Millisecond),
+			failed,
+			execution("POST", "/users", 201, 100*time.Millisecond),
+		},
+	}
+
+	d := Results(before, after, nil)
+
+	if d.Equal() || d.LatencyDelta() != time.Second {
+		t.Errorf("unexpected result diff: %+v", d)
+	}
+
+	expected := []struct {
+		name string
+		kind Kind
+	}{
+		{"GET /users", Unchanged},
+		{"GET /orders", Changed},
+		{"POST /users", Added},
+		{"DELETE /users/1", Removed},
+	}
+
+	if len(d.Items) != len(expected) {
+		t.Fatalf("expected %d items, got %d", len(expected), len(d.Items))
+	}
+
+	for i, item := range d.Items {
+		if item.Name != expected[i].name || item.Kind != expected[i].kind {
+			t.Errorf("item %d: expected %s %s, got %s %s", i, expected[i].name, expected[i].kind, item.Name, item.Kind)
+		}
+	}
+
+	if d.Items[0].LatencyDelta() != 200*time.Millisecond {
+		t.Errorf("expected latency delta of 200ms, got %v", d.Items[0].LatencyDelta())
+	}
+	if d.Items[1].ErrorAfter != "connection refused" || d.Items[1].Response != nil {
+		t.Errorf("unexpected item: %+v", d.Items[1])
+	}
+}
+
+func TestUnitResults_MatchesUnresolvedRequests(t *testing.T) {
+	before := execution("GET", "http://staging/users", 200, 0)
+	before.Unresolved = &types.Request{Method: "GET", URL: "{{base_url}}/users"}
+	after := execution("GET", "http://production/users", 200, 0)
+	after.Unresolved = &types.Request{Method: "GET", URL: "{{base_url}}/users"}
+
+	d := Results(
+		&types.ExecutionResult{Requests: []*types.RequestExecution{before}},
+		&types.ExecutionResult{Requests: []*types.RequestExecution{after}},
+		nil,
+	)
+
+	if len(d.Items) != 1 || d.Items[0].Kind != Unchanged || d.Items[0].Name != "GET {{base_url}}/users" {
+		t.Errorf("expected requests to be matched, got %+v", d.Items)
+	}
+}
+
+func TestUnitResults_DuplicateRequests(t *testing.T) {
+	before := &types.ExecutionResult{Requests: []*types.RequestExecution{
+		execution("GET", "/poll", 202, 0),
+		execution("GET", "/poll", 200, 0),
+	}}
+	after := &types.ExecutionResult{Requests: []*types.RequestExecution{
+		execution("GET", "/poll", 202, 0),
+		execution("GET", "/poll", 202, 0),
+	}}
+
+	d := Results(before, after, nil)
+	if len(d.Items) != 2 || d.Items[0].Kind != Unchanged || d.Items[1].Kind != Changed {
+		t.Errorf("expected repeated requests to be matched in order, got %+v", d.Items)
+	}
+}
+
+func TestUnitResults_MatchesItemNames(t *testing.T) {
+	createUser := execution("POST", "/users", 201, 0)
+	createUser.Name = "Create user"
+	createAdmin := execution("POST", "/users", 201, 0)
+	createAdmin.Name = "Create admin"
+
+	renamed := execution("PUT", "/users/1", 200, 0)
+	renamed.Name = "Update user"
+	moved := execution("PATCH", "/users/1", 200, 0)
+	moved.Name = "Update user"
+
+	unnamed := execution("GET", "/users", 200, 0)
+	named := execution("GET", "/users", 200, 0)
+	named.Name = "List users"
+
+	d := Results(
+		&types.ExecutionResult{Requests: []*types.RequestExecution{createUser, createAdmin, renamed, unnamed}},
+		&types.ExecutionResult{Requests: []*types.RequestExecution{createAdmin, createUser, moved, named}},
+		nil,
+	)
+
+	expected := []string{"Create admin", "Create user", "Update user", "List users"}
+	if len(d.Items) != len(expected) {
+		t.Fatalf("expected %d items, got %+v", len(expected), d.Items)
+	}
+
+	for i, item := range d.Items {
+		if item.Name != expected[i] || item.Kind != Unchanged {
+			t.Errorf("item %d: expected unchanged %s, got %s %s", i, expected[i], item.Kind, item.Name)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSON compares two JSON documents structurally and returns the changes, ordered
+// by path. Object keys are compared regardless of their order, array elements by
+// index. Values under an ignored path (see Options.IgnorePaths) are not compared.
+func JSON(before, after []byte, opts *Options) ([]Change, error) {
+	beforeValue, err := decodeJSON(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse first document: %w", err)
+	}
+
+	afterValue, err := decodeJSON(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse second document: %w", err)
+	}
+
+	return Values(beforeValue, afterValue, opts), nil
+}
+
+// Values compares two decoded JSON values like JSON does.
+func Values(before, after any, opts *Options) []Change {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	d := &jsonDiff{ignore: parsePatterns(opts.IgnorePaths)}
+	d.compare(nil, before, after)
+	return d.changes
+}
+
+// decodeJSON decodes a document keeping numbers as written.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+type jsonDiff struct {
+	ignore  [][]string
+	changes []Change
+}
+
+func (d *jsonDiff) compare(path []string, before, after any) {
+	if d.ignored(path) {
+		return
+	}
+
+	switch beforeValue := before.(type) {
+	case map[string]any:
+		if afterValue, ok := after.(map[string]any); ok {
+			d.compareObjects(path, beforeValue, afterValue)
+			return
+		}
+	case []any:
+		if afterValue, ok := after.([]any); ok {
+			d.compareArrays(path, beforeValue, afterValue)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		d.changes = append(d.changes, Change{Path: FormatPath(path), Kind: Changed, Before: before, After: after})
+	}
+}
+
+func (d *jsonDiff) compareObjects(path []string, before, after map[string]any) {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := append(path[:len(path):len(path)], key)
+		beforeValue, inBefore := before[key]
+		afterValue, inAfter := after[key]
+
+		switch {
+		case !inAfter:
+			d.add(keyPath, Removed, beforeValue, nil)
+		case !inBefore:
+			d.add(keyPath, Added, nil, afterValue)
+		default:
+			d.compare(keyPath, beforeValue, afterValue)
+		}
+	}
+}
+
+func (d *jsonDiff) compareArrays(path []string, before, after []any) {
+	for i := 0; i < max(len(before), len(after)); i++ {
+		indexPath := append(path[:len(path):len(path)], "["+strconv.Itoa(i)+"]")
+
+		switch {
+		case i >= len(after):
+			d.add(indexPath, Removed, before[i], nil)
+		case i >= len(before):
+			d.add(indexPath, Added, nil, after[i])
+		default:
+			d.compare(indexPath, before[i], after[i])
+		}
+	}
+}
+
+func (d *jsonDiff) add(path []string, kind Kind, before, after any) {
+	if !d.ignored(path) {
+		d.changes = append(d.changes, Change{Path: FormatPath(path), Kind: kind, Before: before, After: after})
+	}
+}
+
+// ignored reports whether an ignore pattern matches path or one of its parents.
+func (d *jsonDiff) ignored(path []string) bool {
+	for _, pattern := range d.ignore {
+		if matchPath(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatPath formats the segments of a path, where array indexes are written as
+// "[N]", in the form used by Change.Path: "$.items[0].id".
+func FormatPath(segments []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+
+	for _, segment := range segments {
+		switch {
+		case isIndex(segment):
+			sb.WriteString(segment)
+		case isIdentifier(segment):
+			sb.WriteString("." + segment)
+		default:
+			sb.WriteString("[" + strconv.Quote(segment) + "]")
+		}
+	}
+
+	return sb.String()
+}
+
+// ParsePath splits a path in the form of Change.Path into its segments. The "$."
+// prefix is optional. "*" and "[*]" are wildcards matching any key or index.
+func ParsePath(path string) []string {
+	path = strings.TrimPrefix(path, "$")
+
+	var segments []string
+
+	for len(path) > 0 {
+		switch {
+		case path[0] == '.':
+			path = path[1:]
+		case strings.HasPrefix(path, `["`):
+			end := strings.Index(path[2:], `"]`)
+			if end < 0 {
+				return append(segments, path)
+			}
+
+			key, err := strconv.Unquote(path[1 : end+3])
+			if err != nil {
+				key = path[2 : end+2]
+			}
+
+			segments = append(segments, key)
+			path = path[end+4:]
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return append(segments, path)
+			}
+
+			segments = append(segments, path[:end+1])
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+
+			segments = append(segments, path[:end])
+			path = path[end:]
+		}
+	}
+
+	return segments
+}
+
+func parsePatterns(paths []string) [][]string {
+	patterns := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		patterns = append(patterns, ParsePath(path))
+	}
+
+	return patterns
+}
+
+// MatchPath reports whether a path in the form of Change.Path is pattern or lies
+// under it. The pattern may use the wildcards of ParsePath.
+func MatchPath(pattern, path string) bool {
+	return matchPath(ParsePath(pattern), ParsePath(path))
+}
+
+func matchPath(pattern, path []string) bool {
+	if len(pattern) > len(path) {
+		return false
+	}
+
+	for i, segment := range pattern {
+		switch {
+		case segment == "[*]":
+			if !isIndex(path[i]) {
+				return false
+			}
+		case segment == "*":
+		case segment != path[i]:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isIndex(segment string) bool {
+	if len(segment) < 3 || segment[0] != '[' || segment[len(segment)-1] != ']' {
+		return false
+	}
+
+	_, err := strconv.Atoi(segment[1 : len(segment)-1])
+	return err == nil
+}
+
+func isIdentifier(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	for _, r := range segment {
+		if !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
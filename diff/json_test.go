@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func changePaths(changes []Change) string {
+	var paths []string
+	for _, change := range changes {
+		paths = append(paths, string(change.Kind)+" "+change.Path)
+	}
+
+	return strings.Join(paths, ", ")
+}
+
+func TestUnitJSON_Equal(t *testing.T) {
+	changes, err := JSON([]byte(`{"a": 1, "b": [1, 2]}`), []byte(`{"b": [1, 2], "a": 1}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestUnitJSON_Changes(t *testing.T) {
+	before := `{"id": 1, "name": "old", "gone": true, "items": [{"id": 1}, {"id": 2}], "odd key": 1}`
+	after := `{"id": 1, "name": "new", "added": null, "items": [{"id": 1}], "odd key": 2}`
+
+	changes, err := JSON([]byte(before), []byte(after), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `added $.added, removed $.gone, removed $.items[1], changed $.name, changed $["odd key"]`
+	if got := changePaths(changes); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if changes[3].Before != "old" || changes[3].After != "new" {
+		t.Errorf("unexpected values: %+v", changes[3])
+	}
+}
+
+func TestUnitJSON_TypeChange(t *testing.T) {
+	changes, err := JSON([]byte(`{"a": {"b": 1}}`), []byte(`{"a": [1]}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := changePaths(changes); got != "changed $.a" {
+		t.Errorf("expected a single change of $.a, got %s", got)
+	}
+}
+
+func TestUnitJSON_IgnorePaths(t *testing.T) {
+	before := `{"updated_at": "2024", "items": [{"id": 1, "name": "a"}], "meta": {"trace": "x"}}`
+	after := `{"updated_at": "2025", "items": [{"id": 2, "name": "b"}], "meta": {"trace": "y", "extra": 1}}`
+
+	changes, err := JSON([]byte(before), []byte(after), &Options{
+		IgnorePaths: []string{"updated_at", "$.items[*].id", "$.meta"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := changePaths(changes); got != "changed $.items[0].name" {
+		t.Errorf("expected only the name change, got %s", got)
+	}
+}
+
+func TestUnitJSON_Invalid(t *testing.T) {
+	if _, err := JSON([]byte(`{`), []byte(`{}`), nil); err == nil {
+		t.Error("expected error for invalid first document")
+	}
+	if _, err := JSON([]byte(`{}`), []byte(`nope`), nil); err == nil {
+		t.Error("expected error for invalid second document")
+	}
+}
+
+func TestUnitParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"$", ""},
+		{"$.a.b", "a|b"},
+		{"a[0].b", "a|[0]|b"},
+		{`$["odd key"].x`, "odd key|x"},
+		{"$.items[*].*", "items|[*]|*"},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(ParsePath(tt.path), "|"); got != tt.expected {
+			t.Errorf("ParsePath(%q): expected %q, got %q", tt.path, tt.expected, got)
+		}
+	}
+}
+
+func TestUnitFormatPath_RoundTrip(t *testing.T) {
+	for _, path := range []string{"$", "$.a[0].b", `$["odd key"][2]`} {
+		if got := FormatPath(ParsePath(path)); got != path {
+			t.Errorf("expected %s, got %s", path, got)
+		}
+	}
+}
+
+func TestUnitMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"$.a", "$.a", true},
+		{"$.a", "$.a.b[0]", true},
+		{"$.a.b", "$.a", false},
+		{"$.items[*].id", "$.items[3].id", true},
+		{"$.items[*].id", "$.items.x.id", false},
+		{"$.*.id", "$.user.id", true},
+		{"$.a", "$.b", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchPath(tt.pattern, tt.path); got != tt.expected {
+			t.Errorf("MatchPath(%q, %q): expected %v, got %v", tt.pattern, tt.path, tt.expected, got)
+		}
+	}
+}
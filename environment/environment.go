@@ -4,11 +4,12 @@ Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor
 package environment
 
 import (
-	"encoding/json"
 	"fmt"
 	"maps"
 	"os"
 	"sync"
+
+	"github.com/KonnorFrik/getman/storage"
 )
 
 // Environment represents a collection of variables that can be used for request templating.
@@ -26,7 +27,8 @@ func NewEnvironment(name string) *Environment {
 	}
 }
 
-// NewEnvironmentFromFile loads an environment directly from a JSON file.
+// NewEnvironmentFromFile loads an environment directly from a JSON or YAML file.
+// The format is chosen by the file extension.
 func NewEnvironmentFromFile(filepath string) (*Environment, error) {
 	data, err := os.ReadFile(filepath)
 
@@ -34,8 +36,13 @@ func NewEnvironmentFromFile(filepath string) (*Environment, error) {
 		return nil, fmt.Errorf("failed to read environment file: %w", err)
 	}
 
+	return ParseEnvironment(data, storage.FormatForPath(filepath))
+}
+
+// ParseEnvironment decodes and validates an environment stored in the given format.
+func ParseEnvironment(data []byte, format storage.Format) (*Environment, error) {
 	var env Environment
-	if err := json.Unmarshal(data, &env); err != nil {
+	if err := storage.Unmarshal(data, &env, format); err != nil {
 		return nil, fmt.Errorf("failed to parse environment file: %w", err)
 	}
 
@@ -84,7 +91,7 @@ func (e *Environment) CopyMap() map[string]string {
 	return result
 }
 
-// Load overwrites the environment with data from a JSON file.
+// Load overwrites the environment with data from a JSON or YAML file.
 func (e *Environment) Load(filepath string) error {
 	data, err := os.ReadFile(filepath)
 
@@ -93,7 +100,7 @@ func (e *Environment) Load(filepath string) error {
 	}
 
 	var env Environment
-	if err := json.Unmarshal(data, &env); err != nil {
+	if err := storage.Unmarshal(data, &env, storage.FormatForPath(filepath)); err != nil {
 		return fmt.Errorf("failed to parse environment file: %w", err)
 	}
 
@@ -106,25 +113,37 @@ func (e *Environment) Load(filepath string) error {
 	return nil
 }
 
-// Save saves the environment to a JSON file. If the file exists, it will be overwritten.
+// Save saves the environment to a JSON or YAML file chosen by the file extension.
+// If the file exists, it is replaced atomically.
 func (e *Environment) Save(filepath string) error {
-	if err := validateEnvironment(e); err != nil {
-		return fmt.Errorf("invalid environment: %w", err)
-	}
-
-	data, err := json.MarshalIndent(e, "", "  ")
+	data, err := e.Marshal(storage.FormatForPath(filepath))
 
 	if err != nil {
-		return fmt.Errorf("failed to marshal environment: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(filepath, data, 0644); err != nil {
+	if err := storage.WriteFileAtomic(filepath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write environment file: %w", err)
 	}
 
 	return nil
 }
 
+// Marshal validates the environment and encodes it in the given format.
+func (e *Environment) Marshal(format storage.Format) ([]byte, error) {
+	if err := validateEnvironment(e); err != nil {
+		return nil, fmt.Errorf("invalid environment: %w", err)
+	}
+
+	data, err := storage.Marshal(e, format)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal environment: %w", err)
+	}
+
+	return data, nil
+}
+
 // func LoadEnvironmentFromFile(filePath string) (*types.Environment, error) {
 // 	data, err := os.ReadFile(filePath)
 // 	if err != nil {
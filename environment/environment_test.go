@@ -132,6 +132,35 @@ func TestUnitSaveEnvironmentToFile_Valid(t *testing.T) {
 	}
 }
 
+func TestUnitSaveEnvironmentToFile_BodyLikeVariables(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	for _, fileName := range []string{"test.json", "test.yaml"} {
+		env := &Environment{
+			Name:      "test",
+			Variables: map[string]string{"type": "admin", "text": "hello"},
+		}
+
+		filePath := filepath.Join(dir, fileName)
+		if err := env.Save(filePath); err != nil {
+			t.Fatalf("%s: unexpected error: %v", fileName, err)
+		}
+
+		loadedEnv, err := NewEnvironmentFromFile(filePath)
+		if err != nil {
+			t.Fatalf("%s: unexpected error loading environment: %v", fileName, err)
+		}
+
+		if len(loadedEnv.Variables) != 2 || loadedEnv.Variables["type"] != "admin" || loadedEnv.Variables["text"] != "hello" {
+			t.Errorf("%s: expected variables to be kept, got %v", fileName, loadedEnv.Variables)
+		}
+	}
+}
+
 func TestUnitSaveEnvironmentToFile_InvalidEnvironment(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 
@@ -252,3 +281,40 @@ func TestUnitSaveEnvironmentToFile_EmptyVariables(t *testing.T) {
 	}
 }
 
+
+func TestUnitSaveEnvironmentToFile_YAML(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	env := &Environment{
+		Name:      "dev",
+		Variables: map[string]string{"baseUrl": "http://localhost", "retries": "3"},
+	}
+
+	filePath := filepath.Join(dir, "dev.yaml")
+	if err := env.Save(filePath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	expected := "name: dev\nvariables:\n  baseUrl: http://localhost\n  retries: \"3\"\n"
+	if string(data) != expected {
+		t.Errorf("unexpected YAML:\n%s", data)
+	}
+
+	loadedEnv, err := NewEnvironmentFromFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error loading environment: %v", err)
+	}
+
+	if loadedEnv.Name != "dev" || loadedEnv.Variables["retries"] != "3" {
+		t.Errorf("unexpected environment: %+v", loadedEnv.Variables)
+	}
+}
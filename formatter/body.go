@@ -0,0 +1,600 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package formatter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxHexDumpSize is the number of bytes of a binary body shown in a hexdump.
+const MaxHexDumpSize = 512
+
+var (
+	colorBodyKey     = color.New(color.FgHiBlue)
+	colorBodyString  = color.New(color.FgGreen)
+	colorBodyNumber  = color.New(color.FgYellow)
+	colorBodyLiteral = color.New(color.FgMagenta)
+	colorBodyTag     = color.New(color.FgCyan)
+	colorBodyComment = color.New(color.FgHiBlack)
+)
+
+// BodyRenderer renders a body of a media type, such as "application/json", for
+// display. colored is set when the result is printed to a terminal, so the renderer
+// may highlight the syntax. A renderer returns an error for a body it cannot
+// render; the body is shown as text or as a hexdump then.
+type BodyRenderer func(body []byte, mediaType string, colored bool) (string, error)
+
+var bodyRenderers = struct {
+	mu        sync.RWMutex
+	renderers map[string]BodyRenderer
+}{
+	renderers: map[string]BodyRenderer{
+		"application/json":                  RenderJSON,
+		"+json":                             RenderJSON,
+		"application/xml":                   RenderXML,
+		"text/xml":                          RenderXML,
+		"+xml":                              RenderXML,
+		"text/html":                         RenderHTML,
+		"application/yaml":                  RenderYAML,
+		"application/x-yaml":                RenderYAML,
+		"text/yaml":                         RenderYAML,
+		"text/x-yaml":                       RenderYAML,
+		"+yaml":                             RenderYAML,
+		"application/x-www-form-urlencoded": RenderForm,
+		"image/*":                           RenderImage,
+		"audio/*":                           RenderSize,
+		"video/*":                           RenderSize,
+		"font/*":                            RenderSize,
+		"application/pdf":                   RenderSize,
+		"application/zip":                   RenderSize,
+		"application/gzip":                  RenderSize,
+		"application/octet-stream":          RenderHexDump,
+	},
+}
+
+// RegisterBodyRenderer registers a renderer for bodies matching a pattern, replacing
+// the renderer registered for it before. The pattern is a media type such as
+// "application/json", a structured syntax suffix such as "+json" or a type wildcard
+// such as "image/*"; they are matched in this order. A nil renderer removes the
+// pattern.
+func RegisterBodyRenderer(pattern string, renderer BodyRenderer) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	bodyRenderers.mu.Lock()
+	defer bodyRenderers.mu.Unlock()
+
+	if renderer == nil {
+		delete(bodyRenderers.renderers, pattern)
+		return
+	}
+
+	bodyRenderers.renderers[pattern] = renderer
+}
+
+// lookupBodyRenderer returns the renderer of a media type, or nil.
+func lookupBodyRenderer(mediaType string) BodyRenderer {
+	bodyRenderers.mu.RLock()
+	defer bodyRenderers.mu.RUnlock()
+
+	if renderer, ok := bodyRenderers.renderers[mediaType]; ok {
+		return renderer
+	}
+
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		if renderer, ok := bodyRenderers.renderers[mediaType[i:]]; ok {
+			return renderer
+		}
+	}
+
+	if i := strings.IndexByte(mediaType, '/'); i >= 0 {
+		if renderer, ok := bodyRenderers.renderers[mediaType[:i]+"/*"]; ok {
+			return renderer
+		}
+	}
+
+	return nil
+}
+
+// FormatBody formats a body for display by the renderer of its content type. The
+// content type is detected from the body when empty. Bodies without a renderer are
+// shown as text, or as a hexdump when they are not valid UTF-8.
+func FormatBody(body []byte, contentType string) string {
+	return renderBody(body, contentType, false)
+}
+
+func renderBody(body []byte, contentType string, colored bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(body))
+	}
+
+	renderer := lookupBodyRenderer(mediaType)
+	if renderer == nil && isJSON(body) {
+		renderer = RenderJSON
+	}
+
+	if renderer != nil {
+		if rendered, err := renderer(body, mediaType, colored); err == nil {
+			return rendered
+		}
+	}
+
+	if !utf8.Valid(body) {
+		rendered, _ := RenderHexDump(body, mediaType, colored)
+		return rendered
+	}
+
+	return string(body)
+}
+
+func paint(c *color.Color, s string, colored bool) string {
+	if colored {
+		return c.Sprint(s)
+	}
+
+	return s
+}
+
+// RenderJSON indents a JSON body, keeping the order of object keys. Keys, strings,
+// numbers and literals are colored when colored is set.
+func RenderJSON(body []byte, _ string, colored bool) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var sb strings.Builder
+	// containers holds a flag per open container: true for an object.
+	var containers []bool
+	// first is set when the next value is the first one of its container.
+	first := false
+	// key is set when the next token of an object is a key.
+	key := false
+
+	newline := func() {
+		sb.WriteString("\n")
+		sb.WriteString(strings.Repeat("  ", len(containers)))
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			containers = containers[:len(containers)-1]
+			if !first {
+				newline()
+			}
+			sb.WriteString(delim.String())
+			first = false
+			key = len(containers) > 0 && containers[len(containers)-1]
+			continue
+		}
+
+		inObject := len(containers) > 0 && containers[len(containers)-1]
+		switch {
+		case len(containers) == 0 && sb.Len() > 0:
+			// A stream of several values, such as newline-delimited JSON.
+			sb.WriteString("\n")
+		case len(containers) > 0 && (!inObject || key):
+			if !first {
+				sb.WriteString(",")
+			}
+			newline()
+		}
+		first = false
+
+		switch value := token.(type) {
+		case json.Delim:
+			sb.WriteString(value.String())
+			containers = append(containers, value == '{')
+			first = true
+			key = value == '{'
+			continue
+		case string:
+			quoted := jsonQuote(value)
+			if key {
+				sb.WriteString(paint(colorBodyKey, quoted, colored))
+				sb.WriteString(": ")
+				key = false
+				continue
+			}
+			sb.WriteString(paint(colorBodyString, quoted, colored))
+		case json.Number:
+			sb.WriteString(paint(colorBodyNumber, value.String(), colored))
+		case bool:
+			sb.WriteString(paint(colorBodyLiteral, fmt.Sprint(value), colored))
+		case nil:
+			sb.WriteString(paint(colorBodyLiteral, "null", colored))
+		}
+
+		key = inObject
+	}
+
+	if len(containers) > 0 {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	if sb.Len() == 0 {
+		return "", errors.New("empty JSON body")
+	}
+
+	return sb.String(), nil
+}
+
+// jsonQuote quotes a string as JSON, without escaping HTML characters.
+func jsonQuote(s string) string {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// RenderXML indents an XML body. Markup is colored when colored is set.
+func RenderXML(body []byte, _ string, colored bool) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+
+	var tokens []xml.Token
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if data, ok := token.(xml.CharData); ok && len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+
+		tokens = append(tokens, xml.CopyToken(token))
+	}
+
+	var sb strings.Builder
+	depth := 0
+
+	line := func(s string) {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(s)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch token := tokens[i].(type) {
+		case xml.StartElement:
+			tag := xmlStartTag(token)
+
+			// An element without children is written on one line.
+			if i+1 < len(tokens) {
+				if _, ok := tokens[i+1].(xml.EndElement); ok {
+					line(paint(colorBodyTag, strings.TrimSuffix(tag, ">")+"/>", colored))
+					i++
+					continue
+				}
+			}
+			if i+2 < len(tokens) {
+				data, isText := tokens[i+1].(xml.CharData)
+				end, isEnd := tokens[i+2].(xml.EndElement)
+				if isText && isEnd {
+					line(paint(colorBodyTag, tag, colored) + xmlText(data) + paint(colorBodyTag, xmlEndTag(end), colored))
+					i += 2
+					continue
+				}
+			}
+
+			line(paint(colorBodyTag, tag, colored))
+			depth++
+		case xml.EndElement:
+			depth = max(depth-1, 0)
+			line(paint(colorBodyTag, xmlEndTag(token), colored))
+		case xml.CharData:
+			line(xmlText(token))
+		case xml.Comment:
+			line(paint(colorBodyComment, "<!--"+string(token)+"-->", colored))
+		case xml.ProcInst:
+			line(paint(colorBodyTag, fmt.Sprintf("<?%s %s?>", token.Target, token.Inst), colored))
+		case xml.Directive:
+			line(paint(colorBodyTag, "<!"+string(token)+">", colored))
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "", errors.New("empty XML body")
+	}
+
+	return sb.String(), nil
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+
+	return name.Local
+}
+
+func xmlStartTag(element xml.StartElement) string {
+	var sb strings.Builder
+
+	sb.WriteString("<" + xmlName(element.Name))
+	for _, attr := range element.Attr {
+		sb.WriteString(" " + xmlName(attr.Name) + `="`)
+		xml.EscapeText(&sb, []byte(attr.Value))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(">")
+
+	return sb.String()
+}
+
+func xmlEndTag(element xml.EndElement) string {
+	return "</" + xmlName(element.Name) + ">"
+}
+
+func xmlText(data xml.CharData) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, bytes.TrimSpace(data))
+	return sb.String()
+}
+
+// htmlVoidElements are the HTML elements without an end tag.
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlPreformattedElements are the HTML elements whose whitespace is significant.
+var htmlPreformattedElements = map[string]bool{"pre": true, "textarea": true}
+
+type htmlToken struct {
+	tokenType html.TokenType
+	raw       string
+	name      string
+}
+
+// RenderHTML indents an HTML body, a tag or a text per line, keeping an element with
+// only a text on one line. The content of pre, script, style and textarea elements
+// is kept as it is. Markup is colored when colored is set.
+func RenderHTML(body []byte, _ string, colored bool) (string, error) {
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+
+	var tokens []htmlToken
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			break
+		}
+
+		token := htmlToken{tokenType: tokenType, raw: string(tokenizer.Raw())}
+		if name, _ := tokenizer.TagName(); len(name) > 0 {
+			token.name = string(name)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	var sb strings.Builder
+	depth := 0
+	// verbatim is the name of the open element whose content is kept as it is.
+	verbatim := ""
+
+	line := func(s string) {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(s)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		switch token.tokenType {
+		case html.StartTagToken:
+			if htmlVoidElements[token.name] {
+				line(paint(colorBodyTag, token.raw, colored))
+				continue
+			}
+
+			if i+2 < len(tokens) && tokens[i+1].tokenType == html.TextToken &&
+				tokens[i+2].tokenType == html.EndTagToken && tokens[i+2].name == token.name {
+				text := tokens[i+1].raw
+				if !htmlPreformattedElements[token.name] {
+					text = strings.TrimSpace(text)
+				}
+
+				if !strings.Contains(text, "\n") {
+					line(paint(colorBodyTag, token.raw, colored) + text + paint(colorBodyTag, tokens[i+2].raw, colored))
+					i += 2
+					continue
+				}
+			}
+
+			line(paint(colorBodyTag, token.raw, colored))
+			depth++
+
+			switch token.name {
+			case "pre", "script", "style", "textarea":
+				verbatim = token.name
+			}
+		case html.EndTagToken:
+			if htmlVoidElements[token.name] {
+				line(paint(colorBodyTag, token.raw, colored))
+				continue
+			}
+
+			if token.name == verbatim {
+				verbatim = ""
+			}
+			depth = max(depth-1, 0)
+			line(paint(colorBodyTag, token.raw, colored))
+		case html.SelfClosingTagToken, html.DoctypeToken:
+			line(paint(colorBodyTag, token.raw, colored))
+		case html.CommentToken:
+			line(paint(colorBodyComment, token.raw, colored))
+		case html.TextToken:
+			if verbatim != "" {
+				if text := strings.Trim(token.raw, "\r\n"); strings.TrimSpace(text) != "" {
+					sb.WriteString("\n")
+					sb.WriteString(text)
+				}
+				continue
+			}
+
+			if text := strings.Join(strings.Fields(token.raw), " "); text != "" {
+				line(text)
+			}
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "", errors.New("empty HTML body")
+	}
+
+	return sb.String(), nil
+}
+
+// RenderYAML re-indents a YAML body, keeping its comments. Every document of a
+// multi-document stream is rendered.
+func RenderYAML(body []byte, _ string, _ bool) (string, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(body))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if err := encoder.Encode(&document); err != nil {
+			return "", err
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	if buf.Len() == 0 {
+		return "", errors.New("empty YAML body")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// RenderForm decodes a form-encoded body into a field per line, in the order of
+// the body. Field names are colored when colored is set.
+func RenderForm(body []byte, _ string, colored bool) (string, error) {
+	var lines []string
+
+	for _, field := range strings.Split(strings.TrimSpace(string(body)), "&") {
+		if field == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(field, "=")
+
+		name, err := url.QueryUnescape(name)
+		if err != nil {
+			return "", err
+		}
+
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			return "", err
+		}
+
+		lines = append(lines, paint(colorBodyKey, name, colored)+": "+value)
+	}
+
+	if len(lines) == 0 {
+		return "", errors.New("empty form body")
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// RenderImage describes an image by its size, type and dimensions, e.g.
+// "2048 bytes of image/png, 64x64". The dimensions are known for PNG, JPEG and GIF.
+func RenderImage(body []byte, mediaType string, colored bool) (string, error) {
+	summary, _ := RenderSize(body, mediaType, colored)
+
+	if config, _, err := image.DecodeConfig(bytes.NewReader(body)); err == nil {
+		summary += fmt.Sprintf(", %dx%d", config.Width, config.Height)
+	}
+
+	return summary, nil
+}
+
+// RenderSize describes a body by its size and type, e.g. "2048 bytes of application/pdf".
+func RenderSize(body []byte, mediaType string, _ bool) (string, error) {
+	return fmt.Sprintf("%d bytes of %s", len(body), mediaType), nil
+}
+
+// RenderHexDump renders a binary body as a hexdump of its first MaxHexDumpSize bytes.
+func RenderHexDump(body []byte, mediaType string, _ bool) (string, error) {
+	summary := fmt.Sprintf("%d bytes of %s", len(body), mediaType)
+
+	shown := body
+	if len(shown) > MaxHexDumpSize {
+		shown = shown[:MaxHexDumpSize]
+		summary += fmt.Sprintf(", first %d shown", MaxHexDumpSize)
+	}
+
+	return summary + "\n" + strings.TrimSuffix(hex.Dump(shown), "\n"), nil
+}
+
+// headerValue returns the first value of a header, matching its name case-insensitively.
+func headerValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,264 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+	"github.com/fatih/color"
+)
+
+func TestUnitRenderJSON(t *testing.T) {
+	got, err := RenderJSON([]byte(`{"b":1,"a":[true,{"x":null,"y":[]},{}],"s":"<a&b>"}`), "application/json", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{
+  "b": 1,
+  "a": [
+    true,
+    {
+      "x": null,
+      "y": []
+    },
+    {}
+  ],
+  "s": "<a&b>"
+}`
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestUnitRenderJSON_Colored(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	got, err := RenderJSON([]byte(`{"a":"b","n":1}`), "application/json", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, s := range []string{colorBodyKey.Sprint(`"a"`), colorBodyString.Sprint(`"b"`), colorBodyNumber.Sprint("1")} {
+		if !strings.Contains(got, s) {
+			t.Errorf("expected %q in %q", s, got)
+		}
+	}
+}
+
+func TestUnitRenderJSON_Stream(t *testing.T) {
+	got, err := RenderJSON([]byte("{\"a\":1}\n{\"a\":2}\n"), "application/x-ndjson", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != "{\n  \"a\": 1\n}\n{\n  \"a\": 2\n}" {
+		t.Errorf("unexpected stream rendering:\n%s", got)
+	}
+}
+
+func TestUnitRenderJSON_Invalid(t *testing.T) {
+	if _, err := RenderJSON([]byte(`{"a":`), "application/json", false); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestUnitRenderXML(t *testing.T) {
+	body := `<?xml version="1.0"?><root a="1"><!-- note --><item>x &amp; y</item><empty></empty><ns:n xmlns:ns="urn:x"><c>1</c></ns:n></root>`
+
+	got, err := RenderXML([]byte(body), "application/xml", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `<?xml version="1.0"?>
+<root a="1">
+  <!-- note -->
+  <item>x &amp; y</item>
+  <empty/>
+  <ns:n xmlns:ns="urn:x">
+    <c>1</c>
+  </ns:n>
+</root>`
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestUnitRenderHTML(t *testing.T) {
+	body := "<!DOCTYPE html><html><head><title>T</title></head><body><p>Hello <b>world</b><br>!</p><pre>\n  keep\n</pre></body></html>"
+
+	got, err := RenderHTML([]byte(body), "text/html", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `<!DOCTYPE html>
+<html>
+  <head>
+    <title>T</title>
+  </head>
+  <body>
+    <p>
+      Hello
+      <b>world</b>
+      <br>
+      !
+    </p>
+    <pre>
+  keep
+    </pre>
+  </body>
+</html>`
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestUnitRenderYAML(t *testing.T) {
+	got, err := RenderYAML([]byte("a:   1\nb:\n    - x # note\n---\nc: 2\n"), "application/yaml", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != "a: 1\nb:\n  - x # note\n---\nc: 2" {
+		t.Errorf("unexpected YAML rendering:\n%s", got)
+	}
+
+	if _, err := RenderYAML([]byte("a: [1"), "application/yaml", false); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestUnitRenderForm(t *testing.T) {
+	got, err := RenderForm([]byte("name=John+Doe&q=a%26b&empty="), "application/x-www-form-urlencoded", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != "name: John Doe\nq: a&b\nempty: " {
+		t.Errorf("unexpected form rendering:\n%s", got)
+	}
+}
+
+func TestUnitRenderImage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3, 2))); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	got := FormatBody(buf.Bytes(), "image/png")
+	if expected := fmt.Sprintf("%d bytes of image/png, 3x2", buf.Len()); got != expected {
+		t.Errorf("unexpected image rendering %q", got)
+	}
+
+	if got := FormatBody([]byte{0xff, 0xd8, 0xff, 0x00}, "image/webp"); got != "4 bytes of image/webp" {
+		t.Errorf("unexpected rendering of an unknown image %q", got)
+	}
+}
+
+func TestUnitFormatBody_Binary(t *testing.T) {
+	got := FormatBody([]byte{0x00, 0x01, 0xff, 0xfe}, "")
+	if !strings.HasPrefix(got, "4 bytes of application/octet-stream\n00000000  00 01 ff fe") {
+		t.Errorf("expected a hexdump, got:\n%s", got)
+	}
+
+	got = FormatBody(bytes.Repeat([]byte{0xff}, MaxHexDumpSize+1), "application/x-custom")
+	if !strings.HasPrefix(got, "513 bytes of application/x-custom, first 512 shown\n") {
+		t.Errorf("expected a truncated hexdump, got:\n%s", got[:80])
+	}
+	if strings.Count(got, "\n") != MaxHexDumpSize/16 {
+		t.Errorf("expected %d hexdump lines, got %d", MaxHexDumpSize/16, strings.Count(got, "\n"))
+	}
+}
+
+func TestUnitFormatBody_ContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		expected    string
+	}{
+		{"json detected", `{"a":1}`, "", "{\n  \"a\": 1\n}"},
+		{"json as text", `{"a":1}`, "text/plain", "{\n  \"a\": 1\n}"},
+		{"json suffix", `{"a":1}`, "application/problem+json; charset=utf-8", "{\n  \"a\": 1\n}"},
+		{"xml suffix", `<a><b/></a>`, "application/atom+xml", "<a>\n  <b/>\n</a>"},
+		{"invalid json", `{"a":`, "application/json", `{"a":`},
+		{"text", "plain text", "text/plain", "plain text"},
+		{"invalid content type", `{"a":1}`, ";;", "{\n  \"a\": 1\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatBody([]byte(tt.body), tt.contentType); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnitRegisterBodyRenderer(t *testing.T) {
+	upper := func(body []byte, _ string, _ bool) (string, error) {
+		return strings.ToUpper(string(body)), nil
+	}
+	failing := func([]byte, string, bool) (string, error) {
+		return "", errors.New("unsupported")
+	}
+
+	RegisterBodyRenderer("text/*", upper)
+	RegisterBodyRenderer("Text/X-Failing", failing)
+	defer RegisterBodyRenderer("text/*", nil)
+	defer RegisterBodyRenderer("text/x-failing", nil)
+
+	if got := FormatBody([]byte("hello"), "text/plain"); got != "HELLO" {
+		t.Errorf("expected the wildcard renderer, got %q", got)
+	}
+	if got := FormatBody([]byte("<a/>"), "text/xml"); got != "<a/>" {
+		t.Errorf("expected the media type renderer to take precedence, got %q", got)
+	}
+	if got := FormatBody([]byte("hello"), "text/x-failing"); got != "hello" {
+		t.Errorf("expected the body as text when the renderer fails, got %q", got)
+	}
+
+	RegisterBodyRenderer("text/*", nil)
+	if got := FormatBody([]byte("hello"), "text/plain"); got != "hello" {
+		t.Errorf("expected the renderer to be removed, got %q", got)
+	}
+}
+
+func TestUnitFormatResponse_ContentType(t *testing.T) {
+	resp := &types.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Headers:    map[string][]string{"content-type": {"application/xml"}},
+		Body:       []byte("<a><b>1</b></a>"),
+		Duration:   time.Millisecond,
+		Size:       15,
+	}
+
+	if formatted := FormatResponse(resp); !strings.Contains(formatted, "<a>\n  <b>1</b>\n</a>\n") {
+		t.Errorf("expected an indented XML body, got:\n%s", formatted)
+	}
+}
+
+func TestUnitFormatRequest_FormBody(t *testing.T) {
+	req := &types.Request{
+		Method:  http.MethodPost,
+		URL:     "http://example.com",
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		Body:    &types.RequestBody{Type: "form", Content: []byte("a=1&b=x+y")},
+	}
+
+	if formatted := FormatRequest(req); !strings.Contains(formatted, "a: 1\nb: x y\n") {
+		t.Errorf("expected a decoded form body, got:\n%s", formatted)
+	}
+}
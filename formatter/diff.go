@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/diff"
+	"github.com/fatih/color"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	colorDiffAdded   = color.New(color.FgGreen)
+	colorDiffRemoved = color.New(color.FgRed)
+	colorDiffChanged = color.New(color.FgYellow)
+)
+
+// diffWriter writes a diff as text, colored when color is set.
+type diffWriter struct {
+	sb    strings.Builder
+	color bool
+}
+
+func (w *diffWriter) printf(c *color.Color, format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+
+	if w.color && c != nil {
+		text = c.Sprint(text)
+	}
+
+	w.sb.WriteString(text)
+}
+
+func kindColor(kind diff.Kind) *color.Color {
+	switch kind {
+	case diff.Added:
+		return colorDiffAdded
+	case diff.Removed:
+		return colorDiffRemoved
+	case diff.Changed:
+		return colorDiffChanged
+	default:
+		return nil
+	}
+}
+
+func kindMarker(kind diff.Kind) string {
+	switch kind {
+	case diff.Added:
+		return "+"
+	case diff.Removed:
+		return "-"
+	case diff.Changed:
+		return "~"
+	default:
+		return " "
+	}
+}
+
+// latencyDelta formats a duration change, e.g. "120ms -> 180ms (+60ms)".
+func latencyDelta(before, after time.Duration) string {
+	sign := "+"
+	if after < before {
+		sign = ""
+	}
+
+	return fmt.Sprintf("%v -> %v (%s%v)", before, after, sign, after-before)
+}
+
+func diffValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+func (w *diffWriter) writeResponse(d *diff.ResponseDiff, indent string) {
+	before := diffStatus(d.StatusBefore, d.GRPCCodeBefore)
+	after := diffStatus(d.StatusAfter, d.GRPCCodeAfter)
+
+	if d.StatusChanged() {
+		w.printf(colorDiffChanged, "%sStatus: %s -> %s\n", indent, before, after)
+	} else {
+		w.printf(nil, "%sStatus: %s\n", indent, after)
+	}
+
+	w.printf(nil, "%sLatency: %s\n", indent, latencyDelta(d.DurationBefore, d.DurationAfter))
+
+	if len(d.Headers) > 0 {
+		w.printf(nil, "%sHeaders:\n", indent)
+
+		for _, change := range d.Headers {
+			c := kindColor(change.Kind)
+			marker := kindMarker(change.Kind)
+
+			switch change.Kind {
+			case diff.Added:
+				w.printf(c, "%s  %s %s: %s\n", indent, marker, change.Name, strings.Join(change.After, ", "))
+			case diff.Removed:
+				w.printf(c, "%s  %s %s: %s\n", indent, marker, change.Name, strings.Join(change.Before, ", "))
+			default:
+				w.printf(c, "%s  %s %s: %s -> %s\n", indent, marker, change.Name,
+					strings.Join(change.Before, ", "), strings.Join(change.After, ", "))
+			}
+		}
+	}
+
+	if len(d.Body) > 0 {
+		w.printf(nil, "%sBody:\n", indent)
+
+		for _, change := range d.Body {
+			c := kindColor(change.Kind)
+			marker := kindMarker(change.Kind)
+
+			switch change.Kind {
+			case diff.Added:
+				w.printf(c, "%s  %s %s: %s\n", indent, marker, change.Path, diffValue(change.After))
+			case diff.Removed:
+				w.printf(c, "%s  %s %s: %s\n", indent, marker, change.Path, diffValue(change.Before))
+			default:
+				w.printf(c, "%s  %s %s: %s -> %s\n", indent, marker, change.Path, diffValue(change.Before), diffValue(change.After))
+			}
+		}
+	}
+}
+
+func (w *diffWriter) writeResult(d *diff.ResultDiff) {
+	for _, item := range d.Items {
+		w.printf(kindColor(item.Kind), "%s %s [%s]\n", kindMarker(item.Kind), item.Name, item.Kind)
+
+		if item.ErrorBefore != item.ErrorAfter {
+			w.printf(colorDiffChanged, "    Error: %q -> %q\n", item.ErrorBefore, item.ErrorAfter)
+		} else if item.ErrorAfter != "" {
+			w.printf(nil, "    Error: %s\n", item.ErrorAfter)
+		}
+
+		switch {
+		case item.Response != nil:
+			w.writeResponse(item.Response, "    ")
+		case item.Kind != diff.Added && item.Kind != diff.Removed:
+			w.printf(nil, "    Latency: %s\n", latencyDelta(item.DurationBefore, item.DurationAfter))
+		}
+	}
+
+	w.printf(nil, "\nTotal Duration: %s\n", latencyDelta(d.DurationBefore, d.DurationAfter))
+}
+
+// FormatResponseDiff formats the difference between two responses as text.
+func FormatResponseDiff(d *diff.ResponseDiff) string {
+	w := &diffWriter{}
+	w.writeResponse(d, "")
+	return w.sb.String()
+}
+
+// PrintResponseDiff prints the difference between two responses to stdout, with
+// additions in green, removals in red and changes in yellow.
+func PrintResponseDiff(d *diff.ResponseDiff) {
+	w := &diffWriter{color: true}
+	w.writeResponse(d, "")
+	fmt.Print(w.sb.String())
+}
+
+// FormatResultDiff formats the difference between two execution results as text.
+func FormatResultDiff(d *diff.ResultDiff) string {
+	w := &diffWriter{}
+	w.writeResult(d)
+	return w.sb.String()
+}
+
+// PrintResultDiff prints the difference between two execution results to stdout,
+// with additions in green, removals in red and changes in yellow.
+func PrintResultDiff(d *diff.ResultDiff) {
+	w := &diffWriter{color: true}
+	w.writeResult(d)
+	fmt.Print(w.sb.String())
+}
+
+// diffStatus returns a status of a response diff for display: the gRPC status of a
+// gRPC call, the HTTP status code otherwise.
+func diffStatus(statusCode int, grpcCode *int) string {
+	if grpcCode != nil {
+		return codes.Code(*grpcCode).String()
+	}
+
+	return fmt.Sprint(statusCode)
+}
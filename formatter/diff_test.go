@@ -0,0 +1,75 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/diff"
+)
+
+func TestUnitFormatResponseDiff(t *testing.T) {
+	d := &diff.ResponseDiff{
+		StatusBefore:   200,
+		StatusAfter:    500,
+		DurationBefore: 100 * time.Millisecond,
+		DurationAfter:  150 * time.Millisecond,
+		Headers: []diff.HeaderChange{
+			{Name: "X-New", Kind: diff.Added, After: []string{"1"}},
+		},
+		Body: []diff.Change{
+			{Path: "$.name", Kind: diff.Changed, Before: "a", After: "b"},
+			{Path: "$.gone", Kind: diff.Removed, Before: true},
+		},
+	}
+
+	formatted := FormatResponseDiff(d)
+
+	for _, expected := range []string{
+		"Status: 200 -> 500",
+		"Latency: 100ms -> 150ms (+50ms)",
+		"+ X-New: 1",
+		`~ $.name: "a" -> "b"`,
+		"- $.gone: true",
+	} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("expected %q in:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestUnitFormatResponseDiff_GRPC(t *testing.T) {
+	ok, unavailable := 0, 14
+	d := &diff.ResponseDiff{GRPCCodeBefore: &ok, GRPCCodeAfter: &unavailable}
+
+	formatted := FormatResponseDiff(d)
+	if !strings.Contains(formatted, "Status: OK -> Unavailable") {
+		t.Errorf("expected the gRPC status change in:\n%s", formatted)
+	}
+}
+
+func TestUnitFormatResultDiff(t *testing.T) {
+	d := &diff.ResultDiff{
+		DurationBefore: 2 * time.Second,
+		DurationAfter:  time.Second,
+		Items: []*diff.ItemDiff{
+			{Name: "GET /users", Kind: diff.Unchanged, Response: &diff.ResponseDiff{StatusBefore: 200, StatusAfter: 200}},
+			{Name: "GET /orders", Kind: diff.Changed, ErrorAfter: "timeout"},
+			{Name: "POST /users", Kind: diff.Added},
+		},
+	}
+
+	formatted := FormatResultDiff(d)
+
+	for _, expected := range []string{
+		"  GET /users [unchanged]",
+		"~ GET /orders [changed]",
+		`Error: "" -> "timeout"`,
+		"+ POST /users [added]",
+		"Total Duration: 2s -> 1s (-1s)",
+	} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("expected %q in:\n%s", expected, formatted)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// FormatEvents formats captured Server-Sent Events as a string for display.
+func FormatEvents(events []*types.SSEEvent) string {
+	var sb strings.Builder
+
+	for i, event := range events {
+		sb.WriteString(fmt.Sprintf("  [%d] event: %s", i+1, event.Event))
+
+		if event.ID != "" {
+			sb.WriteString(fmt.Sprintf(" id: %s", event.ID))
+		}
+
+		sb.WriteString("\n")
+
+		for _, line := range strings.Split(event.Data, "\n") {
+			sb.WriteString(fmt.Sprintf("      %s\n", line))
+		}
+	}
+
+	return sb.String()
+}
+
+// PrintEvents prints captured Server-Sent Events to stdout with color coding.
+func PrintEvents(events []*types.SSEEvent) {
+	for i, event := range events {
+		fmt.Printf("  [%d] ", i+1)
+		colorFgCyan.Printf("event: %s", event.Event)
+
+		if event.ID != "" {
+			fmt.Printf(" id: %s", event.ID)
+		}
+
+		fmt.Println()
+
+		for _, line := range strings.Split(event.Data, "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitFormatEvents(t *testing.T) {
+	events := []*types.SSEEvent{
+		{ID: "1", Event: "update", Data: "line1\nline2"},
+		{Event: "message", Data: "plain"},
+	}
+
+	formatted := FormatEvents(events)
+
+	for _, expected := range []string{"[1] event: update id: 1", "line1", "line2", "[2] event: message", "plain"} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("expected formatted events to contain %q, got:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestUnitFormatExecutionResult_WithEvents(t *testing.T) {
+	result := &types.ExecutionResult{
+		CollectionName: "test",
+		Requests: []*types.RequestExecution{
+			{
+				Request:  &types.Request{Method: "GET", URL: "http://example.com/sse"},
+				Response: &types.Response{StatusCode: 200},
+				Events:   []*types.SSEEvent{{Event: "message", Data: "hello"}},
+			},
+		},
+	}
+
+	formatted := FormatExecutionResult(result)
+	if !strings.Contains(formatted, "Events: 1") {
+		t.Errorf("expected formatted result to contain events, got:\n%s", formatted)
+	}
+}
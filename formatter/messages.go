@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KonnorFrik/getman/types"
+	"github.com/fatih/color"
+)
+
+const (
+	messageSentArrow     = ">>"
+	messageReceivedArrow = "<<"
+)
+
+func messageArrow(message *types.WebSocketMessage) string {
+	if message.Direction == types.WebSocketSent {
+		return messageSentArrow
+	}
+
+	return messageReceivedArrow
+}
+
+func messageData(message *types.WebSocketMessage) string {
+	if message.Binary {
+		return fmt.Sprintf("[binary, %d bytes]", len(message.Data))
+	}
+
+	return message.Data
+}
+
+// FormatMessages formats a WebSocket transcript as a string for display.
+func FormatMessages(messages []*types.WebSocketMessage) string {
+	var sb strings.Builder
+
+	for i, message := range messages {
+		sb.WriteString(fmt.Sprintf("  [%d] %s %s\n", i+1, messageArrow(message), messageData(message)))
+	}
+
+	return sb.String()
+}
+
+// PrintMessages prints a WebSocket transcript to stdout with color coding.
+func PrintMessages(messages []*types.WebSocketMessage) {
+	for i, message := range messages {
+		arrowColor := color.New(color.FgGreen)
+		if message.Direction == types.WebSocketSent {
+			arrowColor = colorFgCyan
+		}
+
+		fmt.Printf("  [%d] ", i+1)
+		arrowColor.Print(messageArrow(message))
+		fmt.Printf(" %s\n", messageData(message))
+	}
+}
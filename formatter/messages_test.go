@@ -0,0 +1,42 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitFormatMessages(t *testing.T) {
+	messages := []*types.WebSocketMessage{
+		{Direction: types.WebSocketSent, Data: "hello"},
+		{Direction: types.WebSocketReceived, Data: "world"},
+		{Direction: types.WebSocketReceived, Binary: true, Data: "\x00\x01\x02"},
+	}
+
+	formatted := FormatMessages(messages)
+
+	for _, expected := range []string{"[1] >> hello", "[2] << world", "[3] << [binary, 3 bytes]"} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("expected formatted messages to contain %q, got:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestUnitFormatExecutionResult_WithMessages(t *testing.T) {
+	result := &types.ExecutionResult{
+		CollectionName: "test",
+		Requests: []*types.RequestExecution{
+			{
+				Request:  &types.Request{URL: "ws://example.com/socket"},
+				Error:    "request failed: step 1: expected reply \"pong\" not received within 1s",
+				Messages: []*types.WebSocketMessage{{Direction: types.WebSocketSent, Data: "ping"}},
+			},
+		},
+	}
+
+	formatted := FormatExecutionResult(result)
+	if !strings.Contains(formatted, "Messages: 1") || !strings.Contains(formatted, ">> ping") {
+		t.Errorf("expected formatted result to contain the transcript, got:\n%s", formatted)
+	}
+}
@@ -11,16 +11,30 @@ import (
 
 	"github.com/KonnorFrik/getman/types"
 	"github.com/fatih/color"
+	"google.golang.org/grpc/codes"
 )
 
 // FormatResponse formats a response as a string for display.
 func FormatResponse(resp *types.Response) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("Status: %d %s\n", resp.StatusCode, resp.Status))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", responseStatus(resp)))
 	sb.WriteString(fmt.Sprintf("Duration: %v\n", resp.Duration))
 	sb.WriteString(fmt.Sprintf("Size: %d bytes\n", resp.Size))
 
+	if resp.Truncated {
+		sb.WriteString(fmt.Sprintf("Body truncated: showing %d of %d bytes\n", len(resp.Body), resp.Size))
+	}
+
+	if resp.BodyFile != "" {
+		sb.WriteString(fmt.Sprintf("Body saved to: %s\n", resp.BodyFile))
+	}
+
+	if resp.Timings != nil {
+		sb.WriteString("\nTimings:\n")
+		sb.WriteString(FormatTimings(resp.Timings))
+	}
+
 	if len(resp.Headers) > 0 {
 		sb.WriteString("\nHeaders:\n")
 		for k, v := range resp.Headers {
@@ -30,47 +44,39 @@ func FormatResponse(resp *types.Response) string {
 
 	if len(resp.Body) > 0 {
 		sb.WriteString("\nBody:\n")
-		var bodyStr string
-		if isJSON(resp.Body) {
-			var jsonObj any
-			if err := json.Unmarshal(resp.Body, &jsonObj); err == nil {
-				prettyJSON, err := json.MarshalIndent(jsonObj, "", "  ")
-				if err == nil {
-					bodyStr = string(prettyJSON)
-				} else {
-					bodyStr = string(resp.Body)
-				}
-			} else {
-				bodyStr = string(resp.Body)
-			}
-		} else {
-			bodyStr = string(resp.Body)
-		}
-		sb.WriteString(bodyStr)
+		sb.WriteString(FormatBody(resp.Body, headerValue(resp.Headers, "Content-Type")))
 		sb.WriteString("\n")
 	}
 
+	if len(resp.Trailers) > 0 {
+		sb.WriteString("\nTrailers:\n")
+		for k, v := range resp.Trailers {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", k, strings.Join(v, ", ")))
+		}
+	}
+
 	return sb.String()
 }
 
 // PrintResponse prints a formatted response to stdout with color coding.
 func PrintResponse(resp *types.Response) {
-	var statusColor *color.Color
-	switch {
-	case resp.StatusCode >= 200 && resp.StatusCode < 300:
-		statusColor = color.New(color.FgGreen)
-	case resp.StatusCode >= 300 && resp.StatusCode < 400:
-		statusColor = color.New(color.FgYellow)
-	case resp.StatusCode >= 400 && resp.StatusCode < 500:
-		statusColor = color.New(color.FgRed)
-	default:
-		statusColor = color.New(color.FgMagenta)
-	}
-
-	statusColor.Printf("Status: %d %s\n", resp.StatusCode, resp.Status)
+	statusColor(resp).Printf("Status: %s\n", responseStatus(resp))
 	fmt.Printf("Duration: %v\n", resp.Duration)
 	fmt.Printf("Size: %d bytes\n", resp.Size)
 
+	if resp.Truncated {
+		color.Yellow("Body truncated: showing %d of %d bytes\n", len(resp.Body), resp.Size)
+	}
+
+	if resp.BodyFile != "" {
+		fmt.Printf("Body saved to: %s\n", resp.BodyFile)
+	}
+
+	if resp.Timings != nil {
+		fmt.Println("\nTimings:")
+		PrintTimings(resp.Timings)
+	}
+
 	if len(resp.Headers) > 0 {
 		fmt.Println("\nHeaders:")
 		for k, v := range resp.Headers {
@@ -80,23 +86,14 @@ func PrintResponse(resp *types.Response) {
 
 	if len(resp.Body) > 0 {
 		fmt.Println("\nBody:")
-		var bodyStr string
-		if isJSON(resp.Body) {
-			var jsonObj any
-			if err := json.Unmarshal(resp.Body, &jsonObj); err == nil {
-				prettyJSON, err := json.MarshalIndent(jsonObj, "", "  ")
-				if err == nil {
-					bodyStr = string(prettyJSON)
-				} else {
-					bodyStr = string(resp.Body)
-				}
-			} else {
-				bodyStr = string(resp.Body)
-			}
-		} else {
-			bodyStr = string(resp.Body)
+		fmt.Println(renderBody(resp.Body, headerValue(resp.Headers, "Content-Type"), true))
+	}
+
+	if len(resp.Trailers) > 0 {
+		fmt.Println("\nTrailers:")
+		for k, v := range resp.Trailers {
+			fmt.Printf("  %s: %s\n", k, strings.Join(v, ", "))
 		}
-		fmt.Println(bodyStr)
 	}
 }
 
@@ -104,3 +101,35 @@ func isJSON(data []byte) bool {
 	var js interface{}
 	return json.Unmarshal(data, &js) == nil
 }
+
+// responseStatus returns the status of a response for display: the gRPC status of
+// a gRPC call, the HTTP status code and text otherwise.
+func responseStatus(resp *types.Response) string {
+	if resp.GRPCCode != nil {
+		return resp.Status
+	}
+
+	return fmt.Sprintf("%d %s", resp.StatusCode, resp.Status)
+}
+
+// statusColor returns the color of the status of a response.
+func statusColor(resp *types.Response) *color.Color {
+	if resp.GRPCCode != nil {
+		if *resp.GRPCCode == int(codes.OK) {
+			return color.New(color.FgGreen)
+		}
+
+		return color.New(color.FgRed)
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return color.New(color.FgGreen)
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return color.New(color.FgYellow)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return color.New(color.FgRed)
+	default:
+		return color.New(color.FgMagenta)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/KonnorFrik/getman/types"
+	"github.com/fatih/color"
 )
 
 func TestUnitFormatResponse_Simple(t *testing.T) {
@@ -130,3 +131,73 @@ func TestUnitIsJSON(t *testing.T) {
 	}
 }
 
+
+func TestUnitFormatResponse_Truncated(t *testing.T) {
+	resp := &types.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       []byte("abc"),
+		Size:       1000,
+		Truncated:  true,
+	}
+
+	formatted := FormatResponse(resp)
+	if !strings.Contains(formatted, "showing 3 of 1000 bytes") {
+		t.Errorf("expected truncation marker, got:\n%s", formatted)
+	}
+}
+
+func TestUnitFormatResponse_BodyFile(t *testing.T) {
+	resp := &types.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Size:       1000,
+		BodyFile:   "/tmp/download.bin",
+	}
+
+	formatted := FormatResponse(resp)
+	if !strings.Contains(formatted, "Body saved to: /tmp/download.bin") {
+		t.Errorf("expected body file path, got:\n%s", formatted)
+	}
+}
+
+func TestUnitFormatResponse_Trailers(t *testing.T) {
+	ok := 0
+	resp := &types.Response{
+		GRPCCode: &ok,
+		Status:   "OK",
+		Body:     []byte(`{"message": "hi"}`),
+		Trailers: map[string][]string{"x-trailer": {"done"}},
+	}
+
+	formatted := FormatResponse(resp)
+	if !strings.Contains(formatted, "Trailers:\n  x-trailer: done") {
+		t.Errorf("expected trailers, got:\n%s", formatted)
+	}
+}
+
+func TestUnitFormatResponse_GRPC(t *testing.T) {
+	notFound := 5
+	resp := &types.Response{GRPCCode: &notFound, Status: "NotFound: no such greeting"}
+
+	formatted := FormatResponse(resp)
+	if !strings.Contains(formatted, "Status: NotFound: no such greeting\n") {
+		t.Errorf("expected the gRPC status, got:\n%s", formatted)
+	}
+
+	if strings.Contains(formatted, "Status: 0") {
+		t.Errorf("expected no HTTP status code, got:\n%s", formatted)
+	}
+}
+
+func TestUnitStatusColor_GRPC(t *testing.T) {
+	ok, unavailable := 0, 14
+
+	if got := statusColor(&types.Response{GRPCCode: &ok}); !got.Equals(color.New(color.FgGreen)) {
+		t.Error("expected a successful gRPC call to be green")
+	}
+
+	if got := statusColor(&types.Response{GRPCCode: &unavailable}); !got.Equals(color.New(color.FgRed)) {
+		t.Error("expected a failed gRPC call to be red")
+	}
+}
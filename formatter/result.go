@@ -4,11 +4,11 @@ Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor
 package formatter
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/KonnorFrik/getman/codegen"
 	"github.com/KonnorFrik/getman/types"
 	"github.com/fatih/color"
 )
@@ -47,23 +47,7 @@ func FormatRequest(req *types.Request) string {
 
 	if req.Body != nil && len(req.Body.Content) > 0 {
 		sb.WriteString("\nBody:\n")
-		var bodyStr string
-		if isJSON(req.Body.Content) {
-			var jsonObj any
-			if err := json.Unmarshal(req.Body.Content, &jsonObj); err == nil {
-				prettyJSON, err := json.MarshalIndent(jsonObj, "", "  ")
-				if err == nil {
-					bodyStr = string(prettyJSON)
-				} else {
-					bodyStr = string(req.Body.Content)
-				}
-			} else {
-				bodyStr = string(req.Body.Content)
-			}
-		} else {
-			bodyStr = string(req.Body.Content)
-		}
-		sb.WriteString(bodyStr)
+		sb.WriteString(FormatBody(req.Body.Content, requestContentType(req)))
 		sb.WriteString("\n")
 	}
 
@@ -97,23 +81,7 @@ func PrintRequest(req *types.Request) {
 
 	if req.Body != nil && len(req.Body.Content) > 0 {
 		fmt.Println("\nBody:")
-		var bodyStr string
-		if isJSON(req.Body.Content) {
-			var jsonObj interface{}
-			if err := json.Unmarshal(req.Body.Content, &jsonObj); err == nil {
-				prettyJSON, err := json.MarshalIndent(jsonObj, "", "  ")
-				if err == nil {
-					bodyStr = string(prettyJSON)
-				} else {
-					bodyStr = string(req.Body.Content)
-				}
-			} else {
-				bodyStr = string(req.Body.Content)
-			}
-		} else {
-			bodyStr = string(req.Body.Content)
-		}
-		fmt.Println(bodyStr)
+		fmt.Println(renderBody(req.Body.Content, requestContentType(req), true))
 	}
 }
 
@@ -138,9 +106,23 @@ func FormatExecutionResult(result *types.ExecutionResult) string {
 		if req.Error != "" {
 			sb.WriteString(fmt.Sprintf("   Error: %s\n", req.Error))
 		} else if req.Response != nil {
-			sb.WriteString(fmt.Sprintf("   Status: %d\n", req.Response.StatusCode))
+			sb.WriteString(fmt.Sprintf("   Status: %s\n", executionStatus(req.Response)))
 			sb.WriteString(fmt.Sprintf("   Duration: %v\n", req.Duration))
 		}
+
+		if req.Snapshot != nil {
+			sb.WriteString(FormatSnapshotResult(req.Snapshot))
+		}
+
+		if len(req.Events) > 0 {
+			sb.WriteString(fmt.Sprintf("   Events: %d\n", len(req.Events)))
+			sb.WriteString(FormatEvents(req.Events))
+		}
+
+		if len(req.Messages) > 0 {
+			sb.WriteString(fmt.Sprintf("   Messages: %d\n", len(req.Messages)))
+			sb.WriteString(FormatMessages(req.Messages))
+		}
 	}
 
 	return sb.String()
@@ -153,25 +135,19 @@ func PrintRequestExecution(req *types.RequestExecution, index int) {
 	if req.Error != "" {
 		color.Red("   Error: %s\n", req.Error)
 
-	} else if req.Response != nil {
-		var statusColor *color.Color
-
-		switch {
-		case req.Response.StatusCode >= 200 && req.Response.StatusCode < 300:
-			statusColor = color.New(color.FgGreen)
-
-		case req.Response.StatusCode >= 300 && req.Response.StatusCode < 400:
-			statusColor = color.New(color.FgYellow)
+		if len(req.Messages) > 0 {
+			colorFgMagneta.Printf("   Messages:\n")
+			PrintMessages(req.Messages)
+		}
 
-		case req.Response.StatusCode >= 400 && req.Response.StatusCode < 500:
-			statusColor = color.New(color.FgRed)
+	} else if req.Response != nil {
+		statusColor(req.Response).Printf("   Status: %s\n", executionStatus(req.Response))
+		fmt.Printf("   Duration: %v\n", req.Duration)
 
-		default:
-			statusColor = color.New(color.FgMagenta)
+		if req.Snapshot != nil {
+			PrintSnapshotResult(req.Snapshot)
 		}
 
-		statusColor.Printf("   Status: %d\n", req.Response.StatusCode)
-		fmt.Printf("   Duration: %v\n", req.Duration)
 		colorFgMagneta.Printf("   Headers:\n")
 
 		for k, v := range req.Response.Headers {
@@ -179,6 +155,18 @@ func PrintRequestExecution(req *types.RequestExecution, index int) {
 			fmt.Printf(": %s\n", strings.Join(v, " "))
 		}
 
+		if len(req.Events) > 0 {
+			colorFgMagneta.Printf("   Events:\n")
+			PrintEvents(req.Events)
+			return
+		}
+
+		if len(req.Messages) > 0 {
+			colorFgMagneta.Printf("   Messages:\n")
+			PrintMessages(req.Messages)
+			return
+		}
+
 		colorFgMagneta.Printf("   Body:\n")
 		fmt.Printf("%s\n", string(req.Response.Body))
 	}
@@ -206,6 +194,43 @@ func PrintExecutionResult(result *types.ExecutionResult) {
 
 }
 
+// FormatSnapshotResult formats the snapshot check of a request execution as a string for display.
+func FormatSnapshotResult(snapshot *types.SnapshotResult) string {
+	var sb strings.Builder
+
+	if snapshot.Error != "" {
+		sb.WriteString(fmt.Sprintf("   Snapshot: error: %s\n", snapshot.Error))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("   Snapshot: %s (%s)\n", snapshot.Status, snapshot.Path))
+
+	for _, mismatch := range snapshot.Mismatches {
+		sb.WriteString(fmt.Sprintf("     %s %s: %s -> %s\n", mismatch.Kind, mismatch.Path, mismatch.Expected, mismatch.Actual))
+	}
+
+	return sb.String()
+}
+
+// PrintSnapshotResult prints the snapshot check of a request execution to stdout, with
+// matches in green, mismatches and errors in red and recorded snapshots in yellow.
+func PrintSnapshotResult(snapshot *types.SnapshotResult) {
+	var statusColor *color.Color
+
+	switch {
+	case snapshot.Error != "" || snapshot.Status == types.SnapshotMismatched:
+		statusColor = color.New(color.FgRed)
+
+	case snapshot.Status == types.SnapshotMatched:
+		statusColor = color.New(color.FgGreen)
+
+	default:
+		statusColor = color.New(color.FgYellow)
+	}
+
+	statusColor.Print(FormatSnapshotResult(snapshot))
+}
+
 // FormatStatistics formats statistics as a string for display.
 func FormatStatistics(stats *types.Statistics) string {
 	var sb strings.Builder
@@ -215,6 +240,11 @@ func FormatStatistics(stats *types.Statistics) string {
 	sb.WriteString(fmt.Sprintf("  Avg Time: %v\n", stats.AvgTime))
 	sb.WriteString(fmt.Sprintf("  Min Time: %v\n", stats.MinTime))
 	sb.WriteString(fmt.Sprintf("  Max Time: %v\n", stats.MaxTime))
+
+	if stats.Timings != nil {
+		sb.WriteString(FormatTimingStatistics(stats.Timings))
+	}
+
 	return sb.String()
 }
 
@@ -226,11 +256,38 @@ func PrintStatistics(stats *types.Statistics) {
 	fmt.Printf("  Avg Time: %v\n", stats.AvgTime)
 	fmt.Printf("  Min Time: %v\n", stats.MinTime)
 	fmt.Printf("  Max Time: %v\n", stats.MaxTime)
+
+	if stats.Timings != nil {
+		fmt.Print(FormatTimingStatistics(stats.Timings))
+	}
 }
 
 func maskToken(token string) string {
-	if len(token) < 8 {
-		return "***"
+	return codegen.MaskSecret(token)
+}
+
+// requestContentType returns the content type of a request body, set on the body
+// or as a header.
+func requestContentType(req *types.Request) string {
+	if req.Body.ContentType != "" {
+		return req.Body.ContentType
+	}
+
+	for name, value := range req.Headers {
+		if strings.EqualFold(name, "Content-Type") {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// executionStatus returns the status of a response in a result: the gRPC status of
+// a gRPC call, the HTTP status code otherwise.
+func executionStatus(resp *types.Response) string {
+	if resp.GRPCCode != nil {
+		return resp.Status
 	}
-	return token[:4] + "..." + token[len(token)-4:]
+
+	return fmt.Sprint(resp.StatusCode)
 }
@@ -159,6 +159,25 @@ func TestUnitFormatExecutionResult_WithErrors(t *testing.T) {
 	}
 }
 
+func TestUnitFormatExecutionResult_GRPC(t *testing.T) {
+	ok := 0
+	result := &types.ExecutionResult{
+		CollectionName: "greeter",
+		Requests: []*types.RequestExecution{
+			{
+				Request:  &types.Request{URL: "grpc://localhost:50051/greeter.Greeter/SayHello"},
+				Response: &types.Response{GRPCCode: &ok, Status: "OK"},
+				Duration: time.Millisecond * 10,
+			},
+		},
+	}
+
+	formatted := FormatExecutionResult(result)
+	if !strings.Contains(formatted, "   Status: OK\n") {
+		t.Errorf("expected the gRPC status, got:\n%s", formatted)
+	}
+}
+
 func TestUnitPrintExecutionResult(t *testing.T) {
 	result := &types.ExecutionResult{
 		CollectionName: "Test Collection",
@@ -236,3 +255,27 @@ func TestUnitMaskToken(t *testing.T) {
 	}
 }
 
+func TestUnitFormatSnapshotResult(t *testing.T) {
+	snapshot := &types.SnapshotResult{
+		Status: types.SnapshotMismatched,
+		Path:   "/snapshots/api/item.snap",
+		Mismatches: []types.SnapshotMismatch{
+			{Path: "$.id", Kind: "changed", Expected: "1", Actual: "2"},
+		},
+	}
+
+	formatted := FormatSnapshotResult(snapshot)
+	if !strings.Contains(formatted, "Snapshot: mismatched (/snapshots/api/item.snap)") {
+		t.Errorf("expected snapshot status, got %q", formatted)
+	}
+	if !strings.Contains(formatted, "changed $.id: 1 -> 2") {
+		t.Errorf("expected snapshot mismatch, got %q", formatted)
+	}
+
+	formatted = FormatSnapshotResult(&types.SnapshotResult{Error: "failed to read snapshot"})
+	if !strings.Contains(formatted, "Snapshot: error: failed to read snapshot") {
+		t.Errorf("expected snapshot error, got %q", formatted)
+	}
+
+	PrintSnapshotResult(snapshot)
+}
@@ -0,0 +1,28 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package formatter
+
+import (
+	"fmt"
+
+	"github.com/KonnorFrik/getman/codegen"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// FormatSnippet formats a resolved request as a code snippet in the given language.
+// Credentials from the request auth are masked, like in FormatRequest.
+func FormatSnippet(req *types.Request, lang codegen.Language) (string, error) {
+	return codegen.Generate(req, lang, &codegen.Options{MaskSecrets: true})
+}
+
+// PrintSnippet prints a resolved request as a code snippet to stdout.
+func PrintSnippet(req *types.Request, lang codegen.Language) error {
+	snippet, err := FormatSnippet(req, lang)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(snippet)
+	return nil
+}
@@ -0,0 +1,30 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/codegen"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitFormatSnippet_MasksSecrets(t *testing.T) {
+	req := &types.Request{
+		Method: "GET",
+		URL:    "http://localhost:8080/data",
+		Auth:   &types.Auth{Type: "bearer", Token: "token-1234567890"},
+	}
+
+	snippet, err := FormatSnippet(req, codegen.Python)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(snippet, "token-1234567890") {
+		t.Errorf("expected token to be masked, got:\n%s", snippet)
+	}
+
+	if !strings.Contains(snippet, `"Authorization": "Bearer toke...7890"`) {
+		t.Errorf("expected masked Authorization header, got:\n%s", snippet)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+	"github.com/fatih/color"
+)
+
+const waterfallWidth = 40
+
+type timingPhase struct {
+	name     string
+	offset   time.Duration
+	duration time.Duration
+	color    *color.Color
+}
+
+// timingPhases lays the request phases out one after another.
+// The waiting phase is whatever part of the time to first byte is not
+// covered by DNS lookup, connect and TLS handshake.
+func timingPhases(t *types.Timings) []timingPhase {
+	wait := t.TimeToFirstByte - t.DNSLookup - t.TCPConnect - t.TLSHandshake
+	if wait < 0 {
+		wait = 0
+	}
+
+	phases := []timingPhase{
+		{name: "DNS Lookup", duration: t.DNSLookup, color: color.New(color.FgCyan)},
+		{name: "TCP Connect", duration: t.TCPConnect, color: color.New(color.FgYellow)},
+		{name: "TLS Handshake", duration: t.TLSHandshake, color: color.New(color.FgMagenta)},
+		{name: "Waiting (TTFB)", duration: wait, color: color.New(color.FgGreen)},
+		{name: "Content Transfer", duration: t.ContentTransfer, color: color.New(color.FgBlue)},
+	}
+
+	var offset time.Duration
+	for i := range phases {
+		phases[i].offset = offset
+		offset += phases[i].duration
+	}
+
+	return phases
+}
+
+func waterfallBar(offset, duration, total time.Duration) (string, string, string) {
+	if total <= 0 {
+		return strings.Repeat(" ", waterfallWidth), "", ""
+	}
+
+	start := int(int64(offset) * waterfallWidth / int64(total))
+	length := int(int64(duration) * waterfallWidth / int64(total))
+
+	if duration > 0 && length == 0 {
+		length = 1
+	}
+
+	if start > waterfallWidth {
+		start = waterfallWidth
+	}
+
+	if start+length > waterfallWidth {
+		length = waterfallWidth - start
+	}
+
+	return strings.Repeat(" ", start), strings.Repeat("█", length), strings.Repeat(" ", waterfallWidth-start-length)
+}
+
+func timingsTotal(t *types.Timings) time.Duration {
+	total := t.Total
+	if sum := t.TimeToFirstByte + t.ContentTransfer; sum > total {
+		total = sum
+	}
+
+	return total
+}
+
+// FormatTimings formats request phase timings as a text waterfall.
+func FormatTimings(t *types.Timings) string {
+	var sb strings.Builder
+	total := timingsTotal(t)
+
+	for _, p := range timingPhases(t) {
+		before, bar, after := waterfallBar(p.offset, p.duration, total)
+		sb.WriteString(fmt.Sprintf("  %-17s [%s%s%s] %v\n", p.name, before, bar, after, p.duration))
+	}
+
+	sb.WriteString(fmt.Sprintf("  %-17s %v\n", "Total", t.Total))
+	sb.WriteString(fmt.Sprintf("  %-17s %s\n", "Connection Reused", yesNo(t.ConnectionReused)))
+	return sb.String()
+}
+
+// PrintTimings prints request phase timings as a colored waterfall to stdout.
+func PrintTimings(t *types.Timings) {
+	total := timingsTotal(t)
+
+	for _, p := range timingPhases(t) {
+		before, bar, after := waterfallBar(p.offset, p.duration, total)
+		fmt.Printf("  %-17s [%s", p.name, before)
+		p.color.Print(bar)
+		fmt.Printf("%s] %v\n", after, p.duration)
+	}
+
+	fmt.Printf("  %-17s %v\n", "Total", t.Total)
+	fmt.Printf("  %-17s %s\n", "Connection Reused", yesNo(t.ConnectionReused))
+}
+
+// FormatTimingStatistics formats averaged phase timings of a collection run.
+func FormatTimingStatistics(stats *types.TimingStatistics) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  Avg DNS Lookup: %v\n", stats.AvgDNSLookup))
+	sb.WriteString(fmt.Sprintf("  Avg TCP Connect: %v\n", stats.AvgTCPConnect))
+	sb.WriteString(fmt.Sprintf("  Avg TLS Handshake: %v\n", stats.AvgTLSHandshake))
+	sb.WriteString(fmt.Sprintf("  Avg TTFB: %v\n", stats.AvgTimeToFirstByte))
+	sb.WriteString(fmt.Sprintf("  Avg Content Transfer: %v\n", stats.AvgContentTransfer))
+	sb.WriteString(fmt.Sprintf("  Reused Connections: %d\n", stats.ReusedConnections))
+	return sb.String()
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,77 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitFormatTimings(t *testing.T) {
+	timings := &types.Timings{
+		DNSLookup:        10 * time.Millisecond,
+		TCPConnect:       20 * time.Millisecond,
+		TLSHandshake:     30 * time.Millisecond,
+		TimeToFirstByte:  100 * time.Millisecond,
+		ContentTransfer:  60 * time.Millisecond,
+		Total:            160 * time.Millisecond,
+		ConnectionReused: true,
+	}
+
+	formatted := FormatTimings(timings)
+
+	for _, expected := range []string{"DNS Lookup", "TCP Connect", "TLS Handshake", "Waiting (TTFB)", "Content Transfer", "160ms", "yes"} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("expected formatted timings to contain %q, got:\n%s", expected, formatted)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(formatted), "\n")
+	waitLine := lines[3]
+	if !strings.Contains(waitLine, "40ms") {
+		t.Errorf("expected waiting phase of 40ms, got %q", waitLine)
+	}
+}
+
+func TestUnitFormatTimings_ZeroTotal(t *testing.T) {
+	formatted := FormatTimings(&types.Timings{})
+
+	if !strings.Contains(formatted, "Connection Reused") {
+		t.Error("expected formatted timings to contain connection reuse")
+	}
+}
+
+func TestUnitFormatResponse_WithTimings(t *testing.T) {
+	resp := &types.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Timings: &types.Timings{
+			TimeToFirstByte: 10 * time.Millisecond,
+			Total:           10 * time.Millisecond,
+		},
+	}
+
+	formatted := FormatResponse(resp)
+	if !strings.Contains(formatted, "Timings:") {
+		t.Error("expected formatted response to contain timings")
+	}
+}
+
+func TestUnitFormatStatistics_WithTimings(t *testing.T) {
+	stats := &types.Statistics{
+		Total: 2,
+		Timings: &types.TimingStatistics{
+			AvgTimeToFirstByte: 15 * time.Millisecond,
+			ReusedConnections:  1,
+		},
+	}
+
+	formatted := FormatStatistics(stats)
+	if !strings.Contains(formatted, "Avg TTFB: 15ms") {
+		t.Errorf("expected formatted statistics to contain average TTFB, got:\n%s", formatted)
+	}
+	if !strings.Contains(formatted, "Reused Connections: 1") {
+		t.Errorf("expected formatted statistics to contain reused connections, got:\n%s", formatted)
+	}
+}
@@ -0,0 +1,621 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// bruMethods are the blocks holding the method, URL and modes of a Bruno request.
+var bruMethods = []string{"get", "post", "put", "patch", "delete", "head", "options", "connect", "trace"}
+
+// bruUnsupportedBlocks are the Bruno features that have no equivalent in a collection.
+var bruUnsupportedBlocks = map[string]string{
+	"script:pre-request":   "pre-request scripts",
+	"script:post-response": "post-response scripts",
+	"tests":                "tests",
+	"assert":               "assertions",
+	"vars:pre-request":     "pre-request variables",
+	"vars:post-response":   "post-response variables",
+}
+
+// ImportFromBruno imports a Bruno collection folder. Requests are read from the .bru
+// files, sub folders become folders of the collection and the files of the
+// environments folder become environments.
+func ImportFromBruno(dirPath string) (*ImportResult, error) {
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Bruno collection: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("failed to read Bruno collection: %s is not a directory", dirPath)
+	}
+
+	name := filepath.Base(filepath.Clean(dirPath))
+	if data, err := os.ReadFile(filepath.Join(dirPath, "bruno.json")); err == nil {
+		var config struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse bruno.json: %w", err)
+		}
+		if config.Name != "" {
+			name = config.Name
+		}
+	}
+
+	im := &brunoImporter{
+		result: &ImportResult{},
+		root:   dirPath,
+	}
+
+	collection := &collections.Collection{
+		Name:  name,
+		Items: []*types.RequestItem{},
+	}
+
+	defaults := &bruDefaults{}
+	if file, err := im.readFile(filepath.Join(dirPath, "collection.bru")); err == nil {
+		defaults = im.convertDefaults(file, defaults, "collection")
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := im.importDir(collection, dirPath, "", defaults); err != nil {
+		return nil, err
+	}
+
+	envs, err := im.importEnvironments(filepath.Join(dirPath, "environments"))
+	if err != nil {
+		return nil, err
+	}
+	if len(envs) > 0 {
+		collection.EnvName = envs[0].Name
+	}
+
+	im.result.Collections = append(im.result.Collections, collection)
+	im.result.Environments = append(im.result.Environments, envs...)
+
+	return im.result, nil
+}
+
+type brunoImporter struct {
+	result *ImportResult
+	root   string
+}
+
+// bruDefaults hold the headers and auth set on a collection or folder,
+// which apply to the requests inside it.
+type bruDefaults struct {
+	headers []bruPair
+	auth    *types.Auth
+}
+
+type bruEntry struct {
+	seq  int
+	name string
+	path string
+	file *bruFile
+}
+
+func (im *brunoImporter) importDir(collection *collections.Collection, dirPath, folder string, defaults *bruDefaults) error {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to read Bruno folder: %w", err)
+	}
+
+	var requests, folders []*bruEntry
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dirPath, name)
+
+		if entry.IsDir() {
+			if strings.HasPrefix(name, ".") || name == "node_modules" || (dirPath == im.root && name == "environments") {
+				continue
+			}
+			sub := &bruEntry{seq: -1, name: name, path: path}
+			if file, err := im.readFile(filepath.Join(path, "folder.bru")); err == nil {
+				sub.file = file
+				if meta := file.block("meta"); meta != nil {
+					if metaName := meta.get("name"); metaName != "" {
+						sub.name = metaName
+					}
+					sub.seq = bruSeq(meta)
+				}
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+			folders = append(folders, sub)
+			continue
+		}
+
+		if filepath.Ext(name) != ".bru" || name == "collection.bru" || name == "folder.bru" {
+			continue
+		}
+
+		file, err := im.readFile(path)
+		if err != nil {
+			return err
+		}
+		request := &bruEntry{seq: -1, name: strings.TrimSuffix(name, ".bru"), path: path, file: file}
+		if meta := file.block("meta"); meta != nil {
+			if metaName := meta.get("name"); metaName != "" {
+				request.name = metaName
+			}
+			request.seq = bruSeq(meta)
+		}
+		requests = append(requests, request)
+	}
+
+	sortBruEntries(requests)
+	sortBruEntries(folders)
+
+	for _, entry := range requests {
+		context := fmt.Sprintf("request %q", entry.name)
+		if meta := entry.file.block("meta"); meta != nil {
+			if kind := meta.get("type"); kind != "" && kind != "http" && kind != "graphql" {
+				im.result.warn("%s: %s requests are not supported", context, kind)
+				continue
+			}
+		}
+
+		req := im.convertRequest(entry.file, defaults, context)
+		if req == nil {
+			continue
+		}
+		collection.Items = append(collection.Items, &types.RequestItem{
+			Name:    entry.name,
+			Folder:  folder,
+			Request: req,
+		})
+	}
+
+	for _, entry := range folders {
+		path := entry.name
+		if folder != "" {
+			path = folder + "/" + entry.name
+		}
+
+		folderDefaults := defaults
+		if entry.file != nil {
+			folderDefaults = im.convertDefaults(entry.file, defaults, fmt.Sprintf("folder %q", path))
+		}
+
+		if err := im.importDir(collection, entry.path, path, folderDefaults); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sortBruEntries orders entries by their seq, entries without one go last by name.
+func sortBruEntries(entries []*bruEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.seq < 0) != (b.seq < 0) {
+			return a.seq >= 0
+		}
+		if a.seq != b.seq {
+			return a.seq < b.seq
+		}
+		return a.name < b.name
+	})
+}
+
+func bruSeq(meta *bruBlock) int {
+	seq, err := strconv.Atoi(meta.get("seq"))
+	if err != nil {
+		return -1
+	}
+	return seq
+}
+
+func (im *brunoImporter) readFile(path string) (*bruFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read Bruno file: %w", err)
+	}
+
+	file, err := parseBru(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if strings.Contains(string(data), "{{process.env.") {
+		im.result.warn("%s: process.env variables are not supported", path)
+	}
+
+	return file, nil
+}
+
+// convertDefaults reads the headers and auth of a collection.bru or folder.bru file
+// on top of the defaults of the parent folder.
+func (im *brunoImporter) convertDefaults(file *bruFile, parent *bruDefaults, context string) *bruDefaults {
+	defaults := &bruDefaults{
+		headers: parent.headers,
+		auth:    parent.auth,
+	}
+
+	if headers := file.block("headers"); headers != nil {
+		defaults.headers = append(append([]bruPair{}, parent.headers...), headers.pairs()...)
+	}
+
+	if auth := file.block("auth"); auth != nil {
+		if mode := auth.get("mode"); mode != "inherit" {
+			defaults.auth = im.convertAuth(file, mode, context)
+		}
+	}
+
+	im.warnUnsupported(file, context)
+
+	return defaults
+}
+
+func (im *brunoImporter) warnUnsupported(file *bruFile, context string) {
+	for _, block := range file.blocks {
+		if feature, ok := bruUnsupportedBlocks[block.name]; ok {
+			im.result.warn("%s: %s are not supported", context, feature)
+		}
+	}
+}
+
+func (im *brunoImporter) convertRequest(file *bruFile, defaults *bruDefaults, context string) *types.Request {
+	var method *bruBlock
+	for _, name := range bruMethods {
+		if method = file.block(name); method != nil {
+			break
+		}
+	}
+	if method == nil {
+		im.result.warn("%s: no request method was found", context)
+		return nil
+	}
+
+	req := &types.Request{
+		Method:  strings.ToUpper(method.name),
+		URL:     method.get("url"),
+		Headers: make(map[string]string),
+	}
+
+	if params := file.block("params:path"); params != nil {
+		req.URL = replaceBruPathParams(req.URL, params.pairs())
+	}
+
+	if params := file.block("params:query"); params != nil && !strings.Contains(req.URL, "?") {
+		for _, param := range params.pairs() {
+			if !param.disabled {
+				req.URL = appendQuery(req.URL, param.key, param.value)
+			}
+		}
+	}
+
+	for _, header := range defaults.headers {
+		if !header.disabled {
+			req.Headers[header.key] = header.value
+		}
+	}
+	if headers := file.block("headers"); headers != nil {
+		for _, header := range headers.pairs() {
+			if header.disabled {
+				continue
+			}
+			if key, ok := findHeader(req.Headers, header.key); ok {
+				delete(req.Headers, key)
+			}
+			req.Headers[header.key] = header.value
+		}
+	}
+
+	req.Body = im.convertBody(file, method.get("body"), context)
+	moveContentType(req)
+
+	switch mode := method.get("auth"); mode {
+	case "inherit":
+		if defaults.auth != nil {
+			auth := *defaults.auth
+			req.Auth = &auth
+		}
+	default:
+		req.Auth = im.convertAuth(file, mode, context)
+	}
+
+	im.warnUnsupported(file, context)
+
+	return req
+}
+
+// replaceBruPathParams replaces :name segments of the URL path with their values.
+func replaceBruPathParams(rawURL string, params []bruPair) string {
+	path, query, hasQuery := strings.Cut(rawURL, "?")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		for _, param := range params {
+			if segment == ":"+param.key {
+				segments[i] = param.value
+			}
+		}
+	}
+
+	path = strings.Join(segments, "/")
+	if hasQuery {
+		return path + "?" + query
+	}
+	return path
+}
+
+func (im *brunoImporter) convertBody(file *bruFile, mode, context string) *types.RequestBody {
+	text := func(name string) string {
+		if block := file.block(name); block != nil {
+			return block.text()
+		}
+		return ""
+	}
+
+	switch mode {
+	case "", "none":
+		return nil
+	case "json":
+		return &types.RequestBody{Type: "json", Content: []byte(text("body:json")), ContentType: "application/json"}
+	case "text":
+		return &types.RequestBody{Type: "text", Content: []byte(text("body:text")), ContentType: "text/plain"}
+	case "xml":
+		return &types.RequestBody{Type: "xml", Content: []byte(text("body:xml")), ContentType: "application/xml"}
+	case "graphql":
+		return convertPostmanGraphQL(&PostmanGraphQL{
+			Query:     text("body:graphql"),
+			Variables: text("body:graphql:vars"),
+		})
+	case "formUrlEncoded":
+		var parts []string
+		if block := file.block("body:form-urlencoded"); block != nil {
+			for _, field := range block.pairs() {
+				if !field.disabled {
+					parts = append(parts, url.QueryEscape(field.key)+"="+url.QueryEscape(field.value))
+				}
+			}
+		}
+		return &types.RequestBody{
+			Type:        "urlencoded",
+			Content:     []byte(strings.Join(parts, "&")),
+			ContentType: "application/x-www-form-urlencoded",
+		}
+	case "multipartForm":
+		var fields []formField
+		if block := file.block("body:multipart-form"); block != nil {
+			for _, pair := range block.pairs() {
+				if pair.disabled {
+					continue
+				}
+				field := formField{name: pair.key, value: pair.value}
+				if strings.HasPrefix(pair.value, "@file(") && strings.HasSuffix(pair.value, ")") {
+					path := strings.TrimSuffix(strings.TrimPrefix(pair.value, "@file("), ")")
+					if !filepath.IsAbs(path) {
+						path = filepath.Join(im.root, path)
+					}
+					content, err := os.ReadFile(path)
+					if err != nil {
+						im.result.warn("%s: file %s of form field %q could not be read", context, path, pair.key)
+					}
+					field.value = string(content)
+					field.filename = filepath.Base(path)
+				}
+				fields = append(fields, field)
+			}
+		}
+		form, err := multipartBody(fields)
+		if err != nil {
+			im.result.warn("%s: %v", context, err)
+			return nil
+		}
+		return form
+	default:
+		im.result.warn("%s: %s bodies are not supported", context, mode)
+		return nil
+	}
+}
+
+func (im *brunoImporter) convertAuth(file *bruFile, mode, context string) *types.Auth {
+	block := file.block("auth:" + mode)
+
+	switch mode {
+	case "", "none":
+		return nil
+	case "basic":
+		if block == nil {
+			return nil
+		}
+		return &types.Auth{Type: "basic", Username: block.get("username"), Password: block.get("password")}
+	case "bearer":
+		if block == nil {
+			return nil
+		}
+		return &types.Auth{Type: "bearer", Token: block.get("token")}
+	case "apikey":
+		if block == nil {
+			return nil
+		}
+		location := "header"
+		if block.get("placement") == "queryparams" {
+			location = "query"
+		}
+		return &types.Auth{Type: "apikey", KeyName: block.get("key"), APIKey: block.get("value"), Location: location}
+	default:
+		im.result.warn("%s: %s authentication is not supported", context, mode)
+		return nil
+	}
+}
+
+// importEnvironments reads the environments folder of a collection. Secret variables
+// are not stored in the files, so they are imported empty.
+func (im *brunoImporter) importEnvironments(dirPath string) ([]*environment.Environment, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read Bruno environments: %w", err)
+	}
+
+	var envs []*environment.Environment
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".bru" {
+			continue
+		}
+
+		file, err := im.readFile(filepath.Join(dirPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		env := environment.NewEnvironment(strings.TrimSuffix(entry.Name(), ".bru"))
+		if vars := file.block("vars"); vars != nil {
+			for _, pair := range vars.pairs() {
+				if !pair.disabled {
+					env.Set(pair.key, pair.value)
+				}
+			}
+		}
+		if secrets := file.block("vars:secret"); secrets != nil {
+			for _, name := range secrets.list() {
+				env.Set(name, "")
+				im.result.warn("environment %q: secret %s was imported without its value", env.Name, name)
+			}
+		}
+
+		envs = append(envs, env)
+	}
+
+	return envs, nil
+}
+
+// bruFile is a parsed .bru file made of top level blocks.
+type bruFile struct {
+	blocks []*bruBlock
+}
+
+// bruBlock is a "name { ... }" or "name [ ... ]" block of a .bru file.
+// The lines are stored without the block indentation.
+type bruBlock struct {
+	name  string
+	lines []string
+}
+
+// bruPair is a "key: value" line of a block. Disabled pairs start with ~.
+type bruPair struct {
+	key      string
+	value    string
+	disabled bool
+}
+
+func parseBru(content string) (*bruFile, error) {
+	file := &bruFile{}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		if line == "" {
+			continue
+		}
+
+		var closer string
+		switch {
+		case strings.HasSuffix(line, "{"):
+			closer = "}"
+		case strings.HasSuffix(line, "["):
+			closer = "]"
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", i+1, line)
+		}
+
+		block := &bruBlock{name: strings.TrimSpace(line[:len(line)-1])}
+		start := i
+		for i++; ; i++ {
+			if i == len(lines) {
+				return nil, fmt.Errorf("line %d: block %s is not closed", start+1, block.name)
+			}
+			if strings.TrimRight(lines[i], " \t\r") == closer {
+				break
+			}
+			block.lines = append(block.lines, strings.TrimPrefix(lines[i], "  "))
+		}
+
+		file.blocks = append(file.blocks, block)
+	}
+
+	return file, nil
+}
+
+func (f *bruFile) block(name string) *bruBlock {
+	for _, block := range f.blocks {
+		if block.name == name {
+			return block
+		}
+	}
+	return nil
+}
+
+func (b *bruBlock) text() string {
+	return strings.TrimSpace(strings.Join(b.lines, "\n"))
+}
+
+func (b *bruBlock) pairs() []bruPair {
+	var pairs []bruPair
+	for _, line := range b.lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var pair bruPair
+		if strings.HasPrefix(line, "~") {
+			pair.disabled = true
+			line = line[1:]
+		}
+
+		key, value, _ := strings.Cut(line, ":")
+		pair.key = strings.TrimSpace(key)
+		pair.value = strings.TrimSpace(value)
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+func (b *bruBlock) get(key string) string {
+	for _, pair := range b.pairs() {
+		if pair.key == key && !pair.disabled {
+			return pair.value
+		}
+	}
+	return ""
+}
+
+func (b *bruBlock) list() []string {
+	var items []string
+	for _, line := range b.lines {
+		item := strings.TrimSuffix(strings.TrimSpace(line), ",")
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+)
+
+func TestIntegrationImportFromBruno_ExecuteImported(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	dir, cleanup := writeBrunoCollection(t, map[string]string{
+		"bruno.json": `{"version": "1", "name": "Echo", "type": "collection"}`,
+		"Echo.bru": `meta {
+  name: Echo
+  type: http
+  seq: 1
+}
+
+put {
+  url: {{baseUrl}}/echo?page=2
+  body: formUrlEncoded
+  auth: basic
+}
+
+auth:basic {
+  username: ann
+  password: secret
+}
+
+body:form-urlencoded {
+  name: Ann
+}
+`,
+		"environments/local.bru": "vars {\n  baseUrl: " + http_server.GetServerURL() + "\n}\n",
+	})
+	defer cleanup()
+
+	result, err := ImportFromBruno(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Collections) != 1 || len(result.Environments) != 1 {
+		t.Fatalf("expected 1 collection and 1 environment, got %d and %d", len(result.Collections), len(result.Environments))
+	}
+
+	resolver, err := core.NewVariableResolver(result.Environments[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := result.Collections[0].Items[0].Request
+	if req.URL, err = resolver.Resolve(req.URL); err != nil {
+		t.Fatalf("failed to resolve URL: %v", err)
+	}
+
+	client := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var echo struct {
+		Method  string              `json:"method"`
+		Query   map[string][]string `json:"query"`
+		Headers map[string][]string `json:"headers"`
+		Body    string              `json:"body"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		t.Fatalf("failed to parse echo response: %v", err)
+	}
+
+	if echo.Method != "PUT" || echo.Query["page"][0] != "2" {
+		t.Errorf("unexpected echo %s %v", echo.Method, echo.Query)
+	}
+	if !strings.HasPrefix(echo.Headers["Authorization"][0], "Basic ") {
+		t.Errorf("unexpected Authorization header: %v", echo.Headers["Authorization"])
+	}
+	if echo.Headers["Content-Type"][0] != "application/x-www-form-urlencoded" || echo.Body != "name=Ann" {
+		t.Errorf("unexpected body: %v %s", echo.Headers["Content-Type"], echo.Body)
+	}
+}
@@ -0,0 +1,378 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+)
+
+var testBrunoFiles = map[string]string{
+	"bruno.json": `{"version": "1", "name": "Shop API", "type": "collection"}`,
+	"collection.bru": `headers {
+  X-Client: getman
+}
+
+auth {
+  mode: bearer
+}
+
+auth:bearer {
+  token: {{token}}
+}
+`,
+	"Get user.bru": `meta {
+  name: Get user
+  type: http
+  seq: 2
+}
+
+get {
+  url: {{baseUrl}}/users/:id
+  body: none
+  auth: inherit
+}
+
+params:path {
+  id: 42
+}
+
+params:query {
+  expand: orders
+  ~debug: 1
+}
+
+headers {
+  Accept: application/json
+  ~X-Debug: 1
+}
+
+tests {
+  test("ok", function() {
+    expect(res.status).to.equal(200);
+  });
+}
+`,
+	"Create user.bru": `meta {
+  name: Create user
+  type: http
+  seq: 1
+}
+
+post {
+  url: {{baseUrl}}/users?notify=true
+  body: json
+  auth: basic
+}
+
+params:query {
+  notify: true
+}
+
+auth:basic {
+  username: ann
+  password: {{process.env.PASSWORD}}
+}
+
+body:json {
+  {
+    "name": "Ann",
+    "tags": ["a"]
+  }
+}
+
+script:pre-request {
+  req.setHeader("X-Time", Date.now());
+}
+`,
+	"admin/folder.bru": `meta {
+  name: Administration
+  seq: 1
+}
+
+auth {
+  mode: apikey
+}
+
+auth:apikey {
+  key: X-Api-Key
+  value: {{apiKey}}
+  placement: header
+}
+`,
+	"admin/Login.bru": `meta {
+  name: Login
+  type: http
+  seq: 1
+}
+
+post {
+  url: {{baseUrl}}/login
+  body: formUrlEncoded
+  auth: inherit
+}
+
+body:form-urlencoded {
+  user: ann
+  pass: a b
+  ~remember: true
+}
+`,
+	"admin/Upload.bru": `meta {
+  name: Upload
+  type: http
+  seq: 2
+}
+
+post {
+  url: {{baseUrl}}/upload
+  body: multipartForm
+  auth: awsv4
+}
+
+body:multipart-form {
+  title: Avatar
+  file: @file(files/avatar.txt)
+}
+`,
+	"admin/Search.bru": `meta {
+  name: Search
+  type: graphql
+  seq: 3
+}
+
+post {
+  url: {{baseUrl}}/graphql
+  body: graphql
+  auth: none
+}
+
+body:graphql {
+  { users { id } }
+}
+
+body:graphql:vars {
+  {"limit": 10}
+}
+`,
+	"files/avatar.txt": "avatar",
+	"environments/dev.bru": `vars {
+  baseUrl: http://localhost:8080
+  ~old: 1
+}
+vars:secret [
+  token,
+  apiKey
+]
+`,
+	"environments/prod.bru": `vars {
+  baseUrl: https://shop.example.com
+}
+`,
+}
+
+func writeBrunoCollection(t *testing.T, files map[string]string) (string, func()) {
+	t.Helper()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create folder: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	return dir, func() { helper.CleanupTempDir(dir) }
+}
+
+func TestUnitImportFromBruno_Collection(t *testing.T) {
+	dir, cleanup := writeBrunoCollection(t, testBrunoFiles)
+	defer cleanup()
+
+	result, err := ImportFromBruno(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Collections) != 1 {
+		t.Fatalf("expected 1 collection, got %d", len(result.Collections))
+	}
+
+	collection := result.Collections[0]
+	if collection.Name != "Shop API" || collection.EnvName != "dev" {
+		t.Errorf("unexpected collection: %s env %q", collection.Name, collection.EnvName)
+	}
+
+	var names []string
+	for _, item := range collection.Items {
+		names = append(names, item.Folder+":"+item.Name)
+	}
+	expected := ":Create user,:Get user,Administration:Login,Administration:Upload,Administration:Search"
+	if strings.Join(names, ",") != expected {
+		t.Fatalf("expected items %s, got %s", expected, strings.Join(names, ","))
+	}
+
+	create := collection.Items[0].Request
+	if create.Method != "POST" || create.URL != "{{baseUrl}}/users?notify=true" {
+		t.Errorf("unexpected request: %s %s", create.Method, create.URL)
+	}
+	if create.Body == nil || create.Body.Type != "json" || string(create.Body.Content) != "{\n  \"name\": \"Ann\",\n  \"tags\": [\"a\"]\n}" {
+		t.Errorf("unexpected body: %+v", create.Body)
+	}
+	if create.Auth == nil || create.Auth.Type != "basic" || create.Auth.Username != "ann" {
+		t.Errorf("unexpected auth: %+v", create.Auth)
+	}
+	if create.Headers["X-Client"] != "getman" {
+		t.Errorf("expected collection headers, got %v", create.Headers)
+	}
+
+	get := collection.Items[1].Request
+	if get.URL != "{{baseUrl}}/users/42?expand=orders" {
+		t.Errorf("unexpected URL: %s", get.URL)
+	}
+	if get.Headers["Accept"] != "application/json" {
+		t.Errorf("unexpected headers: %v", get.Headers)
+	}
+	if _, ok := get.Headers["X-Debug"]; ok {
+		t.Error("expected disabled header to be skipped")
+	}
+	if get.Auth == nil || get.Auth.Type != "bearer" || get.Auth.Token != "{{token}}" {
+		t.Errorf("expected collection auth, got %+v", get.Auth)
+	}
+
+	login := collection.Items[2].Request
+	if login.Body == nil || login.Body.Type != "urlencoded" || string(login.Body.Content) != "user=ann&pass=a+b" {
+		t.Errorf("unexpected form body: %+v", login.Body)
+	}
+	if login.Auth == nil || login.Auth.Type != "apikey" || login.Auth.KeyName != "X-Api-Key" || login.Auth.Location != "header" {
+		t.Errorf("expected folder auth, got %+v", login.Auth)
+	}
+
+	upload := collection.Items[3].Request
+	if upload.Body == nil || upload.Body.Type != "formdata" {
+		t.Fatalf("unexpected multipart body: %+v", upload.Body)
+	}
+	content := string(upload.Body.Content)
+	if !strings.Contains(content, `filename="avatar.txt"`) || !strings.Contains(content, "avatar") {
+		t.Errorf("expected file to be read into the form, got %s", content)
+	}
+	if upload.Auth != nil {
+		t.Errorf("expected unsupported auth to be dropped, got %+v", upload.Auth)
+	}
+
+	search := collection.Items[4].Request
+	if search.Body == nil || search.Body.Type != "graphql" || search.Body.GraphQL.Query != "{ users { id } }" {
+		t.Fatalf("unexpected graphql body: %+v", search.Body)
+	}
+	if search.Body.GraphQL.Variables["limit"] != float64(10) {
+		t.Errorf("unexpected graphql variables: %v", search.Body.GraphQL.Variables)
+	}
+	if search.Auth != nil {
+		t.Errorf("expected no auth, got %+v", search.Auth)
+	}
+}
+
+func TestUnitImportFromBruno_Environments(t *testing.T) {
+	dir, cleanup := writeBrunoCollection(t, testBrunoFiles)
+	defer cleanup()
+
+	result, err := ImportFromBruno(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Environments) != 2 {
+		t.Fatalf("expected 2 environments, got %d", len(result.Environments))
+	}
+
+	dev := result.Environments[0]
+	if baseURL, _ := dev.Get("baseUrl"); baseURL != "http://localhost:8080" {
+		t.Errorf("unexpected baseUrl: %s", baseURL)
+	}
+	if _, ok := dev.Get("old"); ok {
+		t.Error("expected disabled variable to be skipped")
+	}
+	if token, ok := dev.Get("token"); !ok || token != "" {
+		t.Errorf("expected empty secret, got %q", token)
+	}
+}
+
+func TestUnitImportFromBruno_Warnings(t *testing.T) {
+	dir, cleanup := writeBrunoCollection(t, testBrunoFiles)
+	defer cleanup()
+
+	result, err := ImportFromBruno(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, expected := range []string{
+		`request "Get user": tests are not supported`,
+		`request "Create user": pre-request scripts are not supported`,
+		`process.env variables are not supported`,
+		`request "Upload": awsv4 authentication is not supported`,
+		`environment "dev": secret token was imported without its value`,
+	} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("expected warning %q, got:\n%s", expected, warnings)
+		}
+	}
+}
+
+func TestUnitImportFromBruno_InvalidFile(t *testing.T) {
+	dir, cleanup := writeBrunoCollection(t, map[string]string{
+		"broken.bru": "meta {\n  name: Broken\n",
+	})
+	defer cleanup()
+
+	if _, err := ImportFromBruno(dir); err == nil {
+		t.Error("expected error for unclosed block")
+	}
+}
+
+func TestUnitImportFromBruno_NotADirectory(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "request.bru", "get {\n}\n")
+	defer cleanup()
+
+	if _, err := ImportFromBruno(filePath); err == nil {
+		t.Error("expected error")
+	}
+
+	if _, err := ImportFromBruno("/nonexistent/bruno"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnitParseBru(t *testing.T) {
+	file, err := parseBru("meta {\n  name: Test\n  seq: 3\n}\n\nbody:json {\n  {\n    \"a\": 1\n  }\n}\n\nvars:secret [\n  a,\n  b\n]\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(file.blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(file.blocks))
+	}
+	if meta := file.block("meta"); meta.get("name") != "Test" || bruSeq(meta) != 3 {
+		t.Errorf("unexpected meta: %v", meta.lines)
+	}
+	if body := file.block("body:json").text(); body != "{\n  \"a\": 1\n}" {
+		t.Errorf("unexpected body: %q", body)
+	}
+	if list := file.block("vars:secret").list(); strings.Join(list, ",") != "a,b" {
+		t.Errorf("unexpected list: %v", list)
+	}
+
+	if _, err := parseBru("not a block\n"); err == nil {
+		t.Error("expected error for text outside of a block")
+	}
+}
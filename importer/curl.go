@@ -0,0 +1,749 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// curlShortOptions maps short curl options to their long form.
+var curlShortOptions = map[byte]string{
+	'X': "--request",
+	'H': "--header",
+	'd': "--data",
+	'F': "--form",
+	'u': "--user",
+	'b': "--cookie",
+	'A': "--user-agent",
+	'e': "--referer",
+	'x': "--proxy",
+	'U': "--proxy-user",
+	'm': "--max-time",
+	'k': "--insecure",
+	'G': "--get",
+	'I': "--head",
+	'L': "--location",
+	's': "--silent",
+	'S': "--show-error",
+	'v': "--verbose",
+	'i': "--include",
+	'f': "--fail",
+	'g': "--globoff",
+	'N': "--no-buffer",
+	'#': "--progress-bar",
+	'o': "--output",
+	'O': "--remote-name",
+	'c': "--cookie-jar",
+	'D': "--dump-header",
+	'T': "--upload-file",
+	'E': "--cert",
+	'K': "--config",
+	'r': "--range",
+	'w': "--write-out",
+}
+
+// curlValueOptions are the long options that take an argument.
+var curlValueOptions = map[string]bool{
+	"--request":         true,
+	"--header":          true,
+	"--data":            true,
+	"--data-ascii":      true,
+	"--data-raw":        true,
+	"--data-binary":     true,
+	"--data-urlencode":  true,
+	"--json":            true,
+	"--form":            true,
+	"--form-string":     true,
+	"--user":            true,
+	"--cookie":          true,
+	"--user-agent":      true,
+	"--referer":         true,
+	"--url":             true,
+	"--proxy":           true,
+	"--proxy-user":      true,
+	"--noproxy":         true,
+	"--max-time":        true,
+	"--connect-timeout": true,
+	"--oauth2-bearer":   true,
+	"--output":          true,
+	"--cookie-jar":      true,
+	"--dump-header":     true,
+	"--upload-file":     true,
+	"--cert":            true,
+	"--key":             true,
+	"--cacert":          true,
+	"--config":          true,
+	"--range":           true,
+	"--write-out":       true,
+	"--resolve":         true,
+	"--max-redirs":      true,
+	"--retry":           true,
+	"--limit-rate":      true,
+	"--interface":       true,
+}
+
+// curlIgnoredOptions have no effect on the imported request. Most only change how
+// curl reports the response. --compressed and --location are the defaults of the
+// HTTP client, which asks for gzip and decompresses responses on its own and
+// follows redirects.
+var curlIgnoredOptions = map[string]bool{
+	"--compressed":        true,
+	"--location":          true,
+	"--silent":            true,
+	"--show-error":        true,
+	"--verbose":           true,
+	"--include":           true,
+	"--fail":              true,
+	"--globoff":           true,
+	"--no-buffer":         true,
+	"--progress-bar":      true,
+	"--no-progress-meter": true,
+}
+
+// curlCommand collects the parsed options of a curl command line.
+type curlCommand struct {
+	method   string
+	url      string
+	headers  []curlHeader
+	data     []string
+	form     []formField
+	json     bool
+	get      bool
+	head     bool
+	user     *string
+	bearer   string
+	cookies  []string
+	proxy    *types.ProxySettings
+	timeout  *types.Timeout
+	insecure bool
+	warnings []string
+}
+
+type curlHeader struct {
+	name  string
+	value string
+}
+
+// ImportFromCurl converts a curl command line, e.g. one copied from the browser developer
+// tools, into a request item. Options that have no equivalent in a request are not
+// applied and reported in the returned warnings.
+func ImportFromCurl(command string) (*types.RequestItem, []string, error) {
+	args, err := splitCurlCommand(command)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(args) == 0 || filepath.Base(args[0]) != "curl" {
+		return nil, nil, fmt.Errorf("%w: not a curl command", errors.ErrInvalidArgument)
+	}
+
+	cmd := &curlCommand{}
+	if err := cmd.parse(args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := cmd.request()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item := &types.RequestItem{
+		Name:    requestItemName(req),
+		Request: req,
+	}
+
+	return item, cmd.warnings, nil
+}
+
+func (cmd *curlCommand) warn(format string, args ...any) {
+	cmd.warnings = append(cmd.warnings, fmt.Sprintf(format, args...))
+}
+
+func (cmd *curlCommand) parse(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			cmd.setURL(arg)
+			continue
+		}
+
+		var options []string
+		var inlineValue *string
+
+		if strings.HasPrefix(arg, "--") {
+			options = []string{arg}
+		} else {
+			// Short options can be combined (-sSL) and take their value attached (-XPOST).
+			for j := 1; j < len(arg); j++ {
+				name, ok := curlShortOptions[arg[j]]
+				if !ok {
+					name = "-" + string(arg[j])
+				}
+
+				options = append(options, name)
+
+				if curlValueOptions[name] && j+1 < len(arg) {
+					value := arg[j+1:]
+					inlineValue = &value
+					break
+				}
+			}
+		}
+
+		for _, option := range options {
+			var value string
+
+			if curlValueOptions[option] {
+				switch {
+				case inlineValue != nil:
+					value = *inlineValue
+				case i+1 < len(args):
+					i++
+					value = args[i]
+				default:
+					return fmt.Errorf("%w: curl option %s requires a value", errors.ErrInvalidArgument, option)
+				}
+			}
+
+			cmd.apply(option, value)
+		}
+	}
+
+	return nil
+}
+
+func (cmd *curlCommand) apply(option, value string) {
+	switch option {
+	case "--request":
+		cmd.method = strings.ToUpper(value)
+	case "--url":
+		cmd.setURL(value)
+	case "--header":
+		cmd.addHeader(value)
+	case "--user-agent":
+		cmd.headers = append(cmd.headers, curlHeader{name: "User-Agent", value: value})
+	case "--referer":
+		cmd.headers = append(cmd.headers, curlHeader{name: "Referer", value: value})
+	case "--data", "--data-ascii":
+		cmd.addData(option, value, true)
+	case "--data-binary":
+		cmd.addData(option, value, false)
+	case "--data-raw":
+		cmd.data = append(cmd.data, value)
+	case "--data-urlencode":
+		cmd.addURLEncodedData(value)
+	case "--json":
+		cmd.json = true
+		cmd.addData(option, value, false)
+	case "--form":
+		cmd.addForm(value, false)
+	case "--form-string":
+		cmd.addForm(value, true)
+	case "--get":
+		cmd.get = true
+	case "--head":
+		cmd.head = true
+	case "--user":
+		cmd.user = &value
+	case "--oauth2-bearer":
+		cmd.bearer = value
+	case "--cookie":
+		if !strings.Contains(value, "=") {
+			cmd.warn("cookie file %q is not supported, cookies were not imported", value)
+			return
+		}
+		cmd.cookies = append(cmd.cookies, value)
+	case "--proxy":
+		if !strings.Contains(value, "://") {
+			value = "http://" + value
+		}
+		cmd.proxySettings().URL = value
+	case "--proxy-user":
+		username, password, _ := strings.Cut(value, ":")
+		cmd.proxySettings().Username = username
+		cmd.proxySettings().Password = password
+	case "--noproxy":
+		for _, host := range strings.Split(value, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				cmd.proxySettings().NoProxy = append(cmd.proxySettings().NoProxy, host)
+			}
+		}
+	case "--max-time":
+		if d, ok := cmd.seconds(option, value); ok {
+			cmd.timeoutSettings().Read = d
+		}
+	case "--connect-timeout":
+		if d, ok := cmd.seconds(option, value); ok {
+			cmd.timeoutSettings().Connect = d
+		}
+	case "--insecure":
+		cmd.insecure = true
+	default:
+		if curlIgnoredOptions[option] {
+			return
+		}
+
+		cmd.warn("unsupported option %s was ignored", option)
+	}
+}
+
+func (cmd *curlCommand) setURL(value string) {
+	if cmd.url != "" {
+		cmd.warn("only one URL is supported, %q was ignored", value)
+		return
+	}
+
+	cmd.url = value
+}
+
+func (cmd *curlCommand) addHeader(value string) {
+	if strings.HasPrefix(value, "@") {
+		cmd.warn("reading headers from file %q is not supported", value[1:])
+		return
+	}
+
+	name, headerValue, found := strings.Cut(value, ":")
+	if !found {
+		// "Name;" sends the header with an empty value.
+		if name, ok := strings.CutSuffix(value, ";"); ok {
+			cmd.headers = append(cmd.headers, curlHeader{name: strings.TrimSpace(name)})
+			return
+		}
+
+		cmd.warn("invalid header %q was ignored", value)
+		return
+	}
+
+	if clientManagedHeaders[strings.ToLower(strings.TrimSpace(name))] {
+		return
+	}
+
+	headerValue = strings.TrimSpace(headerValue)
+	if headerValue == "" {
+		// "Name:" removes a header curl would add on its own.
+		return
+	}
+
+	cmd.headers = append(cmd.headers, curlHeader{name: strings.TrimSpace(name), value: headerValue})
+}
+
+// addData adds a -d style value. A leading @ reads the value from a file;
+// for --data the line breaks of the file are removed, like curl does.
+func (cmd *curlCommand) addData(option, value string, stripNewlines bool) {
+	if !strings.HasPrefix(value, "@") {
+		cmd.data = append(cmd.data, value)
+		return
+	}
+
+	content, ok := cmd.readFile(option, value[1:])
+	if !ok {
+		return
+	}
+
+	if stripNewlines {
+		content = strings.NewReplacer("\r", "", "\n", "").Replace(content)
+	}
+
+	cmd.data = append(cmd.data, content)
+}
+
+// addURLEncodedData handles the content, =content, name=content, @file and name@file forms.
+func (cmd *curlCommand) addURLEncodedData(value string) {
+	name, content := "", value
+
+	if i := strings.IndexAny(value, "=@"); i >= 0 {
+		name = value[:i]
+
+		if value[i] == '@' {
+			fileContent, ok := cmd.readFile("--data-urlencode", value[i+1:])
+			if !ok {
+				return
+			}
+			content = fileContent
+		} else {
+			content = value[i+1:]
+		}
+	}
+
+	encoded := url.QueryEscape(content)
+	if name != "" {
+		encoded = name + "=" + encoded
+	}
+
+	cmd.data = append(cmd.data, encoded)
+}
+
+// addForm handles name=value, name=@file (file upload) and name=<file (value from file).
+func (cmd *curlCommand) addForm(value string, literal bool) {
+	name, content, found := strings.Cut(value, "=")
+	if !found {
+		cmd.warn("invalid form field %q was ignored", value)
+		return
+	}
+
+	field := formField{name: name, value: content}
+
+	if !literal && (strings.HasPrefix(content, "@") || strings.HasPrefix(content, "<")) {
+		params := strings.Split(content[1:], ";")
+		path := params[0]
+
+		for _, param := range params[1:] {
+			key, paramValue, _ := strings.Cut(strings.TrimSpace(param), "=")
+			switch key {
+			case "type":
+				field.mimeType = paramValue
+			case "filename":
+				field.filename = strings.Trim(paramValue, `"`)
+			}
+		}
+
+		fileContent, ok := cmd.readFile("--form", path)
+		if !ok {
+			return
+		}
+
+		field.value = fileContent
+		if content[0] == '@' && field.filename == "" {
+			field.filename = filepath.Base(path)
+		}
+	}
+
+	cmd.form = append(cmd.form, field)
+}
+
+func (cmd *curlCommand) readFile(option, path string) (string, bool) {
+	if path == "-" {
+		cmd.warn("%s reading from stdin is not supported, the value was ignored", option)
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		cmd.warn("%s: failed to read %s, the value was ignored: %v", option, path, err)
+		return "", false
+	}
+
+	return string(data), true
+}
+
+func (cmd *curlCommand) seconds(option, value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		cmd.warn("%s: invalid number of seconds %q was ignored", option, value)
+		return 0, false
+	}
+
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+func (cmd *curlCommand) proxySettings() *types.ProxySettings {
+	if cmd.proxy == nil {
+		cmd.proxy = &types.ProxySettings{}
+	}
+
+	return cmd.proxy
+}
+
+func (cmd *curlCommand) timeoutSettings() *types.Timeout {
+	if cmd.timeout == nil {
+		cmd.timeout = &types.Timeout{}
+	}
+
+	return cmd.timeout
+}
+
+func (cmd *curlCommand) request() (*types.Request, error) {
+	if cmd.url == "" {
+		return nil, fmt.Errorf("%w: curl command has no URL", errors.ErrInvalidArgument)
+	}
+
+	rawURL := cmd.url
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+
+	req := &types.Request{
+		URL:     rawURL,
+		Headers: make(map[string]string),
+		Proxy:   cmd.proxy,
+		Timeout: cmd.timeout,
+	}
+
+	if cmd.insecure {
+		req.TLS = &types.TLSSettings{InsecureSkipVerify: true}
+	}
+
+	for _, h := range cmd.headers {
+		appendHeader(req.Headers, h.name, h.value)
+	}
+
+	for _, cookie := range cmd.cookies {
+		appendHeader(req.Headers, "Cookie", cookie)
+	}
+
+	if len(cmd.form) > 0 && len(cmd.data) > 0 {
+		cmd.warn("data and form options can not be combined, the data was ignored")
+		cmd.data = nil
+	}
+
+	switch {
+	case cmd.get && len(cmd.data) > 0:
+		separator := "?"
+		if strings.Contains(req.URL, "?") {
+			separator = "&"
+		}
+		req.URL += separator + strings.Join(cmd.data, "&")
+	case len(cmd.data) > 0:
+		req.Body = cmd.dataBody(req.Headers)
+	case len(cmd.form) > 0:
+		body, err := multipartBody(cmd.form)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+
+	if req.Body != nil {
+		// The content type is kept on the body, where the client applies it.
+		if name, ok := findHeader(req.Headers, "Content-Type"); ok {
+			if len(cmd.form) == 0 {
+				req.Body.ContentType = req.Headers[name]
+				req.Body.Type = bodyTypeFor(req.Body.ContentType)
+			}
+			delete(req.Headers, name)
+		}
+	}
+
+	switch {
+	case cmd.method != "":
+		req.Method = cmd.method
+	case cmd.head:
+		req.Method = "HEAD"
+	case req.Body != nil:
+		req.Method = "POST"
+	default:
+		req.Method = "GET"
+	}
+
+	req.Auth = cmd.auth(req.Headers)
+
+	return req, nil
+}
+
+func (cmd *curlCommand) dataBody(headers map[string]string) *types.RequestBody {
+	contentType := "application/x-www-form-urlencoded"
+
+	if cmd.json {
+		contentType = "application/json"
+		if _, ok := findHeader(headers, "Accept"); !ok {
+			headers["Accept"] = "application/json"
+		}
+	}
+
+	return &types.RequestBody{
+		Type:        bodyTypeFor(contentType),
+		Content:     []byte(strings.Join(cmd.data, "&")),
+		ContentType: contentType,
+	}
+}
+
+// auth returns the auth settings from -u, --oauth2-bearer or an Authorization header.
+// A converted Authorization header is removed from the headers.
+func (cmd *curlCommand) auth(headers map[string]string) *types.Auth {
+	if cmd.user != nil {
+		username, password, found := strings.Cut(*cmd.user, ":")
+		if !found {
+			cmd.warn("--user has no password, curl would prompt for it")
+		}
+
+		return &types.Auth{Type: "basic", Username: username, Password: password}
+	}
+
+	if cmd.bearer != "" {
+		return &types.Auth{Type: "bearer", Token: cmd.bearer}
+	}
+
+	return authFromHeader(headers)
+}
+
+// splitCurlCommand splits a command line into arguments following the POSIX shell
+// quoting rules, including ANSI-C $'...' quoting and backslash line continuations.
+func splitCurlCommand(command string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inWord  bool
+	)
+
+	flush := func() {
+		if inWord {
+			args = append(args, current.String())
+			current.Reset()
+			inWord = false
+		}
+	}
+
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			flush()
+		case c == '\\':
+			if i+1 >= len(command) {
+				return nil, fmt.Errorf("%w: curl command ends with a backslash", errors.ErrInvalidArgument)
+			}
+
+			i++
+			if command[i] == '\r' && i+1 < len(command) && command[i+1] == '\n' {
+				i++
+			}
+			if command[i] == '\n' {
+				continue
+			}
+
+			current.WriteByte(command[i])
+			inWord = true
+		case c == '\'':
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated quote in curl command", errors.ErrInvalidArgument)
+			}
+
+			current.WriteString(command[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '$' && i+1 < len(command) && command[i+1] == '\'':
+			n, err := readANSIQuoted(command[i+2:], &current)
+			if err != nil {
+				return nil, err
+			}
+
+			i += n + 1
+			inWord = true
+		case c == '"':
+			n, err := readDoubleQuoted(command[i+1:], &current)
+			if err != nil {
+				return nil, err
+			}
+
+			i += n
+			inWord = true
+		default:
+			current.WriteByte(c)
+			inWord = true
+		}
+	}
+
+	flush()
+
+	return args, nil
+}
+
+// readDoubleQuoted reads up to the closing quote and returns the number of bytes consumed.
+// Inside double quotes a backslash only escapes $, `, ", \ and newlines.
+func readDoubleQuoted(s string, sb *strings.Builder) (int, error) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return i + 1, nil
+		case '\\':
+			if i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+				i++
+				if s[i] != '\n' {
+					sb.WriteByte(s[i])
+				}
+				continue
+			}
+
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unterminated quote in curl command", errors.ErrInvalidArgument)
+}
+
+// readANSIQuoted reads the content of a $'...' string up to the closing quote
+// and returns the number of bytes consumed.
+func readANSIQuoted(s string, sb *strings.Builder) (int, error) {
+	simple := map[byte]byte{
+		'n': '\n', 't': '\t', 'r': '\r', 'a': '\a', 'b': '\b', 'f': '\f', 'v': '\v',
+		'e': 0x1b, '\\': '\\', '\'': '\'', '"': '"', '?': '?',
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if c == '\'' {
+			return i + 1, nil
+		}
+
+		if c != '\\' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		escape := s[i]
+
+		if r, ok := simple[escape]; ok {
+			sb.WriteByte(r)
+			continue
+		}
+
+		switch escape {
+		case 'x', 'u', 'U':
+			maxDigits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[escape]
+			digits := hexDigits(s[i+1:], maxDigits)
+			if digits == "" {
+				sb.WriteByte('\\')
+				sb.WriteByte(escape)
+				continue
+			}
+
+			value, _ := strconv.ParseUint(digits, 16, 32)
+			if escape == 'x' {
+				sb.WriteByte(byte(value))
+			} else {
+				sb.WriteRune(rune(value))
+			}
+			i += len(digits)
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			end := i
+			for end < len(s) && end < i+3 && s[end] >= '0' && s[end] <= '7' {
+				end++
+			}
+
+			value, _ := strconv.ParseUint(s[i:end], 8, 16)
+			sb.WriteByte(byte(value))
+			i = end - 1
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(escape)
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unterminated quote in curl command", errors.ErrInvalidArgument)
+}
+
+func hexDigits(s string, max int) string {
+	n := 0
+	for n < len(s) && n < max && strings.IndexByte("0123456789abcdefABCDEF", s[n]) >= 0 {
+		n++
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+)
+
+func TestIntegrationImportFromCurl_ExecuteImported(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	command := `curl '` + http_server.GetServerURL() + `/echo?page=1' \
+  -X PATCH \
+  -H 'X-Trace: abc' \
+  -H 'Content-Type: application/json' \
+  -u user:pass \
+  --cookie 'session=42' \
+  --data-raw '{"name":"test"}' \
+  --compressed`
+
+	item, warnings, err := ImportFromCurl(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	client := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resp, err := client.Execute(item.Request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var echo struct {
+		Method  string              `json:"method"`
+		Headers map[string][]string `json:"headers"`
+		Body    string              `json:"body"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		t.Fatalf("failed to parse echo response: %v", err)
+	}
+
+	if echo.Method != "PATCH" || echo.Body != `{"name":"test"}` {
+		t.Errorf("unexpected echo %s %q", echo.Method, echo.Body)
+	}
+
+	expected := map[string]string{
+		"X-Trace":       "abc",
+		"Content-Type":  "application/json",
+		"Authorization": "Basic dXNlcjpwYXNz",
+		"Cookie":        "session=42",
+	}
+
+	for name, value := range expected {
+		if got := echo.Headers[name]; len(got) != 1 || got[0] != value {
+			t.Errorf("expected header %s=%s, got %v", name, value, got)
+		}
+	}
+}
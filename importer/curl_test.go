@@ -0,0 +1,311 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+)
+
+func TestUnitImportFromCurl_Simple(t *testing.T) {
+	item, warnings, err := ImportFromCurl(`curl https://api.example.com/users`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	if item.Name != "GET api.example.com/users" {
+		t.Errorf("unexpected name %q", item.Name)
+	}
+
+	if item.Request.Method != "GET" || item.Request.URL != "https://api.example.com/users" {
+		t.Errorf("unexpected request %s %s", item.Request.Method, item.Request.URL)
+	}
+
+	if item.Request.Body != nil {
+		t.Error("expected no body")
+	}
+}
+
+func TestUnitImportFromCurl_DevToolsCommand(t *testing.T) {
+	command := `curl 'https://api.example.com/users?page=2' \
+  -H 'accept: application/json' \
+  -H 'accept-encoding: gzip, deflate, br' \
+  -H 'authorization: Bearer abc.def.ghi' \
+  -H 'content-type: application/json' \
+  -b 'session=123; theme=dark' \
+  --data-raw $'{"name":"O\'Brien","note":"line\\nbreak"}' \
+  --compressed`
+
+	item, warnings, err := ImportFromCurl(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	req := item.Request
+	if req.Method != "POST" {
+		t.Errorf("expected POST for a command with data, got %s", req.Method)
+	}
+
+	if req.URL != "https://api.example.com/users?page=2" {
+		t.Errorf("unexpected URL %s", req.URL)
+	}
+
+	if req.Headers["accept"] != "application/json" {
+		t.Errorf("expected accept header, got %v", req.Headers)
+	}
+
+	if _, ok := req.Headers["accept-encoding"]; ok {
+		t.Error("expected accept-encoding to be left to the HTTP client")
+	}
+
+	if req.Headers["Cookie"] != "session=123; theme=dark" {
+		t.Errorf("expected cookie header, got %q", req.Headers["Cookie"])
+	}
+
+	if _, ok := req.Headers["authorization"]; ok {
+		t.Error("expected Authorization header to be converted to auth")
+	}
+
+	if req.Auth == nil || req.Auth.Type != "bearer" || req.Auth.Token != "abc.def.ghi" {
+		t.Errorf("expected bearer auth, got %+v", req.Auth)
+	}
+
+	if _, ok := req.Headers["content-type"]; ok {
+		t.Error("expected content type to be moved to the body")
+	}
+
+	if req.Body == nil || req.Body.Type != "json" || req.Body.ContentType != "application/json" {
+		t.Fatalf("expected JSON body, got %+v", req.Body)
+	}
+
+	if string(req.Body.Content) != `{"name":"O'Brien","note":"line\nbreak"}` {
+		t.Errorf("unexpected body %q", req.Body.Content)
+	}
+}
+
+func TestUnitImportFromCurl_Options(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		check   func(t *testing.T, method, url, body, contentType string)
+	}{
+		{
+			name:    "method and joined data",
+			command: `curl -XPUT -d a=1 --data "b=two words" localhost:8080/items`,
+			check: func(t *testing.T, method, url, body, contentType string) {
+				if method != "PUT" || url != "http://localhost:8080/items" {
+					t.Errorf("unexpected request %s %s", method, url)
+				}
+				if body != "a=1&b=two words" || contentType != "application/x-www-form-urlencoded" {
+					t.Errorf("unexpected body %q (%s)", body, contentType)
+				}
+			},
+		},
+		{
+			name:    "get moves data to query",
+			command: `curl -G -d q=go --data-urlencode "tag=a b" https://example.com/search?x=1`,
+			check: func(t *testing.T, method, url, body, contentType string) {
+				if method != "GET" || url != "https://example.com/search?x=1&q=go&tag=a+b" || body != "" {
+					t.Errorf("unexpected request %s %s %q", method, url, body)
+				}
+			},
+		},
+		{
+			name:    "head",
+			command: `curl -sSI https://example.com`,
+			check: func(t *testing.T, method, url, body, contentType string) {
+				if method != "HEAD" {
+					t.Errorf("expected HEAD, got %s", method)
+				}
+			},
+		},
+		{
+			name:    "json",
+			command: `curl --json '{"a":1}' https://example.com`,
+			check: func(t *testing.T, method, url, body, contentType string) {
+				if method != "POST" || body != `{"a":1}` || contentType != "application/json" {
+					t.Errorf("unexpected request %s %q (%s)", method, body, contentType)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, _, err := ImportFromCurl(tt.command)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var body, contentType string
+			if item.Request.Body != nil {
+				body = string(item.Request.Body.Content)
+				contentType = item.Request.Body.ContentType
+			}
+
+			tt.check(t, item.Request.Method, item.Request.URL, body, contentType)
+		})
+	}
+}
+
+func TestUnitImportFromCurl_BasicAuth(t *testing.T) {
+	item, _, err := ImportFromCurl(`curl -u admin:s3cret https://example.com`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	auth := item.Request.Auth
+	if auth == nil || auth.Type != "basic" || auth.Username != "admin" || auth.Password != "s3cret" {
+		t.Errorf("expected basic auth, got %+v", auth)
+	}
+
+	item, _, err = ImportFromCurl(`curl -H "Authorization: Basic YWRtaW46czNjcmV0" https://example.com`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	auth = item.Request.Auth
+	if auth == nil || auth.Username != "admin" || auth.Password != "s3cret" {
+		t.Errorf("expected Authorization header to be decoded, got %+v", auth)
+	}
+}
+
+func TestUnitImportFromCurl_Form(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	filePath := filepath.Join(dir, "avatar.png")
+	if err := os.WriteFile(filePath, []byte("PNGDATA"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	item, warnings, err := ImportFromCurl(`curl -F name=John -F "avatar=@` + filePath + `;type=image/png" https://example.com/upload`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	body := item.Request.Body
+	if body == nil || body.Type != "formdata" {
+		t.Fatalf("expected form body, got %+v", body)
+	}
+
+	if body.ContentType != "multipart/form-data; boundary="+formBoundary {
+		t.Errorf("unexpected content type %s", body.ContentType)
+	}
+
+	content := string(body.Content)
+	for _, expected := range []string{
+		`name="name"`,
+		"John",
+		`name="avatar"; filename="avatar.png"`,
+		"Content-Type: image/png",
+		"PNGDATA",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("expected form body to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestUnitImportFromCurl_ProxyAndTimeouts(t *testing.T) {
+	item, _, err := ImportFromCurl(`curl -x proxy.local:3128 -U bob:pw --noproxy "a.com, b.com" -m 2.5 --connect-timeout 1 https://example.com`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	proxy := item.Request.Proxy
+	if proxy == nil || proxy.URL != "http://proxy.local:3128" || proxy.Username != "bob" || proxy.Password != "pw" {
+		t.Fatalf("unexpected proxy %+v", proxy)
+	}
+
+	if len(proxy.NoProxy) != 2 || proxy.NoProxy[1] != "b.com" {
+		t.Errorf("unexpected no proxy list %v", proxy.NoProxy)
+	}
+
+	timeout := item.Request.Timeout
+	if timeout == nil || timeout.Read != 2500*time.Millisecond || timeout.Connect != time.Second {
+		t.Errorf("unexpected timeout %+v", timeout)
+	}
+}
+
+func TestUnitImportFromCurl_Warnings(t *testing.T) {
+	item, warnings, err := ImportFromCurl(`curl -k -o out.json --retry 3 -b cookies.txt --tlsv1.2 https://example.com`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if item.Request.URL != "https://example.com" {
+		t.Errorf("expected option values not to be taken as URL, got %s", item.Request.URL)
+	}
+
+	if item.Request.TLS == nil || !item.Request.TLS.InsecureSkipVerify {
+		t.Errorf("expected -k to skip certificate verification, got %+v", item.Request.TLS)
+	}
+
+	expected := []string{"--output", "--retry", "cookies.txt", "--tlsv1.2"}
+	if len(warnings) != len(expected) {
+		t.Fatalf("expected %d warnings, got %v", len(expected), warnings)
+	}
+
+	for i, part := range expected {
+		if !strings.Contains(warnings[i], part) {
+			t.Errorf("expected warning %d to mention %s, got %q", i, part, warnings[i])
+		}
+	}
+}
+
+func TestUnitImportFromCurl_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+	}{
+		{"not curl", `wget https://example.com`},
+		{"no URL", `curl -X POST`},
+		{"missing value", `curl https://example.com -H`},
+		{"unterminated quote", `curl 'https://example.com`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ImportFromCurl(tt.command); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestUnitSplitCurlCommand(t *testing.T) {
+	args, err := splitCurlCommand("curl \"a \\\"b\\\" \\$c\" 'd\\e' $'\\x41\\u00e9\\101' f\\ g \\\n  h ''")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"curl", `a "b" $c`, `d\e`, "AéA", "f g", "h", ""}
+	if len(args) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, args)
+	}
+
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("argument %d: expected %q, got %q", i, expected[i], args[i])
+		}
+	}
+}
@@ -0,0 +1,588 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+const (
+	harVersion = "1.2"
+	harCreator = "getman"
+)
+
+// harStaticExtensions and harStaticMimeTypes identify static assets such as
+// scripts, styles, images and fonts.
+var (
+	harStaticExtensions = map[string]bool{
+		".js": true, ".mjs": true, ".css": true, ".map": true,
+		".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".ico": true, ".webp": true, ".avif": true,
+		".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true,
+		".mp4": true, ".webm": true, ".mp3": true, ".wav": true,
+	}
+	harStaticMimeTypes = []string{
+		"image/", "font/", "audio/", "video/", "text/css",
+		"text/javascript", "application/javascript", "application/x-javascript", "application/font",
+	}
+)
+
+// HAR represents an HTTP Archive (HAR 1.2) document.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root object of a HAR document.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Pages   []HARPage  `json:"pages,omitempty"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator describes the application that created the HAR document.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HARPage represents a page the entries of a HAR document belong to.
+type HARPage struct {
+	StartedDateTime string `json:"startedDateTime"`
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+}
+
+// HAREntry represents a single request and its response.
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+// HARRequest represents a captured request.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARResponse represents a captured response.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie or query parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData represents the body of a captured request.
+type HARPostData struct {
+	MimeType string     `json:"mimeType"`
+	Text     string     `json:"text,omitempty"`
+	Params   []HARParam `json:"params,omitempty"`
+}
+
+// HARParam is a posted form parameter.
+type HARParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// HARContent represents the body of a captured response.
+// Binary content is base64 encoded, with Encoding set to "base64".
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings holds the phase durations of an entry in milliseconds, -1 when not available.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HAROptions controls which HAR entries are imported. Empty filters match every entry.
+type HAROptions struct {
+	// Domains keeps entries whose host is one of the domains or a subdomain of one.
+	Domains []string
+	// Methods keeps entries with one of the HTTP methods.
+	Methods []string
+	// ContentTypes keeps entries whose response content type starts with one of the values,
+	// e.g. "application/json".
+	ContentTypes []string
+	// KeepStaticAssets keeps scripts, styles, images, fonts and media, which are dropped by default.
+	KeepStaticAssets bool
+	// SaveResponses stores the captured responses as examples of the request items.
+	SaveResponses bool
+}
+
+// ImportFromHAR imports the entries of a HAR file as request items of a new collection.
+// The collection is named after the first page of the capture, or the file name.
+func ImportFromHAR(filePath string, opts *HAROptions) (*collections.Collection, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HAR file: %w", err)
+	}
+
+	var har HAR
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR file: %w", err)
+	}
+
+	if opts == nil {
+		opts = &HAROptions{}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	if len(har.Log.Pages) > 0 && har.Log.Pages[0].Title != "" {
+		name = har.Log.Pages[0].Title
+	}
+
+	collection := &collections.Collection{
+		Name:  name,
+		Items: []*types.RequestItem{},
+	}
+
+	for _, entry := range har.Log.Entries {
+		if !opts.matches(&entry) {
+			continue
+		}
+
+		req := convertHARRequest(&entry.Request)
+		item := &types.RequestItem{
+			Name:    requestItemName(req),
+			Request: req,
+		}
+
+		if opts.SaveResponses {
+			item.Examples = []*types.Response{convertHARResponse(&entry)}
+		}
+
+		collection.Items = append(collection.Items, item)
+	}
+
+	return collection, nil
+}
+
+func (opts *HAROptions) matches(entry *HAREntry) bool {
+	u, err := url.Parse(entry.Request.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+
+	if len(opts.Domains) > 0 && !matchesDomain(u.Hostname(), opts.Domains) {
+		return false
+	}
+
+	if len(opts.Methods) > 0 && !containsFold(opts.Methods, entry.Request.Method) {
+		return false
+	}
+
+	mimeType := mediaType(entry.Response.Content.MimeType)
+
+	if len(opts.ContentTypes) > 0 {
+		matched := false
+		for _, contentType := range opts.ContentTypes {
+			if strings.HasPrefix(mimeType, strings.ToLower(contentType)) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return opts.KeepStaticAssets || !isStaticAsset(u, mimeType)
+}
+
+func matchesDomain(host string, domains []string) bool {
+	host = strings.ToLower(host)
+
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isStaticAsset(u *url.URL, mimeType string) bool {
+	if harStaticExtensions[strings.ToLower(path.Ext(u.Path))] {
+		return true
+	}
+
+	for _, prefix := range harStaticMimeTypes {
+		if strings.HasPrefix(mimeType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func convertHARRequest(harReq *HARRequest) *types.Request {
+	req := &types.Request{
+		Method:  strings.ToUpper(harReq.Method),
+		URL:     harReq.URL,
+		Headers: make(map[string]string),
+	}
+
+	for _, header := range harReq.Headers {
+		// HTTP/2 pseudo headers such as :authority are part of the URL.
+		if strings.HasPrefix(header.Name, ":") || clientManagedHeaders[strings.ToLower(header.Name)] {
+			continue
+		}
+
+		appendHeader(req.Headers, header.Name, header.Value)
+	}
+
+	if postData := harReq.PostData; postData != nil {
+		req.Body = convertHARPostData(postData)
+	}
+
+	if key, ok := findHeader(req.Headers, "Content-Type"); ok && req.Body != nil {
+		// Bodies built from form params have their own content type and boundary,
+		// otherwise the captured header is kept with all its parameters.
+		if harReq.PostData.Text != "" {
+			req.Body.ContentType = req.Headers[key]
+		}
+		delete(req.Headers, key)
+	}
+
+	return req
+}
+
+func convertHARPostData(postData *HARPostData) *types.RequestBody {
+	if postData.Text != "" {
+		return &types.RequestBody{
+			Type:        bodyTypeFor(postData.MimeType),
+			Content:     []byte(postData.Text),
+			ContentType: postData.MimeType,
+		}
+	}
+
+	if len(postData.Params) == 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(mediaType(postData.MimeType), "multipart/form-data") {
+		var fields []formField
+		for _, param := range postData.Params {
+			fields = append(fields, formField{
+				name:     param.Name,
+				value:    param.Value,
+				filename: param.FileName,
+				mimeType: param.ContentType,
+			})
+		}
+
+		body, err := multipartBody(fields)
+		if err != nil {
+			return nil
+		}
+
+		return body
+	}
+
+	values := url.Values{}
+	for _, param := range postData.Params {
+		values.Add(param.Name, param.Value)
+	}
+
+	return &types.RequestBody{
+		Type:        "urlencoded",
+		Content:     []byte(values.Encode()),
+		ContentType: "application/x-www-form-urlencoded",
+	}
+}
+
+func convertHARResponse(entry *HAREntry) *types.Response {
+	harResp := &entry.Response
+
+	headers := make(map[string][]string)
+	for _, header := range harResp.Headers {
+		headers[header.Name] = append(headers[header.Name], header.Value)
+	}
+
+	body := []byte(harResp.Content.Text)
+	if harResp.Content.Encoding == "base64" {
+		if decoded, err := base64.StdEncoding.DecodeString(harResp.Content.Text); err == nil {
+			body = decoded
+		}
+	}
+
+	size := harResp.Content.Size
+	if size <= 0 {
+		size = int64(len(body))
+	}
+
+	return &types.Response{
+		StatusCode: harResp.Status,
+		Status:     strings.TrimSpace(fmt.Sprintf("%d %s", harResp.Status, harResp.StatusText)),
+		Headers:    headers,
+		Body:       body,
+		Duration:   time.Duration(entry.Time * float64(time.Millisecond)),
+		Size:       size,
+	}
+}
+
+// ExportToHAR writes the requests of an execution result as a HAR 1.2 file,
+// so that a run can be opened in browser developer tools and other HAR viewers.
+func ExportToHAR(result *types.ExecutionResult, filePath string) error {
+	har, err := NewHAR(result)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal HAR: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write HAR file: %w", err)
+	}
+
+	return nil
+}
+
+// NewHAR converts an execution result into a HAR document. Requests that failed
+// without a response are kept with status 0 and the error in the _error field.
+func NewHAR(result *types.ExecutionResult) (*HAR, error) {
+	if result == nil {
+		return nil, fmt.Errorf("%w: execution result is nil", errors.ErrInvalidArgument)
+	}
+
+	har := &HAR{
+		Log: HARLog{
+			Version: harVersion,
+			Creator: HARCreator{Name: harCreator},
+			Entries: []HAREntry{},
+		},
+	}
+
+	for _, execution := range result.Requests {
+		if execution == nil || execution.Request == nil {
+			continue
+		}
+
+		har.Log.Entries = append(har.Log.Entries, harEntry(execution))
+	}
+
+	return har, nil
+}
+
+func harEntry(execution *types.RequestExecution) HAREntry {
+	started := execution.Timestamp.Add(-execution.Duration)
+
+	entry := HAREntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            milliseconds(execution.Duration),
+		Request:         harRequest(execution.Request),
+		Response:        harResponse(execution.Response),
+		Timings:         harTimings(execution),
+		Error:           execution.Error,
+	}
+
+	return entry
+}
+
+func harRequest(req *types.Request) HARRequest {
+	harReq := HARRequest{
+		Method:      req.Method,
+		URL:         req.URL,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameValue{},
+		Headers:     []HARNameValue{},
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+	}
+
+	// The request is rebuilt to include auth and the content type as they were sent.
+	httpReq, err := core.NewHTTPRequest(req)
+	if err != nil {
+		for name, value := range req.Headers {
+			harReq.Headers = append(harReq.Headers, HARNameValue{Name: name, Value: value})
+		}
+	} else {
+		harReq.URL = httpReq.URL.String()
+		harReq.Headers = harNameValues(httpReq.Header)
+
+		for _, cookie := range httpReq.Cookies() {
+			harReq.Cookies = append(harReq.Cookies, HARNameValue{Name: cookie.Name, Value: cookie.Value})
+		}
+
+		harReq.QueryString = harNameValues(httpReq.URL.Query())
+	}
+
+	// GraphQL bodies are exported encoded, as they are sent.
+	content, err := core.RequestContent(req)
+	if err != nil && req.Body != nil {
+		content = req.Body.Content
+	}
+
+	if len(content) > 0 {
+		mimeType := req.Body.ContentType
+		if httpReq != nil {
+			mimeType = httpReq.Header.Get("Content-Type")
+		}
+
+		harReq.PostData = &HARPostData{
+			MimeType: mimeType,
+			Text:     string(content),
+		}
+		harReq.BodySize = int64(len(content))
+	}
+
+	return harReq
+}
+
+func harResponse(resp *types.Response) HARResponse {
+	harResp := HARResponse{
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameValue{},
+		Headers:     []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if resp == nil {
+		return harResp
+	}
+
+	harResp.Status = resp.StatusCode
+	harResp.StatusText = strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode)))
+	harResp.Headers = harNameValues(resp.Headers)
+	harResp.BodySize = resp.Size
+
+	for name, values := range resp.Headers {
+		switch strings.ToLower(name) {
+		case "content-type":
+			harResp.Content.MimeType = values[0]
+		case "location":
+			harResp.RedirectURL = values[0]
+		}
+	}
+
+	// The size of a truncated body is the one received, not the part that was kept.
+	harResp.Content.Size = int64(len(resp.Body))
+	if resp.Truncated {
+		harResp.Content.Size = resp.Size
+	}
+	if utf8.Valid(resp.Body) {
+		harResp.Content.Text = string(resp.Body)
+	} else {
+		harResp.Content.Text = base64.StdEncoding.EncodeToString(resp.Body)
+		harResp.Content.Encoding = "base64"
+	}
+
+	return harResp
+}
+
+func harTimings(execution *types.RequestExecution) HARTimings {
+	timings := HARTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: 0, Wait: milliseconds(execution.Duration), Receive: 0}
+
+	if execution.Response == nil || execution.Response.Timings == nil {
+		return timings
+	}
+
+	t := execution.Response.Timings
+	timings.DNS = milliseconds(t.DNSLookup)
+	timings.Connect = milliseconds(t.TCPConnect + t.TLSHandshake)
+	timings.SSL = milliseconds(t.TLSHandshake)
+	timings.Receive = milliseconds(t.ContentTransfer)
+
+	// HAR counts the wait from the request being sent to the first byte.
+	wait := t.TimeToFirstByte - t.DNSLookup - t.TCPConnect - t.TLSHandshake
+	if wait < 0 {
+		wait = 0
+	}
+	timings.Wait = milliseconds(wait)
+
+	return timings
+}
+
+func harNameValues(values map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []HARNameValue{}
+	for _, name := range names {
+		for _, value := range values[name] {
+			result = append(result, HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	return result
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func mediaType(contentType string) string {
+	mimeType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationHAR_ExportAndImport(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	req := &types.Request{
+		Method:  "PUT",
+		URL:     http_server.GetServerURL() + "/echo",
+		Headers: map[string]string{"X-Trace": "abc"},
+		Body:    &types.RequestBody{Type: "json", Content: []byte(`{"name":"test"}`), ContentType: "application/json"},
+	}
+
+	client := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	startTime := time.Now()
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := &types.ExecutionResult{
+		CollectionName: "echo",
+		Requests: []*types.RequestExecution{
+			{Request: req, Response: resp, Duration: time.Since(startTime), Timestamp: time.Now()},
+		},
+	}
+
+	filePath := filepath.Join(dir, "run.har")
+	if err := ExportToHAR(result, filePath); err != nil {
+		t.Fatalf("failed to export HAR: %v", err)
+	}
+
+	collection, err := ImportFromHAR(filePath, &HAROptions{SaveResponses: true})
+	if err != nil {
+		t.Fatalf("failed to import HAR: %v", err)
+	}
+
+	if len(collection.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(collection.Items))
+	}
+
+	item := collection.Items[0]
+	imported := item.Request
+
+	if imported.Method != "PUT" || imported.URL != req.URL || imported.Headers["X-Trace"] != "abc" {
+		t.Errorf("unexpected imported request %s %s %v", imported.Method, imported.URL, imported.Headers)
+	}
+
+	if imported.Body == nil || string(imported.Body.Content) != `{"name":"test"}` || imported.Body.ContentType != "application/json" {
+		t.Errorf("unexpected imported body %+v", imported.Body)
+	}
+
+	if len(item.Examples) != 1 || item.Examples[0].StatusCode != resp.StatusCode || string(item.Examples[0].Body) != string(resp.Body) {
+		t.Errorf("expected the response as example, got %+v", item.Examples)
+	}
+}
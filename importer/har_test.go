@@ -0,0 +1,379 @@
+package importer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+const testHAR = `{
+	"log": {
+		"version": "1.2",
+		"creator": {"name": "WebInspector", "version": "537.36"},
+		"pages": [{"startedDateTime": "2025-01-01T10:00:00.000Z", "id": "page_1", "title": "My App"}],
+		"entries": [
+			{
+				"startedDateTime": "2025-01-01T10:00:00.000Z",
+				"time": 120.5,
+				"request": {
+					"method": "POST",
+					"url": "https://api.example.com/v1/users?invite=true",
+					"httpVersion": "HTTP/2",
+					"headers": [
+						{"name": ":authority", "value": "api.example.com"},
+						{"name": "content-type", "value": "application/json; charset=utf-8"},
+						{"name": "accept-encoding", "value": "gzip, br"},
+						{"name": "x-trace", "value": "1"},
+						{"name": "x-trace", "value": "2"}
+					],
+					"queryString": [{"name": "invite", "value": "true"}],
+					"cookies": [],
+					"headersSize": -1,
+					"bodySize": 15,
+					"postData": {"mimeType": "application/json; charset=utf-8", "text": "{\"name\":\"Ann\"}"}
+				},
+				"response": {
+					"status": 201,
+					"statusText": "Created",
+					"httpVersion": "HTTP/2",
+					"headers": [{"name": "content-type", "value": "application/json"}],
+					"cookies": [],
+					"content": {"size": 9, "mimeType": "application/json", "text": "eyJpZCI6MX0=", "encoding": "base64"},
+					"redirectURL": "",
+					"headersSize": -1,
+					"bodySize": 9
+				},
+				"cache": {},
+				"timings": {"blocked": -1, "dns": -1, "connect": -1, "ssl": -1, "send": 0, "wait": 100, "receive": 20.5}
+			},
+			{
+				"startedDateTime": "2025-01-01T10:00:01.000Z",
+				"time": 10,
+				"request": {
+					"method": "POST",
+					"url": "https://auth.example.com/login",
+					"httpVersion": "HTTP/1.1",
+					"headers": [{"name": "Content-Type", "value": "application/x-www-form-urlencoded"}],
+					"queryString": [],
+					"cookies": [],
+					"headersSize": -1,
+					"bodySize": -1,
+					"postData": {"mimeType": "application/x-www-form-urlencoded", "params": [{"name": "user", "value": "ann"}, {"name": "pass", "value": "a b"}]}
+				},
+				"response": {"status": 302, "statusText": "Found", "httpVersion": "HTTP/1.1", "headers": [], "cookies": [], "content": {"size": 0, "mimeType": "text/html"}, "redirectURL": "/", "headersSize": -1, "bodySize": 0},
+				"cache": {},
+				"timings": {"send": 0, "wait": 10, "receive": 0}
+			},
+			{
+				"startedDateTime": "2025-01-01T10:00:02.000Z",
+				"time": 5,
+				"request": {"method": "GET", "url": "https://cdn.example.com/app.js", "httpVersion": "HTTP/1.1", "headers": [], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+				"response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/1.1", "headers": [], "cookies": [], "content": {"size": 100, "mimeType": "application/javascript"}, "redirectURL": "", "headersSize": -1, "bodySize": 100},
+				"cache": {},
+				"timings": {"send": 0, "wait": 5, "receive": 0}
+			},
+			{
+				"startedDateTime": "2025-01-01T10:00:03.000Z",
+				"time": 5,
+				"request": {"method": "GET", "url": "https://www.other.org/logo", "httpVersion": "HTTP/1.1", "headers": [], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+				"response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/1.1", "headers": [], "cookies": [], "content": {"size": 100, "mimeType": "image/png"}, "redirectURL": "", "headersSize": -1, "bodySize": 100},
+				"cache": {},
+				"timings": {"send": 0, "wait": 5, "receive": 0}
+			},
+			{
+				"startedDateTime": "2025-01-01T10:00:04.000Z",
+				"time": 1,
+				"request": {"method": "GET", "url": "data:image/png;base64,AAAA", "httpVersion": "HTTP/1.1", "headers": [], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+				"response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/1.1", "headers": [], "cookies": [], "content": {"size": 3, "mimeType": "image/png"}, "redirectURL": "", "headersSize": -1, "bodySize": 3},
+				"cache": {},
+				"timings": {"send": 0, "wait": 1, "receive": 0}
+			}
+		]
+	}
+}`
+
+func writeHAR(t *testing.T) (string, func()) {
+	t.Helper()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	filePath := filepath.Join(dir, "capture.har")
+	if err := os.WriteFile(filePath, []byte(testHAR), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	return filePath, func() { helper.CleanupTempDir(dir) }
+}
+
+func TestUnitImportFromHAR(t *testing.T) {
+	filePath, cleanup := writeHAR(t)
+	defer cleanup()
+
+	collection, err := ImportFromHAR(filePath, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if collection.Name != "My App" {
+		t.Errorf("expected collection to be named after the page, got %q", collection.Name)
+	}
+
+	if len(collection.Items) != 2 {
+		t.Fatalf("expected static assets to be dropped, got %d items", len(collection.Items))
+	}
+
+	create := collection.Items[0]
+	if create.Name != "POST api.example.com/v1/users" {
+		t.Errorf("unexpected name %q", create.Name)
+	}
+
+	req := create.Request
+	if req.URL != "https://api.example.com/v1/users?invite=true" {
+		t.Errorf("unexpected URL %s", req.URL)
+	}
+
+	if len(req.Headers) != 1 || req.Headers["x-trace"] != "1, 2" {
+		t.Errorf("expected only the joined x-trace header, got %v", req.Headers)
+	}
+
+	if req.Body == nil || req.Body.Type != "json" || req.Body.ContentType != "application/json; charset=utf-8" || string(req.Body.Content) != `{"name":"Ann"}` {
+		t.Errorf("unexpected body %+v", req.Body)
+	}
+
+	if create.Examples != nil {
+		t.Error("expected no examples without SaveResponses")
+	}
+
+	login := collection.Items[1].Request
+	if login.Body == nil || login.Body.Type != "urlencoded" || string(login.Body.Content) != "pass=a+b&user=ann" {
+		t.Errorf("expected form params as urlencoded body, got %+v", login.Body)
+	}
+}
+
+func TestUnitImportFromHAR_Filters(t *testing.T) {
+	filePath, cleanup := writeHAR(t)
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		opts     *HAROptions
+		expected []string
+	}{
+		{"domain", &HAROptions{Domains: []string{"auth.example.com"}}, []string{"POST auth.example.com/login"}},
+		{"parent domain", &HAROptions{Domains: []string{"example.com"}, KeepStaticAssets: true}, []string{"POST api.example.com/v1/users", "POST auth.example.com/login", "GET cdn.example.com/app.js"}},
+		{"method", &HAROptions{Methods: []string{"get"}, KeepStaticAssets: true}, []string{"GET cdn.example.com/app.js", "GET www.other.org/logo"}},
+		{"content type", &HAROptions{ContentTypes: []string{"application/json"}}, []string{"POST api.example.com/v1/users"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, err := ImportFromHAR(filePath, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, item := range collection.Items {
+				names = append(names, item.Name)
+			}
+
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestUnitImportFromHAR_SaveResponses(t *testing.T) {
+	filePath, cleanup := writeHAR(t)
+	defer cleanup()
+
+	collection, err := ImportFromHAR(filePath, &HAROptions{SaveResponses: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	examples := collection.Items[0].Examples
+	if len(examples) != 1 {
+		t.Fatalf("expected 1 example, got %d", len(examples))
+	}
+
+	example := examples[0]
+	if example.StatusCode != 201 || example.Status != "201 Created" {
+		t.Errorf("unexpected status %d %q", example.StatusCode, example.Status)
+	}
+
+	if string(example.Body) != `{"id":1}` {
+		t.Errorf("expected base64 content to be decoded, got %q", example.Body)
+	}
+
+	if example.Duration != 120500*time.Microsecond {
+		t.Errorf("unexpected duration %v", example.Duration)
+	}
+}
+
+func TestUnitImportFromHAR_Invalid(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	filePath := filepath.Join(dir, "invalid.har")
+	if err := os.WriteFile(filePath, []byte("{invalid"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	if _, err := ImportFromHAR(filePath, nil); err == nil {
+		t.Error("expected error for invalid HAR")
+	}
+
+	if _, err := ImportFromHAR(filepath.Join(dir, "missing.har"), nil); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestUnitNewHAR(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 10, 0, 1, 0, time.UTC)
+	result := &types.ExecutionResult{
+		Requests: []*types.RequestExecution{
+			{
+				Request: &types.Request{
+					Method:  "POST",
+					URL:     "https://api.example.com/items?x=1",
+					Headers: map[string]string{"Cookie": "a=1"},
+					Body:    &types.RequestBody{Type: "json", Content: []byte(`{"a":1}`), ContentType: "application/json"},
+					Auth:    &types.Auth{Type: "bearer", Token: "secret"},
+				},
+				Response: &types.Response{
+					StatusCode: 200,
+					Status:     "200 OK",
+					Headers:    map[string][]string{"Content-Type": {"application/json"}},
+					Body:       []byte(`{"ok":true}`),
+					Size:       11,
+					Timings: &types.Timings{
+						DNSLookup:       5 * time.Millisecond,
+						TCPConnect:      10 * time.Millisecond,
+						TimeToFirstByte: 40 * time.Millisecond,
+						ContentTransfer: 2 * time.Millisecond,
+					},
+				},
+				Duration:  time.Second,
+				Timestamp: timestamp,
+			},
+			{
+				Request:   &types.Request{Method: "GET", URL: "https://down.example.com"},
+				Error:     "request failed: connection refused",
+				Timestamp: timestamp,
+			},
+		},
+	}
+
+	har, err := NewHAR(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 2 {
+		t.Fatalf("unexpected log %+v", har.Log)
+	}
+
+	entry := har.Log.Entries[0]
+	if entry.StartedDateTime != "2025-01-01T10:00:00Z" || entry.Time != 1000 {
+		t.Errorf("unexpected start %s and time %v", entry.StartedDateTime, entry.Time)
+	}
+
+	headers := make(map[string]string)
+	for _, h := range entry.Request.Headers {
+		headers[h.Name] = h.Value
+	}
+
+	if headers["Authorization"] != "Bearer secret" || headers["Content-Type"] != "application/json" {
+		t.Errorf("expected headers as sent, got %v", headers)
+	}
+
+	if len(entry.Request.Cookies) != 1 || len(entry.Request.QueryString) != 1 {
+		t.Errorf("expected cookies and query string, got %+v", entry.Request)
+	}
+
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != `{"a":1}` {
+		t.Errorf("unexpected post data %+v", entry.Request.PostData)
+	}
+
+	if entry.Response.Status != 200 || entry.Response.StatusText != "OK" || entry.Response.Content.MimeType != "application/json" {
+		t.Errorf("unexpected response %+v", entry.Response)
+	}
+
+	if entry.Timings.DNS != 5 || entry.Timings.Connect != 10 || entry.Timings.Wait != 25 || entry.Timings.Receive != 2 {
+		t.Errorf("unexpected timings %+v", entry.Timings)
+	}
+
+	failed := har.Log.Entries[1]
+	if failed.Response.Status != 0 || failed.Error != "request failed: connection refused" {
+		t.Errorf("expected failed entry with error, got %+v", failed)
+	}
+
+	data, err := json.Marshal(har)
+	if err != nil {
+		t.Fatalf("failed to marshal HAR: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"cache":{}`) {
+		t.Errorf("expected cache object in HAR, got %s", data)
+	}
+
+	if _, err := NewHAR(nil); err == nil {
+		t.Error("expected error for nil result")
+	}
+}
+
+func TestUnitNewHAR_GraphQLAndTruncated(t *testing.T) {
+	result := &types.ExecutionResult{
+		Requests: []*types.RequestExecution{
+			{
+				Request: &types.Request{
+					Method: "POST",
+					URL:    "https://api.example.com/graphql",
+					Body: &types.RequestBody{
+						Type:    "graphql",
+						GraphQL: &types.GraphQLBody{Query: "{ me { id } }"},
+					},
+				},
+				Response: &types.Response{
+					StatusCode: 200,
+					Status:     "200 OK",
+					Body:       []byte(`{"data":`),
+					Size:       1024,
+					Truncated:  true,
+				},
+			},
+		},
+	}
+
+	har, err := NewHAR(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entry := har.Log.Entries[0]
+	postData := entry.Request.PostData
+	if postData == nil || postData.Text != `{"query":"{ me { id } }"}` || postData.MimeType != "application/json" {
+		t.Errorf("expected the encoded GraphQL body, got %+v", postData)
+	}
+	if entry.Request.BodySize != int64(len(postData.Text)) {
+		t.Errorf("unexpected body size %d", entry.Request.BodySize)
+	}
+
+	if entry.Response.Content.Size != 1024 {
+		t.Errorf("expected the received size of a truncated body, got %d", entry.Response.Content.Size)
+	}
+}
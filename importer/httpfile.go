@@ -0,0 +1,564 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// HTTPEnvFile is the name of the environments file of the JetBrains HTTP client.
+// HTTPPrivateEnvFile holds the secret values that are kept out of version control.
+const (
+	HTTPEnvFile        = "http-client.env.json"
+	HTTPPrivateEnvFile = "http-client.private.env.json"
+)
+
+// httpFileMethods are the request methods recognized at the start of a request line.
+var httpFileMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true,
+	"HEAD": true, "OPTIONS": true, "TRACE": true, "CONNECT": true, "GRAPHQL": true,
+}
+
+var (
+	httpFileDynamicVariable = regexp.MustCompile(`\{\{\s*\$`)
+	httpFileRequestVariable = regexp.MustCompile(`\{\{\s*[\w-]+\.(request|response)\.`)
+)
+
+// ImportFromHTTPFile imports a .http or .rest file of the JetBrains HTTP client or
+// VS Code REST Client as a collection. The environments of an http-client.env.json
+// next to the file are imported together with the @variables of the file.
+func ImportFromHTTPFile(filePath string) (*ImportResult, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HTTP file: %w", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	parser := &httpFileParser{
+		result: &ImportResult{},
+		dir:    filepath.Dir(filePath),
+		vars:   make(map[string]string),
+	}
+
+	collection := &collections.Collection{
+		Name:  name,
+		Items: parser.parse(string(data)),
+	}
+
+	var envs []*environment.Environment
+	envPath := filepath.Join(parser.dir, HTTPEnvFile)
+	if _, err := os.Stat(envPath); err == nil {
+		if envs, err = ImportHTTPEnvironments(envPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(envs) == 0 && len(parser.vars) > 0 {
+		envs = append(envs, environment.NewEnvironment(name))
+	}
+
+	// File variables take precedence over environment variables, as in both tools.
+	for _, env := range envs {
+		for key, value := range parser.vars {
+			env.Set(key, value)
+		}
+	}
+
+	if len(envs) > 0 {
+		collection.EnvName = envs[0].Name
+	}
+
+	parser.result.Collections = append(parser.result.Collections, collection)
+	parser.result.Environments = append(parser.result.Environments, envs...)
+
+	return parser.result, nil
+}
+
+// ImportHTTPEnvironments imports the environments of an http-client.env.json file.
+// Variables of the "$shared" environment are added to every environment, and values
+// of an http-client.private.env.json next to the file override the public ones.
+func ImportHTTPEnvironments(filePath string) ([]*environment.Environment, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HTTP environments file: %w", err)
+	}
+
+	var envFile map[string]map[string]any
+	if err := json.Unmarshal(data, &envFile); err != nil {
+		return nil, fmt.Errorf("failed to parse HTTP environments file: %w", err)
+	}
+
+	privatePath := filepath.Join(filepath.Dir(filePath), HTTPPrivateEnvFile)
+	var privateFile map[string]map[string]any
+	if privateData, err := os.ReadFile(privatePath); err == nil {
+		if err := json.Unmarshal(privateData, &privateFile); err != nil {
+			return nil, fmt.Errorf("failed to parse HTTP private environments file: %w", err)
+		}
+	}
+
+	names := make([]string, 0, len(envFile))
+	for name := range envFile {
+		if name != "$shared" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	envs := make([]*environment.Environment, 0, len(names))
+	for _, name := range names {
+		env := environment.NewEnvironment(name)
+		for _, vars := range []map[string]any{envFile["$shared"], privateFile["$shared"], envFile[name], privateFile[name]} {
+			for key, value := range vars {
+				switch v := value.(type) {
+				case string:
+					env.Set(key, v)
+				case map[string]any, []any:
+					// Settings such as SSLConfiguration are not variables.
+				default:
+					encoded, _ := json.Marshal(v)
+					env.Set(key, string(encoded))
+				}
+			}
+		}
+		envs = append(envs, env)
+	}
+
+	return envs, nil
+}
+
+type httpFileParser struct {
+	result *ImportResult
+	dir    string
+	vars   map[string]string
+}
+
+// parse splits the file into ### separated requests.
+func (p *httpFileParser) parse(content string) []*types.RequestItem {
+	items := []*types.RequestItem{}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var title string
+	var block []string
+	flush := func() {
+		if item := p.parseRequest(title, block); item != nil {
+			items = append(items, item)
+		}
+		block = nil
+	}
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "###") {
+			flush()
+			title = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+
+	return items
+}
+
+func (p *httpFileParser) parseRequest(title string, lines []string) *types.RequestItem {
+	var name, folder, comment string
+
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+
+		if text, ok := httpFileComment(line); ok {
+			if meta, ok := strings.CutPrefix(text, "@"); ok {
+				key, value, _ := strings.Cut(meta, " ")
+				value = strings.TrimSpace(value)
+				switch key {
+				case "name":
+					name = value
+				case "folder":
+					folder = value
+				case "note":
+				default:
+					p.result.warn("request %q: @%s is not supported", httpFileContext(name, title, comment), key)
+				}
+				continue
+			}
+			if comment == "" {
+				comment = text
+			}
+			continue
+		}
+
+		if variable, ok := strings.CutPrefix(line, "@"); ok {
+			key, value, _ := strings.Cut(variable, "=")
+			p.vars[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			continue
+		}
+
+		break
+	}
+
+	if i == len(lines) {
+		return nil
+	}
+	context := httpFileContext(name, title, comment)
+
+	fields := strings.Fields(lines[i])
+	method := "GET"
+	if len(fields) > 1 && (httpFileMethods[fields[0]] || fields[0] == "WEBSOCKET" || fields[0] == "GRPC") {
+		method = fields[0]
+		fields = fields[1:]
+	}
+	if len(fields) > 1 && strings.HasPrefix(fields[len(fields)-1], "HTTP/") {
+		fields = fields[:len(fields)-1]
+	}
+
+	if method == "WEBSOCKET" || method == "GRPC" {
+		p.result.warn("request %q: %s requests are not supported", context, method)
+		return nil
+	}
+
+	req := &types.Request{
+		Method:  method,
+		URL:     strings.Join(fields, ""),
+		Headers: make(map[string]string),
+	}
+
+	// Long query strings may continue on indented ? and & lines.
+	for i++; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "?") && !strings.HasPrefix(line, "&") {
+			break
+		}
+		req.URL += line
+	}
+
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			i++
+			break
+		}
+		if _, ok := httpFileComment(line); ok {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			p.result.warn("request %q: invalid header line %q was ignored", context, line)
+			continue
+		}
+		appendHeader(req.Headers, strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	body := p.parseBody(lines[i:], context)
+
+	graphQL := method == "GRAPHQL"
+	if key, ok := findHeader(req.Headers, "X-Request-Type"); ok && strings.EqualFold(req.Headers[key], "GraphQL") {
+		delete(req.Headers, key)
+		graphQL = true
+	}
+
+	switch {
+	case graphQL:
+		req.Method = "POST"
+		query, variables := splitGraphQLBody(body)
+		req.Body = convertPostmanGraphQL(&PostmanGraphQL{Query: query, Variables: variables})
+	case body != "":
+		req.Body = &types.RequestBody{Type: "raw", Content: []byte(body)}
+		if key, ok := findHeader(req.Headers, "Content-Type"); ok && strings.HasPrefix(req.Headers[key], "multipart/") {
+			req.Body.Content = []byte(strings.ReplaceAll(body, "\n", "\r\n") + "\r\n")
+		}
+	}
+	moveContentType(req)
+
+	req.Auth = httpFileAuth(req.Headers)
+
+	p.warnVariables(req, body, context)
+
+	if name == "" {
+		name = title
+	}
+	if name == "" {
+		name = comment
+	}
+	if name == "" {
+		name = requestItemName(req)
+	}
+
+	return &types.RequestItem{
+		Name:    name,
+		Folder:  folder,
+		Request: req,
+	}
+}
+
+// parseBody reads the request body. Response handlers and redirects are dropped,
+// and "< path" lines are replaced with the content of the file.
+func (p *httpFileParser) parseBody(lines []string, context string) string {
+	var body []string
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.HasPrefix(line, "> {%"):
+			p.result.warn("request %q: response handler scripts are not supported", context)
+			for ; i < len(lines) && !strings.Contains(lines[i], "%}"); i++ {
+			}
+		case strings.HasPrefix(line, ">>"), strings.HasPrefix(line, "> "):
+			p.result.warn("request %q: response handlers and redirects are not supported", context)
+		case strings.HasPrefix(line, "<> "):
+		case strings.HasPrefix(line, "< "):
+			path := strings.TrimSpace(strings.TrimPrefix(line, "< "))
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(p.dir, path)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				p.result.warn("request %q: body file %s could not be read", context, path)
+				continue
+			}
+			body = append(body, strings.TrimSuffix(string(content), "\n"))
+		default:
+			body = append(body, line)
+		}
+	}
+
+	return strings.TrimRight(strings.Join(body, "\n"), " \t\n")
+}
+
+func (p *httpFileParser) warnVariables(req *types.Request, body, context string) {
+	text := req.URL + "\n" + body
+	for key, value := range req.Headers {
+		text += "\n" + key + ": " + value
+	}
+	if req.Auth != nil {
+		text += "\n" + req.Auth.Username + req.Auth.Password + req.Auth.Token
+	}
+
+	if httpFileDynamicVariable.MatchString(text) {
+		p.result.warn("request %q: dynamic variables such as {{$guid}} are not supported", context)
+	}
+	if httpFileRequestVariable.MatchString(text) {
+		p.result.warn("request %q: references to other requests are not supported", context)
+	}
+}
+
+// httpFileComment returns the text of a # or // comment line.
+func httpFileComment(line string) (string, bool) {
+	for _, prefix := range []string{"#", "//"} {
+		if text, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.TrimSpace(text), true
+		}
+	}
+	return "", false
+}
+
+func httpFileContext(name, title, comment string) string {
+	for _, context := range []string{name, title, comment} {
+		if context != "" {
+			return context
+		}
+	}
+	return "unnamed"
+}
+
+// splitGraphQLBody splits a GraphQL body into the query and the JSON variables
+// that follow it after an empty line.
+func splitGraphQLBody(body string) (string, string) {
+	index := strings.LastIndex(body, "\n\n")
+	if index < 0 {
+		return body, ""
+	}
+
+	variables := strings.TrimSpace(body[index:])
+	if !strings.HasPrefix(variables, "{") {
+		return body, ""
+	}
+
+	return strings.TrimSpace(body[:index]), variables
+}
+
+// httpFileAuth converts an Authorization header to auth settings. Both tools also
+// accept basic credentials that are not base64 encoded, as "user:password" or "user password".
+func httpFileAuth(headers map[string]string) *types.Auth {
+	key, ok := findHeader(headers, "Authorization")
+	if !ok {
+		return nil
+	}
+
+	scheme, credentials, _ := strings.Cut(headers[key], " ")
+	credentials = strings.TrimSpace(credentials)
+	if strings.EqualFold(scheme, "Basic") {
+		if _, err := base64.StdEncoding.DecodeString(credentials); err != nil || strings.Contains(credentials, "{{") {
+			username, password, found := strings.Cut(credentials, ":")
+			if !found {
+				username, password, _ = strings.Cut(credentials, " ")
+			}
+			delete(headers, key)
+			return &types.Auth{Type: "basic", Username: strings.TrimSpace(username), Password: strings.TrimSpace(password)}
+		}
+	}
+
+	return authFromHeader(headers)
+}
+
+// ExportToHTTPFile writes a collection as a .http file. Request settings that the format
+// can not express, such as timeouts, proxies and streaming, are not exported.
+func ExportToHTTPFile(collection *collections.Collection, filePath string) error {
+	if collection == nil {
+		return fmt.Errorf("%w: collection is nil", errors.ErrInvalidArgument)
+	}
+
+	if err := os.WriteFile(filePath, []byte(formatHTTPFile(collection)), 0644); err != nil {
+		return fmt.Errorf("failed to write HTTP file: %w", err)
+	}
+
+	return nil
+}
+
+func formatHTTPFile(collection *collections.Collection) string {
+	var buf bytes.Buffer
+
+	for i, item := range collection.Items {
+		if item == nil || item.Request == nil {
+			continue
+		}
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		req := item.Request
+		fmt.Fprintf(&buf, "### %s\n", item.Name)
+		if item.Folder != "" {
+			fmt.Fprintf(&buf, "# @folder %s\n", item.Folder)
+		}
+
+		headers := make(map[string]string, len(req.Headers))
+		for key, value := range req.Headers {
+			headers[key] = value
+		}
+
+		rawURL := req.URL
+		if auth := req.Auth; auth != nil {
+			switch auth.Type {
+			case "basic":
+				credentials := auth.Username + ":" + auth.Password
+				if strings.Contains(credentials, "{{") {
+					headers["Authorization"] = "Basic " + auth.Username + " " + auth.Password
+				} else {
+					headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+				}
+			case "bearer":
+				headers["Authorization"] = "Bearer " + auth.Token
+			case "apikey":
+				if auth.Location == "query" {
+					rawURL = appendQuery(rawURL, auth.KeyName, auth.APIKey)
+				} else {
+					headers[auth.KeyName] = auth.APIKey
+				}
+			}
+		}
+
+		var body []byte
+		if req.Body != nil {
+			body = req.Body.Content
+			if req.Body.GraphQL != nil {
+				body = graphQLFileBody(req.Body.GraphQL)
+			}
+
+			contentType := req.Body.ContentType
+			if contentType == "" && req.Body.GraphQL != nil {
+				contentType = "application/json"
+			}
+			if _, ok := findHeader(headers, "Content-Type"); !ok && contentType != "" {
+				headers["Content-Type"] = contentType
+			}
+		}
+
+		fmt.Fprintf(&buf, "%s %s\n", req.Method, rawURL)
+
+		keys := make([]string, 0, len(headers))
+		for key := range headers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&buf, "%s: %s\n", key, headers[key])
+		}
+
+		switch {
+		case len(body) == 0:
+		case !utf8.Valid(body):
+			fmt.Fprintf(&buf, "\n# binary body of %d bytes was not exported\n", len(body))
+		default:
+			buf.WriteString("\n")
+			buf.WriteString(strings.TrimRight(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n"))
+			buf.WriteString("\n")
+		}
+	}
+
+	return buf.String()
+}
+
+// graphQLFileBody returns the JSON body of a GraphQL operation for an HTTP file.
+// Variables in text form are written as they are: they become JSON once the
+// {{variables}} of the file are resolved.
+func graphQLFileBody(graphQL *types.GraphQLBody) []byte {
+	if graphQL.VariablesText == "" {
+		body, _ := json.MarshalIndent(graphQL, "", "  ")
+		return body
+	}
+
+	var buf bytes.Buffer
+	query, _ := json.Marshal(graphQL.Query)
+	fmt.Fprintf(&buf, "{\n  \"query\": %s,\n", query)
+
+	if graphQL.OperationName != "" {
+		operationName, _ := json.Marshal(graphQL.OperationName)
+		fmt.Fprintf(&buf, "  \"operationName\": %s,\n", operationName)
+	}
+
+	variables := strings.ReplaceAll(strings.TrimSpace(graphQL.VariablesText), "\n", "\n  ")
+	fmt.Fprintf(&buf, "  \"variables\": %s\n}", variables)
+
+	return buf.Bytes()
+}
+
+// ExportHTTPEnvironments writes environments to an http-client.env.json file.
+func ExportHTTPEnvironments(envs []*environment.Environment, filePath string) error {
+	envFile := make(map[string]map[string]string, len(envs))
+	for _, env := range envs {
+		if env != nil {
+			envFile[env.Name] = env.CopyMap()
+		}
+	}
+
+	data, err := json.MarshalIndent(envFile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal HTTP environments: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write HTTP environments file: %w", err)
+	}
+
+	return nil
+}
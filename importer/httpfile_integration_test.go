@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+)
+
+func TestIntegrationImportFromHTTPFile_ExecuteImported(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	content := `@name = Ann
+
+### Echo
+PATCH {{baseUrl}}/echo
+    ?page=2
+Content-Type: application/json
+Authorization: Bearer abc
+
+{"name": "{{name}}"}
+`
+
+	filePath, cleanup := writeSpec(t, "echo.http", content)
+	defer cleanup()
+
+	envs := `{"local": {"baseUrl": "` + http_server.GetServerURL() + `"}}`
+	if err := os.WriteFile(filepath.Join(filepath.Dir(filePath), HTTPEnvFile), []byte(envs), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := ImportFromHTTPFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resolver, err := core.NewVariableResolver(result.Environments[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := result.Collections[0].Items[0].Request
+	if req.URL, err = resolver.Resolve(req.URL); err != nil {
+		t.Fatalf("failed to resolve URL: %v", err)
+	}
+	body, err := resolver.Resolve(string(req.Body.Content))
+	if err != nil {
+		t.Fatalf("failed to resolve body: %v", err)
+	}
+	req.Body.Content = []byte(body)
+
+	client := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var echo struct {
+		Method  string              `json:"method"`
+		Query   map[string][]string `json:"query"`
+		Headers map[string][]string `json:"headers"`
+		Body    string              `json:"body"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		t.Fatalf("failed to parse echo response: %v", err)
+	}
+
+	if echo.Method != "PATCH" || echo.Query["page"][0] != "2" {
+		t.Errorf("unexpected echo %s %v", echo.Method, echo.Query)
+	}
+	if echo.Headers["Authorization"][0] != "Bearer abc" || echo.Headers["Content-Type"][0] != "application/json" {
+		t.Errorf("unexpected headers: %v", echo.Headers)
+	}
+	if echo.Body != `{"name": "Ann"}` {
+		t.Errorf("unexpected body: %s", echo.Body)
+	}
+}
@@ -0,0 +1,360 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/types"
+)
+
+const testHTTPFile = `@host = api.example.com
+@token = file-token
+
+### List users
+GET https://{{host}}/users
+    ?page=1
+    &limit=10
+Accept: application/json
+
+###
+# Create a user
+POST https://{{host}}/users HTTP/1.1
+Content-Type: application/json
+Authorization: Bearer {{token}}
+
+{
+  "name": "Ann"
+}
+
+> {%
+  client.global.set("id", response.body.id);
+%}
+
+###
+# @name login
+# @no-redirect
+POST https://{{host}}/login
+Authorization: Basic ann secret
+Content-Type: application/x-www-form-urlencoded
+
+user=ann&pass={{$uuid}}
+
+### Upload
+POST https://{{host}}/upload
+Content-Type: text/plain
+
+< ./avatar.txt
+
+### Search
+GRAPHQL https://{{host}}/graphql
+
+query Users($limit: Int) { users(limit: $limit) { id } }
+
+{"limit": 10}
+
+### Profile
+GET https://{{host}}/users/{{login.response.body.$.id}}
+
+### Socket
+WEBSOCKET ws://{{host}}/ws
+`
+
+func TestUnitImportFromHTTPFile(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "users.http", testHTTPFile)
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(filepath.Dir(filePath), "avatar.txt"), []byte("avatar\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := ImportFromHTTPFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection := result.Collections[0]
+	if collection.Name != "users" || collection.EnvName != "users" {
+		t.Errorf("unexpected collection: %s env %q", collection.Name, collection.EnvName)
+	}
+
+	var names []string
+	for _, item := range collection.Items {
+		names = append(names, item.Name)
+	}
+	if strings.Join(names, ",") != "List users,Create a user,login,Upload,Search,Profile" {
+		t.Fatalf("unexpected items: %v", names)
+	}
+
+	list := collection.Items[0].Request
+	if list.Method != "GET" || list.URL != "https://{{host}}/users?page=1&limit=10" || list.Headers["Accept"] != "application/json" {
+		t.Errorf("unexpected request: %s %s %v", list.Method, list.URL, list.Headers)
+	}
+	if list.Body != nil {
+		t.Errorf("expected no body, got %+v", list.Body)
+	}
+
+	create := collection.Items[1].Request
+	if create.Method != "POST" || create.URL != "https://{{host}}/users" {
+		t.Errorf("unexpected request: %s %s", create.Method, create.URL)
+	}
+	if create.Body == nil || create.Body.Type != "json" || string(create.Body.Content) != "{\n  \"name\": \"Ann\"\n}" {
+		t.Errorf("unexpected body: %+v", create.Body)
+	}
+	if create.Auth == nil || create.Auth.Type != "bearer" || create.Auth.Token != "{{token}}" {
+		t.Errorf("unexpected auth: %+v", create.Auth)
+	}
+
+	login := collection.Items[2].Request
+	if login.Auth == nil || login.Auth.Type != "basic" || login.Auth.Username != "ann" || login.Auth.Password != "secret" {
+		t.Errorf("unexpected auth: %+v", login.Auth)
+	}
+	if login.Body == nil || login.Body.Type != "urlencoded" {
+		t.Errorf("unexpected body: %+v", login.Body)
+	}
+
+	if upload := collection.Items[3].Request; upload.Body == nil || string(upload.Body.Content) != "avatar" {
+		t.Errorf("expected body from file, got %+v", upload.Body)
+	}
+
+	search := collection.Items[4].Request
+	if search.Method != "POST" || search.Body == nil || search.Body.Type != "graphql" {
+		t.Fatalf("unexpected graphql request: %s %+v", search.Method, search.Body)
+	}
+	if !strings.HasPrefix(search.Body.GraphQL.Query, "query Users") || search.Body.GraphQL.Variables["limit"] != float64(10) {
+		t.Errorf("unexpected graphql body: %+v", search.Body.GraphQL)
+	}
+
+	env := result.Environments[0]
+	if host, _ := env.Get("host"); host != "api.example.com" {
+		t.Errorf("expected file variable host, got %q", host)
+	}
+
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, expected := range []string{
+		`request "Create a user": response handler scripts are not supported`,
+		`request "login": @no-redirect is not supported`,
+		`request "login": dynamic variables such as {{$guid}} are not supported`,
+		`request "Profile": references to other requests are not supported`,
+		`request "Socket": WEBSOCKET requests are not supported`,
+	} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("expected warning %q, got:\n%s", expected, warnings)
+		}
+	}
+}
+
+func TestUnitImportFromHTTPFile_Environments(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "api.rest", "@version = v2\n\nGET {{baseUrl}}/{{version}}/health\n")
+	defer cleanup()
+
+	dir := filepath.Dir(filePath)
+	envs := `{"$shared": {"version": "v1", "retries": 3}, "dev": {"baseUrl": "http://localhost"}, "prod": {"baseUrl": "https://example.com", "SSLConfiguration": {"verifyHostCertificate": true}}}`
+	if err := os.WriteFile(filepath.Join(dir, HTTPEnvFile), []byte(envs), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	private := `{"prod": {"token": "secret"}}`
+	if err := os.WriteFile(filepath.Join(dir, HTTPPrivateEnvFile), []byte(private), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := ImportFromHTTPFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Environments) != 2 {
+		t.Fatalf("expected 2 environments, got %d", len(result.Environments))
+	}
+
+	dev, prod := result.Environments[0], result.Environments[1]
+	if dev.Name != "dev" || prod.Name != "prod" || result.Collections[0].EnvName != "dev" {
+		t.Fatalf("unexpected environments: %s, %s", dev.Name, prod.Name)
+	}
+
+	if version, _ := dev.Get("version"); version != "v2" {
+		t.Errorf("expected file variable to override the environment, got %s", version)
+	}
+	if retries, _ := dev.Get("retries"); retries != "3" {
+		t.Errorf("expected shared variable, got %q", retries)
+	}
+	if token, _ := prod.Get("token"); token != "secret" {
+		t.Errorf("expected private variable, got %q", token)
+	}
+	if _, ok := prod.Get("SSLConfiguration"); ok {
+		t.Error("expected SSLConfiguration to be skipped")
+	}
+
+	if item := result.Collections[0].Items[0]; item.Request.Method != "GET" || item.Request.URL != "{{baseUrl}}/{{version}}/health" {
+		t.Errorf("unexpected request: %s %s", item.Request.Method, item.Request.URL)
+	}
+}
+
+func TestUnitImportFromHTTPFile_FileNotFound(t *testing.T) {
+	if _, err := ImportFromHTTPFile("/nonexistent/api.http"); err == nil {
+		t.Error("expected error")
+	}
+
+	if _, err := ImportHTTPEnvironments("/nonexistent/" + HTTPEnvFile); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnitExportToHTTPFile_RoundTrip(t *testing.T) {
+	collection := &collections.Collection{
+		Name: "api",
+		Items: []*types.RequestItem{
+			{
+				Name:   "Create user",
+				Folder: "Users",
+				Request: &types.Request{
+					Method:  "POST",
+					URL:     "{{baseUrl}}/users",
+					Headers: map[string]string{"X-Trace": "1"},
+					Body:    &types.RequestBody{Type: "json", Content: []byte(`{"name":"Ann"}`), ContentType: "application/json"},
+					Auth:    &types.Auth{Type: "basic", Username: "ann", Password: "secret"},
+				},
+			},
+			{
+				Name: "Search",
+				Request: &types.Request{
+					Method: "GET",
+					URL:    "{{baseUrl}}/search",
+					Auth:   &types.Auth{Type: "apikey", KeyName: "key", APIKey: "{{apiKey}}", Location: "query"},
+				},
+			},
+		},
+	}
+
+	filePath, cleanup := writeSpec(t, "api.http", "")
+	defer cleanup()
+
+	if err := ExportToHTTPFile(collection, filePath); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read exported file: %v", err)
+	}
+
+	expected := "### Create user\n# @folder Users\nPOST {{baseUrl}}/users\nAuthorization: Basic YW5uOnNlY3JldA==\nContent-Type: application/json\nX-Trace: 1\n\n{\"name\":\"Ann\"}\n\n" +
+		"### Search\nGET {{baseUrl}}/search?key={{apiKey}}\n"
+	if string(data) != expected {
+		t.Errorf("unexpected export:\n%s", data)
+	}
+
+	result, err := ImportFromHTTPFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+
+	imported := result.Collections[0].Items
+	if len(imported) != 2 || imported[0].Name != "Create user" || imported[0].Folder != "Users" {
+		t.Fatalf("unexpected items: %+v", imported)
+	}
+
+	req := imported[0].Request
+	if req.Auth == nil || req.Auth.Username != "ann" || req.Auth.Password != "secret" {
+		t.Errorf("unexpected auth: %+v", req.Auth)
+	}
+	if req.Body == nil || req.Body.ContentType != "application/json" || string(req.Body.Content) != `{"name":"Ann"}` {
+		t.Errorf("unexpected body: %+v", req.Body)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", result.Warnings)
+	}
+}
+
+func TestUnitFormatHTTPFile_GraphQLVariablesText(t *testing.T) {
+	collection := &collections.Collection{
+		Name: "api",
+		Items: []*types.RequestItem{
+			{
+				Name: "User",
+				Request: &types.Request{
+					Method: "POST",
+					URL:    "{{baseUrl}}/graphql",
+					Body: &types.RequestBody{
+						Type:    "graphql",
+						GraphQL: &types.GraphQLBody{Query: "{ user }", VariablesText: `{"id": {{userId}}}`},
+					},
+				},
+			},
+		},
+	}
+
+	expected := "### User\nPOST {{baseUrl}}/graphql\nContent-Type: application/json\n\n{\n  \"query\": \"{ user }\",\n  \"variables\": {\"id\": {{userId}}}\n}\n"
+	if got := formatHTTPFile(collection); got != expected {
+		t.Errorf("unexpected export:\n%s", got)
+	}
+}
+
+func TestUnitFormatHTTPFile_GraphQLMultiLineVariablesText(t *testing.T) {
+	collection := &collections.Collection{
+		Name: "api",
+		Items: []*types.RequestItem{
+			{
+				Name: "User",
+				Request: &types.Request{
+					Method: "POST",
+					URL:    "{{baseUrl}}/graphql",
+					Body: &types.RequestBody{
+						Type: "graphql",
+						GraphQL: &types.GraphQLBody{
+							Query:         "query User($id: ID!) { user(id: $id) }",
+							OperationName: "User",
+							VariablesText: "{\n  \"id\": {{userId}},\n  \"full\": true\n}\n",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := "### User\nPOST {{baseUrl}}/graphql\nContent-Type: application/json\n\n" +
+		"{\n" +
+		"  \"query\": \"query User($id: ID!) { user(id: $id) }\",\n" +
+		"  \"operationName\": \"User\",\n" +
+		"  \"variables\": {\n" +
+		"    \"id\": {{userId}},\n" +
+		"    \"full\": true\n" +
+		"  }\n" +
+		"}\n"
+	if got := formatHTTPFile(collection); got != expected {
+		t.Errorf("unexpected export:\n%s", got)
+	}
+}
+
+func TestUnitExportToHTTPFile_NilCollection(t *testing.T) {
+	if err := ExportToHTTPFile(nil, "/tmp/nil.http"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnitExportHTTPEnvironments(t *testing.T) {
+	dev := environment.NewEnvironment("dev")
+	dev.Set("baseUrl", "http://localhost")
+
+	filePath, cleanup := writeSpec(t, HTTPEnvFile, "")
+	defer cleanup()
+
+	if err := ExportHTTPEnvironments([]*environment.Environment{dev}, filePath); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	envs, err := ImportHTTPEnvironments(filePath)
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+
+	if len(envs) != 1 || envs[0].Name != "dev" {
+		t.Fatalf("unexpected environments: %v", envs)
+	}
+	if baseURL, _ := envs[0].Get("baseUrl"); baseURL != "http://localhost" {
+		t.Errorf("unexpected baseUrl: %s", baseURL)
+	}
+}
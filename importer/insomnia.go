@@ -0,0 +1,406 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// InsomniaExport represents an Insomnia v4 export document.
+type InsomniaExport struct {
+	Type      string              `json:"_type"`
+	Format    int                 `json:"__export_format"`
+	Resources []*InsomniaResource `json:"resources"`
+}
+
+// InsomniaResource represents a resource of an Insomnia export. The fields in use
+// depend on the resource type: workspace, request_group, request or environment.
+type InsomniaResource struct {
+	ID             string          `json:"_id"`
+	Type           string          `json:"_type"`
+	ParentID       string          `json:"parentId"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description,omitempty"`
+	MetaSortKey    float64         `json:"metaSortKey,omitempty"`
+	Method         string          `json:"method,omitempty"`
+	URL            string          `json:"url,omitempty"`
+	Body           *InsomniaBody   `json:"body,omitempty"`
+	Parameters     []InsomniaParam `json:"parameters,omitempty"`
+	Headers        []InsomniaParam `json:"headers,omitempty"`
+	Authentication *InsomniaAuth   `json:"authentication,omitempty"`
+	Data           map[string]any  `json:"data,omitempty"`
+	Environment    map[string]any  `json:"environment,omitempty"`
+}
+
+// InsomniaBody represents the body of an Insomnia request.
+type InsomniaBody struct {
+	MimeType string          `json:"mimeType,omitempty"`
+	Text     string          `json:"text,omitempty"`
+	Params   []InsomniaParam `json:"params,omitempty"`
+}
+
+// InsomniaParam represents a header, query parameter or form field of an Insomnia request.
+type InsomniaParam struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Type     string `json:"type,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+}
+
+// InsomniaAuth represents the authentication of an Insomnia request.
+type InsomniaAuth struct {
+	Type     string `json:"type,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Value    string `json:"value,omitempty"`
+	AddTo    string `json:"addTo,omitempty"`
+}
+
+var insomniaVariable = regexp.MustCompile(`\{\{\s*(?:_\.)?([^{}\s]+)\s*\}\}`)
+
+// ImportFromInsomnia imports an Insomnia v4 export. Each workspace becomes a collection
+// with its request groups as folders, and each sub environment is merged with its base
+// environment into an environment.
+func ImportFromInsomnia(filePath string) (*ImportResult, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Insomnia export file: %w", err)
+	}
+
+	var export InsomniaExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse Insomnia export: %w", err)
+	}
+
+	if export.Type != "export" || export.Format != 4 {
+		return nil, fmt.Errorf("unsupported Insomnia export: expected export format 4, got %q format %d", export.Type, export.Format)
+	}
+
+	importer := &insomniaImporter{
+		result:   &ImportResult{},
+		children: make(map[string][]*InsomniaResource),
+		fileDir:  filepath.Dir(filePath),
+	}
+
+	var workspaces []*InsomniaResource
+	for _, resource := range export.Resources {
+		if resource == nil {
+			continue
+		}
+		if resource.Type == "workspace" {
+			workspaces = append(workspaces, resource)
+		}
+		importer.children[resource.ParentID] = append(importer.children[resource.ParentID], resource)
+	}
+
+	for _, children := range importer.children {
+		sort.SliceStable(children, func(i, j int) bool {
+			return children[i].MetaSortKey < children[j].MetaSortKey
+		})
+	}
+
+	for _, workspace := range workspaces {
+		importer.importWorkspace(workspace)
+	}
+
+	return importer.result, nil
+}
+
+type insomniaImporter struct {
+	result   *ImportResult
+	children map[string][]*InsomniaResource
+	fileDir  string
+}
+
+func (im *insomniaImporter) importWorkspace(workspace *InsomniaResource) {
+	collection := &collections.Collection{
+		Name:        workspace.Name,
+		Description: workspace.Description,
+		Items:       []*types.RequestItem{},
+	}
+
+	im.importItems(collection, workspace.ID, "")
+
+	envs := im.importEnvironments(workspace)
+	if len(envs) > 0 {
+		collection.EnvName = envs[0].Name
+	}
+
+	im.result.Collections = append(im.result.Collections, collection)
+	im.result.Environments = append(im.result.Environments, envs...)
+}
+
+func (im *insomniaImporter) importItems(collection *collections.Collection, parentID, folder string) {
+	for _, resource := range im.children[parentID] {
+		switch resource.Type {
+		case "request":
+			collection.Items = append(collection.Items, &types.RequestItem{
+				Name:    resource.Name,
+				Folder:  folder,
+				Request: im.convertRequest(resource),
+			})
+		case "request_group":
+			path := resource.Name
+			if folder != "" {
+				path = folder + "/" + resource.Name
+			}
+			if len(resource.Environment) > 0 {
+				im.result.warn("folder %q: folder environments are not supported", path)
+			}
+			im.importItems(collection, resource.ID, path)
+		case "grpc_request":
+			im.result.warn("request %q: gRPC requests are not supported", resource.Name)
+		case "websocket_request":
+			im.result.warn("request %q: WebSocket requests are not supported", resource.Name)
+		case "unit_test_suite":
+			im.result.warn("test suite %q: unit tests are not supported", resource.Name)
+		}
+	}
+}
+
+// importEnvironments merges every sub environment of a workspace with its base
+// environment. The base environment alone is imported when there are no sub environments.
+func (im *insomniaImporter) importEnvironments(workspace *InsomniaResource) []*environment.Environment {
+	var envs []*environment.Environment
+
+	for _, base := range im.children[workspace.ID] {
+		if base.Type != "environment" {
+			continue
+		}
+
+		baseVars := make(map[string]string)
+		flattenInsomniaData("", base.Data, baseVars)
+
+		var subEnvs []*InsomniaResource
+		for _, child := range im.children[base.ID] {
+			if child.Type == "environment" {
+				subEnvs = append(subEnvs, child)
+			}
+		}
+
+		if len(subEnvs) == 0 {
+			name := base.Name
+			if name == "" || name == "Base Environment" {
+				name = workspace.Name
+			}
+			env := environment.NewEnvironment(name)
+			im.setVariables(env, baseVars)
+			envs = append(envs, env)
+			continue
+		}
+
+		for _, sub := range subEnvs {
+			env := environment.NewEnvironment(sub.Name)
+			im.setVariables(env, baseVars)
+
+			subVars := make(map[string]string)
+			flattenInsomniaData("", sub.Data, subVars)
+			im.setVariables(env, subVars)
+
+			envs = append(envs, env)
+		}
+	}
+
+	return envs
+}
+
+func (im *insomniaImporter) setVariables(env *environment.Environment, vars map[string]string) {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		env.Set(key, im.convertTemplate(vars[key], "environment "+env.Name))
+	}
+}
+
+// flattenInsomniaData flattens nested environment data to "a.b" keys,
+// matching the {{ _.a.b }} references of Insomnia templates.
+func flattenInsomniaData(prefix string, data map[string]any, vars map[string]string) {
+	for key, value := range data {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			flattenInsomniaData(key, v, vars)
+		case string:
+			vars[key] = v
+		case nil:
+			vars[key] = ""
+		default:
+			encoded, _ := json.Marshal(v)
+			vars[key] = string(encoded)
+		}
+	}
+}
+
+// convertTemplate turns {{ _.var }} references into {{var}} variables.
+// Template tags such as {% response %} can not be resolved and are reported.
+func (im *insomniaImporter) convertTemplate(s, context string) string {
+	if strings.Contains(s, "{%") {
+		im.result.warn("%s: template tags are not supported: %s", context, s)
+	}
+
+	return insomniaVariable.ReplaceAllString(s, "{{$1}}")
+}
+
+func (im *insomniaImporter) convertRequest(resource *InsomniaResource) *types.Request {
+	context := fmt.Sprintf("request %q", resource.Name)
+	convert := func(s string) string {
+		return im.convertTemplate(s, context)
+	}
+
+	method := strings.ToUpper(resource.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	req := &types.Request{
+		Method:  method,
+		URL:     convert(resource.URL),
+		Headers: make(map[string]string),
+	}
+
+	for _, param := range resource.Parameters {
+		if param.Disabled || param.Name == "" {
+			continue
+		}
+		req.URL = appendQuery(req.URL, convert(param.Name), convert(param.Value))
+	}
+
+	for _, header := range resource.Headers {
+		if header.Disabled || header.Name == "" {
+			continue
+		}
+		appendHeader(req.Headers, convert(header.Name), convert(header.Value))
+	}
+
+	if resource.Body != nil {
+		req.Body = im.convertBody(resource.Body, convert, context)
+	}
+	moveContentType(req)
+
+	if auth := resource.Authentication; auth != nil && auth.Type != "" && auth.Type != "none" {
+		im.convertAuth(req, auth, convert, context)
+	}
+
+	return req
+}
+
+func (im *insomniaImporter) convertBody(body *InsomniaBody, convert func(string) string, context string) *types.RequestBody {
+	switch body.MimeType {
+	case "":
+		if body.Text == "" {
+			return nil
+		}
+		return &types.RequestBody{Type: "text", Content: []byte(convert(body.Text)), ContentType: "text/plain"}
+	case "application/graphql":
+		var operation struct {
+			Query     string          `json:"query"`
+			Variables json.RawMessage `json:"variables"`
+		}
+		if err := json.Unmarshal([]byte(body.Text), &operation); err != nil {
+			return &types.RequestBody{Type: "json", Content: []byte(convert(body.Text)), ContentType: "application/json"}
+		}
+		return convertPostmanGraphQL(&PostmanGraphQL{
+			Query:     convert(operation.Query),
+			Variables: convert(string(operation.Variables)),
+		})
+	case "application/x-www-form-urlencoded":
+		var parts []string
+		for _, param := range body.Params {
+			if param.Disabled {
+				continue
+			}
+			parts = append(parts, url.QueryEscape(convert(param.Name))+"="+url.QueryEscape(convert(param.Value)))
+		}
+		return &types.RequestBody{
+			Type:        "urlencoded",
+			Content:     []byte(strings.Join(parts, "&")),
+			ContentType: body.MimeType,
+		}
+	case "multipart/form-data":
+		var fields []formField
+		for _, param := range body.Params {
+			if param.Disabled {
+				continue
+			}
+			field := formField{name: convert(param.Name), value: convert(param.Value)}
+			if param.Type == "file" {
+				path := param.FileName
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(im.fileDir, path)
+				}
+				content, err := os.ReadFile(path)
+				if err != nil {
+					im.result.warn("%s: file %s of form field %q could not be read", context, param.FileName, param.Name)
+				}
+				field.value = string(content)
+				field.filename = filepath.Base(param.FileName)
+			}
+			fields = append(fields, field)
+		}
+		form, err := multipartBody(fields)
+		if err != nil {
+			im.result.warn("%s: %v", context, err)
+			return nil
+		}
+		return form
+	default:
+		return &types.RequestBody{
+			Type:        bodyTypeFor(body.MimeType),
+			Content:     []byte(convert(body.Text)),
+			ContentType: body.MimeType,
+		}
+	}
+}
+
+func (im *insomniaImporter) convertAuth(req *types.Request, auth *InsomniaAuth, convert func(string) string, context string) {
+	if auth.Disabled {
+		im.result.warn("%s: disabled %s authentication was not imported", context, auth.Type)
+		return
+	}
+
+	switch auth.Type {
+	case "basic":
+		req.Auth = &types.Auth{Type: "basic", Username: convert(auth.Username), Password: convert(auth.Password)}
+	case "bearer":
+		if auth.Prefix != "" && !strings.EqualFold(auth.Prefix, "Bearer") {
+			req.Headers["Authorization"] = convert(auth.Prefix + " " + auth.Token)
+			return
+		}
+		req.Auth = &types.Auth{Type: "bearer", Token: convert(auth.Token)}
+	case "apikey":
+		switch auth.AddTo {
+		case "", "header":
+			req.Auth = &types.Auth{Type: "apikey", KeyName: convert(auth.Key), APIKey: convert(auth.Value), Location: "header"}
+		case "queryParams":
+			req.Auth = &types.Auth{Type: "apikey", KeyName: convert(auth.Key), APIKey: convert(auth.Value), Location: "query"}
+		default:
+			im.result.warn("%s: API key in %s is not supported", context, auth.AddTo)
+		}
+	default:
+		im.result.warn("%s: %s authentication is not supported", context, auth.Type)
+	}
+}
@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+)
+
+func TestIntegrationImportFromInsomnia_ExecuteImported(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	export := `{
+		"_type": "export",
+		"__export_format": 4,
+		"resources": [
+			{"_id": "wrk_1", "_type": "workspace", "parentId": null, "name": "Echo"},
+			{
+				"_id": "req_1", "_type": "request", "parentId": "wrk_1", "name": "Echo",
+				"method": "POST", "url": "{{ _.baseUrl }}/echo",
+				"parameters": [{"name": "page", "value": "2"}],
+				"headers": [{"name": "Content-Type", "value": "application/json"}],
+				"body": {"mimeType": "application/json", "text": "{\"name\": \"{{ _.name }}\"}"},
+				"authentication": {"type": "bearer", "token": "{{ _.token }}"}
+			},
+			{"_id": "env_1", "_type": "environment", "parentId": "wrk_1", "name": "Base Environment", "data": {"baseUrl": "` + http_server.GetServerURL() + `", "name": "Ann", "token": "abc"}}
+		]
+	}`
+
+	filePath, cleanup := writeSpec(t, "insomnia.json", export)
+	defer cleanup()
+
+	result, err := ImportFromInsomnia(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Collections) != 1 || len(result.Environments) != 1 {
+		t.Fatalf("expected 1 collection and 1 environment, got %d and %d", len(result.Collections), len(result.Environments))
+	}
+
+	resolver, err := core.NewVariableResolver(result.Environments[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := result.Collections[0].Items[0].Request
+	if req.URL, err = resolver.Resolve(req.URL); err != nil {
+		t.Fatalf("failed to resolve URL: %v", err)
+	}
+	if req.Auth.Token, err = resolver.Resolve(req.Auth.Token); err != nil {
+		t.Fatalf("failed to resolve token: %v", err)
+	}
+	body, err := resolver.Resolve(string(req.Body.Content))
+	if err != nil {
+		t.Fatalf("failed to resolve body: %v", err)
+	}
+	req.Body.Content = []byte(body)
+
+	client := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var echo struct {
+		Method  string              `json:"method"`
+		Query   map[string][]string `json:"query"`
+		Headers map[string][]string `json:"headers"`
+		Body    string              `json:"body"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		t.Fatalf("failed to parse echo response: %v", err)
+	}
+
+	if echo.Method != "POST" || echo.Query["page"][0] != "2" {
+		t.Errorf("unexpected echo %s %v", echo.Method, echo.Query)
+	}
+	if echo.Headers["Authorization"][0] != "Bearer abc" {
+		t.Errorf("unexpected Authorization header: %v", echo.Headers["Authorization"])
+	}
+	if echo.Body != `{"name": "Ann"}` {
+		t.Errorf("unexpected body: %s", echo.Body)
+	}
+}
@@ -0,0 +1,202 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+const testInsomnia = `{
+	"_type": "export",
+	"__export_format": 4,
+	"__export_source": "insomnia.desktop.app:v8.6.1",
+	"resources": [
+		{"_id": "wrk_1", "_type": "workspace", "parentId": null, "name": "Shop API", "description": "Shop requests"},
+		{"_id": "fld_1", "_type": "request_group", "parentId": "wrk_1", "name": "Users", "metaSortKey": -2},
+		{"_id": "fld_2", "_type": "request_group", "parentId": "fld_1", "name": "Admin", "metaSortKey": 2, "environment": {"role": "admin"}},
+		{
+			"_id": "req_2", "_type": "request", "parentId": "wrk_1", "name": "Health", "metaSortKey": -1,
+			"method": "get", "url": "{{ _.baseUrl }}/health", "body": {}, "parameters": [], "headers": []
+		},
+		{
+			"_id": "req_1", "_type": "request", "parentId": "fld_1", "name": "Create user", "metaSortKey": 1,
+			"method": "POST", "url": "{{ _.baseUrl }}/users",
+			"body": {"mimeType": "application/json", "text": "{\"name\": \"{{ _.user.name }}\"}"},
+			"parameters": [{"name": "notify", "value": "true"}, {"name": "debug", "value": "1", "disabled": true}],
+			"headers": [{"name": "Content-Type", "value": "application/json"}, {"name": "X-Request-Id", "value": "{% uuid 'v4' %}"}],
+			"authentication": {"type": "bearer", "token": "{{ _.token }}"}
+		},
+		{
+			"_id": "req_3", "_type": "request", "parentId": "fld_2", "name": "Login", "metaSortKey": 1,
+			"method": "POST", "url": "{{ baseUrl }}/login",
+			"body": {"mimeType": "application/x-www-form-urlencoded", "params": [{"name": "user", "value": "ann"}, {"name": "pass", "value": "a b"}]},
+			"authentication": {"type": "basic", "username": "ann", "password": "secret"}
+		},
+		{
+			"_id": "req_4", "_type": "request", "parentId": "fld_2", "name": "Search", "metaSortKey": 2,
+			"method": "POST", "url": "{{ _.baseUrl }}/graphql",
+			"body": {"mimeType": "application/graphql", "text": "{\"query\": \"{ users { id } }\", \"variables\": {\"limit\": 10}}"},
+			"authentication": {"type": "apikey", "key": "X-Api-Key", "value": "{{ _.apiKey }}", "addTo": "queryParams"}
+		},
+		{
+			"_id": "req_5", "_type": "request", "parentId": "wrk_1", "name": "OAuth", "metaSortKey": 3,
+			"method": "GET", "url": "https://example.com", "authentication": {"type": "oauth2"}
+		},
+		{"_id": "greq_1", "_type": "grpc_request", "parentId": "wrk_1", "name": "Stream"},
+		{"_id": "uts_1", "_type": "unit_test_suite", "parentId": "wrk_1", "name": "Smoke"},
+		{"_id": "env_base", "_type": "environment", "parentId": "wrk_1", "name": "Base Environment", "data": {"baseUrl": "http://localhost", "user": {"name": "Ann"}, "retries": 3}},
+		{"_id": "env_dev", "_type": "environment", "parentId": "env_base", "name": "Dev", "metaSortKey": 1, "data": {"token": "dev-token"}},
+		{"_id": "env_prod", "_type": "environment", "parentId": "env_base", "name": "Prod", "metaSortKey": 2, "data": {"baseUrl": "https://shop.example.com", "token": "prod-token"}}
+	]
+}`
+
+func TestUnitImportFromInsomnia_Collection(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "insomnia.json", testInsomnia)
+	defer cleanup()
+
+	result, err := ImportFromInsomnia(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Collections) != 1 {
+		t.Fatalf("expected 1 collection, got %d", len(result.Collections))
+	}
+
+	collection := result.Collections[0]
+	if collection.Name != "Shop API" || collection.Description != "Shop requests" {
+		t.Errorf("unexpected collection: %s %q", collection.Name, collection.Description)
+	}
+	if collection.EnvName != "Dev" {
+		t.Errorf("expected environment Dev, got %q", collection.EnvName)
+	}
+
+	var names []string
+	for _, item := range collection.Items {
+		names = append(names, item.Folder+":"+item.Name)
+	}
+	expected := "Users:Create user,Users/Admin:Login,Users/Admin:Search,:Health,:OAuth"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("expected items %s, got %s", expected, strings.Join(names, ","))
+	}
+
+	create := collection.Items[0].Request
+	if create.Method != "POST" || create.URL != "{{baseUrl}}/users?notify=true" {
+		t.Errorf("unexpected request: %s %s", create.Method, create.URL)
+	}
+	if create.Body == nil || create.Body.Type != "json" || string(create.Body.Content) != `{"name": "{{user.name}}"}` {
+		t.Errorf("unexpected body: %+v", create.Body)
+	}
+	if _, ok := create.Headers["Content-Type"]; ok {
+		t.Error("expected Content-Type to be moved to the body")
+	}
+	if create.Auth == nil || create.Auth.Type != "bearer" || create.Auth.Token != "{{token}}" {
+		t.Errorf("unexpected auth: %+v", create.Auth)
+	}
+
+	login := collection.Items[1].Request
+	if login.URL != "{{baseUrl}}/login" {
+		t.Errorf("expected {{ baseUrl }} to be converted, got %s", login.URL)
+	}
+	if login.Body == nil || login.Body.Type != "urlencoded" || string(login.Body.Content) != "user=ann&pass=a+b" {
+		t.Errorf("unexpected form body: %+v", login.Body)
+	}
+	if login.Auth == nil || login.Auth.Type != "basic" || login.Auth.Username != "ann" {
+		t.Errorf("unexpected auth: %+v", login.Auth)
+	}
+
+	search := collection.Items[2].Request
+	if search.Body == nil || search.Body.Type != "graphql" || search.Body.GraphQL.Query != "{ users { id } }" {
+		t.Fatalf("unexpected graphql body: %+v", search.Body)
+	}
+	if search.Body.GraphQL.Variables["limit"] != float64(10) {
+		t.Errorf("unexpected graphql variables: %v", search.Body.GraphQL.Variables)
+	}
+	if search.Auth == nil || search.Auth.Location != "query" || search.Auth.APIKey != "{{apiKey}}" {
+		t.Errorf("unexpected auth: %+v", search.Auth)
+	}
+
+	if health := collection.Items[3].Request; health.Method != "GET" || health.Body != nil {
+		t.Errorf("unexpected request: %s %+v", health.Method, health.Body)
+	}
+}
+
+func TestUnitImportFromInsomnia_Environments(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "insomnia.json", testInsomnia)
+	defer cleanup()
+
+	result, err := ImportFromInsomnia(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Environments) != 2 {
+		t.Fatalf("expected 2 environments, got %d", len(result.Environments))
+	}
+
+	dev, prod := result.Environments[0], result.Environments[1]
+	if dev.Name != "Dev" || prod.Name != "Prod" {
+		t.Fatalf("unexpected environments: %s, %s", dev.Name, prod.Name)
+	}
+
+	expected := map[string]string{"baseUrl": "http://localhost", "user.name": "Ann", "retries": "3", "token": "dev-token"}
+	for key, value := range expected {
+		if got, _ := dev.Get(key); got != value {
+			t.Errorf("expected %s=%s in Dev, got %q", key, value, got)
+		}
+	}
+
+	if baseURL, _ := prod.Get("baseUrl"); baseURL != "https://shop.example.com" {
+		t.Errorf("expected sub environment to override base, got %s", baseURL)
+	}
+}
+
+func TestUnitImportFromInsomnia_Warnings(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "insomnia.json", testInsomnia)
+	defer cleanup()
+
+	result, err := ImportFromInsomnia(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, expected := range []string{
+		`folder "Users/Admin": folder environments are not supported`,
+		`request "Create user": template tags are not supported`,
+		`request "OAuth": oauth2 authentication is not supported`,
+		`request "Stream": gRPC requests are not supported`,
+		`test suite "Smoke": unit tests are not supported`,
+	} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("expected warning %q, got:\n%s", expected, warnings)
+		}
+	}
+}
+
+func TestUnitImportFromInsomnia_InvalidExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid json", `{`},
+		{"old format", `{"_type": "export", "__export_format": 3, "resources": []}`},
+		{"not an export", `{"info": {"name": "Postman"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath, cleanup := writeSpec(t, "insomnia.json", tt.content)
+			defer cleanup()
+
+			if _, err := ImportFromInsomnia(filePath); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestUnitImportFromInsomnia_FileNotFound(t *testing.T) {
+	if _, err := ImportFromInsomnia("/nonexistent/insomnia.json"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,769 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// openAPIBaseURLVar is the environment variable that holds the server URL
+	// imported requests are sent to.
+	openAPIBaseURLVar = "baseUrl"
+
+	openAPIMaxRefDepth    = 32
+	openAPIMaxSchemaDepth = 8
+)
+
+var (
+	openAPIMethods    = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+	openAPIPathParam  = regexp.MustCompile(`\{([^}]+)\}`)
+	openAPIMediaTypes = []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data"}
+)
+
+// ImportFromOpenAPI imports an OpenAPI 3.x or Swagger 2.0 specification in JSON or YAML format.
+// Every operation becomes a request item in the folder of its first tag. Path parameters become
+// {{variables}} and request bodies are filled with examples from the spec or generated from schemas.
+//
+// The returned environment holds the server URL as baseUrl (further servers as baseUrl2, baseUrl3...)
+// and empty variables for path parameters and credentials, and is linked to the collection by EnvName.
+func ImportFromOpenAPI(filePath string) (*collections.Collection, *environment.Environment, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read OpenAPI spec file: %w", err)
+	}
+
+	var root map[string]any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	spec := &openAPISpec{root: root}
+
+	// Unquoted versions are decoded as numbers, e.g. swagger: 2.0.
+	switch {
+	case strings.HasPrefix(scalarString(root["openapi"]), "3"):
+		spec.version = 3
+	case strings.HasPrefix(scalarString(root["swagger"]), "2"):
+		spec.version = 2
+	default:
+		return nil, nil, fmt.Errorf("%w: not an OpenAPI 3.x or Swagger 2.0 spec", errors.ErrInvalidArgument)
+	}
+
+	info := asMap(root["info"])
+	name := asString(info["title"])
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+
+	spec.env = environment.NewEnvironment(name)
+	spec.addServers()
+
+	collection := &collections.Collection{
+		Name:        name,
+		Description: asString(info["description"]),
+		Items:       spec.items(),
+		EnvName:     spec.env.Name,
+	}
+
+	return collection, spec.env, nil
+}
+
+// openAPISpec wraps the decoded document. The document is kept generic, so that the same
+// code reads both spec versions and follows $ref pointers anywhere in the document.
+type openAPISpec struct {
+	root    map[string]any
+	version int
+	env     *environment.Environment
+}
+
+type openAPIOperation struct {
+	path      string
+	method    string
+	operation map[string]any
+	params    []map[string]any
+}
+
+func (s *openAPISpec) addServers() {
+	var servers []string
+
+	if s.version == 3 {
+		for _, server := range asSlice(s.root["servers"]) {
+			server := s.resolve(server)
+			serverURL := asString(server["url"])
+
+			// Server variables are replaced with their defaults.
+			for name, variable := range asMap(server["variables"]) {
+				value := asString(s.resolve(variable)["default"])
+				serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", value)
+			}
+
+			if serverURL != "" {
+				servers = append(servers, strings.TrimSuffix(serverURL, "/"))
+			}
+		}
+	} else {
+		host := asString(s.root["host"])
+		basePath := strings.TrimSuffix(asString(s.root["basePath"]), "/")
+
+		if host != "" {
+			schemes := asSlice(s.root["schemes"])
+			if len(schemes) == 0 {
+				schemes = []any{"https"}
+			}
+
+			for _, scheme := range schemes {
+				servers = append(servers, asString(scheme)+"://"+host+basePath)
+			}
+		} else if basePath != "" {
+			servers = append(servers, basePath)
+		}
+	}
+
+	if len(servers) == 0 {
+		servers = []string{""}
+	}
+
+	for i, server := range servers {
+		name := openAPIBaseURLVar
+		if i > 0 {
+			name = fmt.Sprintf("%s%d", openAPIBaseURLVar, i+1)
+		}
+		s.env.Set(name, server)
+	}
+}
+
+// items returns the request items ordered by folder: tags in the order of the spec
+// tag list, then other tags in order of appearance, then untagged operations.
+func (s *openAPISpec) items() []*types.RequestItem {
+	folders := make(map[string][]*types.RequestItem)
+	var order []string
+
+	for _, tag := range asSlice(s.root["tags"]) {
+		if name := asString(asMap(tag)["name"]); name != "" {
+			order = append(order, name)
+			folders[name] = nil
+		}
+	}
+
+	var untagged []*types.RequestItem
+
+	for _, op := range s.operations() {
+		item := &types.RequestItem{
+			Name:    operationName(op),
+			Request: s.request(op),
+		}
+
+		tags := asSlice(op.operation["tags"])
+		if len(tags) == 0 {
+			untagged = append(untagged, item)
+			continue
+		}
+
+		item.Folder = asString(tags[0])
+		if _, ok := folders[item.Folder]; !ok {
+			order = append(order, item.Folder)
+		}
+		folders[item.Folder] = append(folders[item.Folder], item)
+	}
+
+	items := []*types.RequestItem{}
+	for _, folder := range order {
+		items = append(items, folders[folder]...)
+	}
+
+	return append(items, untagged...)
+}
+
+func (s *openAPISpec) operations() []openAPIOperation {
+	paths := asMap(s.root["paths"])
+
+	names := make([]string, 0, len(paths))
+	for path := range paths {
+		names = append(names, path)
+	}
+	sort.Strings(names)
+
+	var operations []openAPIOperation
+
+	for _, path := range names {
+		pathItem := s.resolve(paths[path])
+
+		for _, method := range openAPIMethods {
+			operation := s.resolve(pathItem[method])
+			if operation == nil {
+				continue
+			}
+
+			operations = append(operations, openAPIOperation{
+				path:      path,
+				method:    strings.ToUpper(method),
+				operation: operation,
+				params:    s.parameters(pathItem["parameters"], operation["parameters"]),
+			})
+		}
+	}
+
+	return operations
+}
+
+// parameters merges path level and operation level parameters. Operation parameters
+// override path parameters with the same name and location.
+func (s *openAPISpec) parameters(pathParams, operationParams any) []map[string]any {
+	var params []map[string]any
+	index := make(map[string]int)
+
+	for _, list := range []any{pathParams, operationParams} {
+		for _, param := range asSlice(list) {
+			param := s.resolve(param)
+			key := asString(param["in"]) + ":" + asString(param["name"])
+
+			if i, ok := index[key]; ok {
+				params[i] = param
+				continue
+			}
+
+			index[key] = len(params)
+			params = append(params, param)
+		}
+	}
+
+	return params
+}
+
+func operationName(op openAPIOperation) string {
+	if summary := strings.TrimSpace(asString(op.operation["summary"])); summary != "" {
+		return summary
+	}
+
+	if id := asString(op.operation["operationId"]); id != "" {
+		return id
+	}
+
+	return op.method + " " + op.path
+}
+
+func (s *openAPISpec) request(op openAPIOperation) *types.Request {
+	path := openAPIPathParam.ReplaceAllStringFunc(op.path, func(match string) string {
+		return "{{" + match[1:len(match)-1] + "}}"
+	})
+
+	req := &types.Request{
+		Method:  op.method,
+		URL:     "{{" + openAPIBaseURLVar + "}}" + path,
+		Headers: make(map[string]string),
+	}
+
+	var query []string
+	var formParams []map[string]any
+
+	for _, param := range op.params {
+		name := asString(param["name"])
+
+		switch asString(param["in"]) {
+		case "path":
+			if _, ok := s.env.Get(name); !ok {
+				value, _ := s.parameterExample(param)
+				s.env.Set(name, value)
+			}
+		case "query":
+			// Examples are escaped; a {{variable}} must stay as it is to be resolved.
+			if value, ok := s.parameterExample(param); ok {
+				query = append(query, url.QueryEscape(name)+"="+url.QueryEscape(value))
+			} else if variable, ok := s.parameterVariable(param); ok {
+				query = append(query, url.QueryEscape(name)+"="+variable)
+			}
+		case "header":
+			if value, ok := s.parameterValue(param); ok {
+				req.Headers[name] = value
+			}
+		case "body":
+			req.Body = s.swaggerBody(op.operation, s.resolve(param["schema"]))
+		case "formData":
+			formParams = append(formParams, param)
+		}
+	}
+
+	if len(query) > 0 {
+		req.URL += "?" + strings.Join(query, "&")
+	}
+
+	if len(formParams) > 0 {
+		req.Body = s.swaggerFormBody(op.operation, formParams)
+	}
+
+	if s.version == 3 {
+		req.Body = s.requestBody(s.resolve(op.operation["requestBody"]))
+	}
+
+	req.Auth = s.auth(op.operation)
+
+	return req
+}
+
+// parameterValue returns the value a query or header parameter is sent with: its example,
+// or a {{variable}} for required parameters without one. Optional parameters without
+// an example are left out.
+func (s *openAPISpec) parameterValue(param map[string]any) (string, bool) {
+	if value, ok := s.parameterExample(param); ok {
+		return value, true
+	}
+
+	return s.parameterVariable(param)
+}
+
+// parameterVariable returns the {{variable}} a required parameter without an example
+// is sent with, adding the variable to the environment.
+func (s *openAPISpec) parameterVariable(param map[string]any) (string, bool) {
+	if required, _ := param["required"].(bool); !required {
+		return "", false
+	}
+
+	name := asString(param["name"])
+	if _, ok := s.env.Get(name); !ok {
+		s.env.Set(name, "")
+	}
+
+	return "{{" + name + "}}", true
+}
+
+func (s *openAPISpec) parameterExample(param map[string]any) (string, bool) {
+	candidates := []any{param["example"], param["x-example"], param["default"]}
+
+	examples := asMap(param["examples"])
+	for _, name := range sortedKeys(examples) {
+		candidates = append(candidates, s.resolve(examples[name])["value"])
+		break
+	}
+
+	schema := s.resolve(param["schema"])
+	candidates = append(candidates, schema["example"], schema["default"], param["enum"], schema["enum"])
+
+	for _, candidate := range candidates {
+		if list, ok := candidate.([]any); ok {
+			if len(list) == 0 {
+				continue
+			}
+			candidate = list[0]
+		}
+
+		if candidate != nil {
+			return scalarString(candidate), true
+		}
+	}
+
+	return "", false
+}
+
+func (s *openAPISpec) requestBody(requestBody map[string]any) *types.RequestBody {
+	content := asMap(requestBody["content"])
+	if len(content) == 0 {
+		return nil
+	}
+
+	mediaType := preferredMediaType(content)
+	media := s.resolve(content[mediaType])
+
+	example := media["example"]
+	if example == nil {
+		for _, name := range sortedKeys(asMap(media["examples"])) {
+			example = s.resolve(asMap(media["examples"])[name])["value"]
+			break
+		}
+	}
+
+	if example == nil {
+		example = s.schemaExample(s.resolve(media["schema"]), 0)
+	}
+
+	return encodeExample(mediaType, normalizeExample(example))
+}
+
+func (s *openAPISpec) swaggerBody(operation, schema map[string]any) *types.RequestBody {
+	mediaType := "application/json"
+	if consumes := s.consumes(operation); len(consumes) > 0 {
+		mediaType = consumes[0]
+	}
+
+	return encodeExample(mediaType, normalizeExample(s.schemaExample(schema, 0)))
+}
+
+func (s *openAPISpec) swaggerFormBody(operation map[string]any, params []map[string]any) *types.RequestBody {
+	mediaType := "application/x-www-form-urlencoded"
+
+	for _, consumes := range s.consumes(operation) {
+		if strings.HasPrefix(consumes, "multipart/form-data") {
+			mediaType = "multipart/form-data"
+		}
+	}
+
+	fields := make(map[string]any)
+	for _, param := range params {
+		if asString(param["type"]) == "file" {
+			mediaType = "multipart/form-data"
+		}
+
+		value, _ := s.parameterExample(param)
+		fields[asString(param["name"])] = value
+	}
+
+	return encodeExample(mediaType, fields)
+}
+
+func (s *openAPISpec) consumes(operation map[string]any) []string {
+	list := asSlice(operation["consumes"])
+	if list == nil {
+		list = asSlice(s.root["consumes"])
+	}
+
+	var consumes []string
+	for _, item := range list {
+		consumes = append(consumes, asString(item))
+	}
+
+	return consumes
+}
+
+// schemaExample builds an example value from a schema: its example, default or first
+// enum value, or a value generated from its type.
+func (s *openAPISpec) schemaExample(schema map[string]any, depth int) any {
+	if schema == nil || depth > openAPIMaxSchemaDepth {
+		return nil
+	}
+
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+
+	if examples := asSlice(schema["examples"]); len(examples) > 0 {
+		return examples[0]
+	}
+
+	if value, ok := schema["default"]; ok {
+		return value
+	}
+
+	if value, ok := schema["const"]; ok {
+		return value
+	}
+
+	if enum := asSlice(schema["enum"]); len(enum) > 0 {
+		return enum[0]
+	}
+
+	if allOf := asSlice(schema["allOf"]); len(allOf) > 0 {
+		merged := make(map[string]any)
+		for _, part := range allOf {
+			if object, ok := s.schemaExample(s.resolve(part), depth+1).(map[string]any); ok {
+				for k, v := range object {
+					merged[k] = v
+				}
+			}
+		}
+
+		return merged
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options := asSlice(schema[key]); len(options) > 0 {
+			return s.schemaExample(s.resolve(options[0]), depth+1)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		object := make(map[string]any)
+		for name, property := range asMap(schema["properties"]) {
+			object[name] = s.schemaExample(s.resolve(property), depth+1)
+		}
+
+		return object
+	case "array":
+		item := s.schemaExample(s.resolve(schema["items"]), depth+1)
+		if item == nil {
+			return []any{}
+		}
+
+		return []any{item}
+	case "string":
+		return stringExample(asString(schema["format"]))
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	}
+
+	return nil
+}
+
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		// OpenAPI 3.1 allows a list of types, e.g. ["string", "null"].
+		for _, item := range t {
+			if name := asString(item); name != "null" {
+				return name
+			}
+		}
+	}
+
+	if schema["properties"] != nil {
+		return "object"
+	}
+
+	return ""
+}
+
+func stringExample(format string) string {
+	switch format {
+	case "date-time":
+		return "2025-01-01T00:00:00Z"
+	case "date":
+		return "2025-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	case "ipv4":
+		return "127.0.0.1"
+	default:
+		return "string"
+	}
+}
+
+// auth maps the first usable security requirement of the operation, or of the spec,
+// to request auth. Credentials are left as {{variables}} of the environment.
+func (s *openAPISpec) auth(operation map[string]any) *types.Auth {
+	requirements, ok := operation["security"]
+	if !ok {
+		requirements = s.root["security"]
+	}
+
+	schemes := asMap(s.root["securityDefinitions"])
+	if s.version == 3 {
+		schemes = asMap(asMap(s.root["components"])["securitySchemes"])
+	}
+
+	for _, requirement := range asSlice(requirements) {
+		for _, name := range sortedKeys(asMap(requirement)) {
+			if auth := s.schemeAuth(s.resolve(schemes[name])); auth != nil {
+				return auth
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *openAPISpec) schemeAuth(scheme map[string]any) *types.Auth {
+	schemeType := strings.ToLower(asString(scheme["type"]))
+	if schemeType == "http" {
+		schemeType = strings.ToLower(asString(scheme["scheme"]))
+	}
+
+	switch schemeType {
+	case "basic":
+		s.setEmpty("username")
+		s.setEmpty("password")
+		return &types.Auth{Type: "basic", Username: "{{username}}", Password: "{{password}}"}
+	case "bearer", "oauth2", "openidconnect":
+		s.setEmpty("token")
+		return &types.Auth{Type: "bearer", Token: "{{token}}"}
+	case "apikey":
+		location := asString(scheme["in"])
+		if location != "header" && location != "query" {
+			return nil
+		}
+
+		s.setEmpty("apiKey")
+		return &types.Auth{
+			Type:     "apikey",
+			APIKey:   "{{apiKey}}",
+			KeyName:  asString(scheme["name"]),
+			Location: location,
+		}
+	}
+
+	return nil
+}
+
+func (s *openAPISpec) setEmpty(name string) {
+	if _, ok := s.env.Get(name); !ok {
+		s.env.Set(name, "")
+	}
+}
+
+// resolve returns the node as a map, following local $ref pointers such as
+// #/components/schemas/User. Unresolvable references give nil.
+func (s *openAPISpec) resolve(node any) map[string]any {
+	current := asMap(node)
+
+	for depth := 0; current != nil; depth++ {
+		ref, ok := current["$ref"].(string)
+		if !ok {
+			return current
+		}
+
+		if depth >= openAPIMaxRefDepth || !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+
+		var target any = s.root
+		for _, token := range strings.Split(ref[2:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			if unescaped, err := url.PathUnescape(token); err == nil {
+				token = unescaped
+			}
+			target = asMap(target)[token]
+		}
+
+		current = asMap(target)
+	}
+
+	return nil
+}
+
+func preferredMediaType(content map[string]any) string {
+	for _, preferred := range openAPIMediaTypes {
+		if _, ok := content[preferred]; ok {
+			return preferred
+		}
+	}
+
+	mediaTypes := sortedKeys(content)
+	for _, mediaType := range mediaTypes {
+		if strings.HasSuffix(mediaType, "+json") {
+			return mediaType
+		}
+	}
+
+	return mediaTypes[0]
+}
+
+func encodeExample(mediaType string, example any) *types.RequestBody {
+	lower := strings.ToLower(mediaType)
+
+	switch {
+	case strings.Contains(lower, "json"):
+		content, err := json.MarshalIndent(example, "", "  ")
+		if err != nil || example == nil {
+			content = nil
+		}
+
+		return &types.RequestBody{Type: "json", Content: content, ContentType: mediaType}
+	case lower == "application/x-www-form-urlencoded":
+		values := url.Values{}
+		for _, name := range sortedKeys(asMap(example)) {
+			values.Set(name, scalarString(asMap(example)[name]))
+		}
+
+		return &types.RequestBody{Type: "urlencoded", Content: []byte(values.Encode()), ContentType: mediaType}
+	case lower == "multipart/form-data":
+		var fields []formField
+		for _, name := range sortedKeys(asMap(example)) {
+			fields = append(fields, formField{name: name, value: scalarString(asMap(example)[name])})
+		}
+
+		body, err := multipartBody(fields)
+		if err != nil {
+			return nil
+		}
+
+		return body
+	}
+
+	var content []byte
+	if text, ok := example.(string); ok {
+		content = []byte(text)
+	}
+
+	bodyType := "raw"
+	switch {
+	case strings.Contains(lower, "xml"):
+		bodyType = "xml"
+	case strings.HasPrefix(lower, "text/"):
+		bodyType = "text"
+	}
+
+	return &types.RequestBody{Type: bodyType, Content: content, ContentType: mediaType}
+}
+
+// normalizeExample turns values that YAML decodes into Go types, such as timestamps,
+// back into the plain values written in the spec.
+func normalizeExample(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339)
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeExample(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeExample(item)
+		}
+	}
+
+	return value
+}
+
+func scalarString(value any) string {
+	switch v := normalizeExample(value).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func asMap(value any) map[string]any {
+	m, _ := value.(map[string]any)
+	return m
+}
+
+func asSlice(value any) []any {
+	s, _ := value.([]any)
+	return s
+}
+
+func asString(value any) string {
+	s, _ := value.(string)
+	return s
+}
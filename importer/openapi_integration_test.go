@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+)
+
+func TestIntegrationImportFromOpenAPI_ExecuteImported(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	spec := `openapi: 3.0.0
+info:
+  title: Echo
+servers:
+  - url: ` + http_server.GetServerURL() + `
+paths:
+  /echo:
+    post:
+      summary: Echo
+      requestBody:
+        content:
+          application/json:
+            example:
+              message: hi
+`
+
+	filePath, cleanup := writeSpec(t, "echo.yaml", spec)
+	defer cleanup()
+
+	collection, env, err := ImportFromOpenAPI(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(collection.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(collection.Items))
+	}
+
+	resolver, err := core.NewVariableResolver(env, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := collection.Items[0].Request
+	req.URL, err = resolver.Resolve(req.URL)
+	if err != nil {
+		t.Fatalf("failed to resolve URL: %v", err)
+	}
+
+	client := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var echo struct {
+		Method  string              `json:"method"`
+		Headers map[string][]string `json:"headers"`
+		Body    string              `json:"body"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		t.Fatalf("failed to parse echo response: %v", err)
+	}
+
+	if echo.Method != "POST" || echo.Headers["Content-Type"][0] != "application/json" {
+		t.Errorf("unexpected echo %s %v", echo.Method, echo.Headers)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal([]byte(echo.Body), &body); err != nil || body["message"] != "hi" {
+		t.Errorf("expected example body to be sent, got %q", echo.Body)
+	}
+}
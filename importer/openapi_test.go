@@ -0,0 +1,390 @@
+package importer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+)
+
+const testOpenAPI3YAML = `openapi: 3.0.3
+info:
+  title: Pet Store
+  description: Pets API
+servers:
+  - url: https://{region}.example.com/v1/
+    variables:
+      region:
+        default: eu
+  - url: http://localhost:8080
+tags:
+  - name: pets
+  - name: store
+security:
+  - bearerAuth: []
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetId'
+    get:
+      tags: [pets]
+      summary: Get a pet
+      parameters:
+        - name: verbose
+          in: query
+          schema:
+            type: boolean
+            example: true
+        - name: fields
+          in: query
+          example: name,tags&owner
+        - name: page
+          in: query
+          required: true
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+    delete:
+      tags: [pets]
+      operationId: deletePet
+      security: []
+  /pets:
+    post:
+      tags: [pets]
+      summary: Create a pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /orders:
+    post:
+      tags: [store]
+      summary: Place an order
+      security:
+        - apiKey: []
+      requestBody:
+        content:
+          application/json:
+            examples:
+              simple:
+                value:
+                  petId: 7
+                  shipDate: 2025-03-01
+  /health:
+    get:
+      summary: Health
+components:
+  parameters:
+    PetId:
+      name: petId
+      in: path
+      required: true
+      schema:
+        type: integer
+        example: 42
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          example: Rex
+        tags:
+          type: array
+          items:
+            type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      allOf:
+        - type: object
+          properties:
+            email:
+              type: string
+              format: email
+        - type: object
+          properties:
+            age:
+              type: integer
+`
+
+const testSwagger2JSON = `{
+	"swagger": "2.0",
+	"info": {"title": "Legacy API"},
+	"host": "api.example.com",
+	"basePath": "/v2",
+	"schemes": ["https", "http"],
+	"securityDefinitions": {
+		"basic": {"type": "basic"},
+		"key": {"type": "apiKey", "in": "query", "name": "api_key"}
+	},
+	"paths": {
+		"/users/{id}": {
+			"put": {
+				"tags": ["users"],
+				"summary": "Update user",
+				"security": [{"key": []}],
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "string"},
+					{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/User"}}
+				]
+			}
+		},
+		"/upload": {
+			"post": {
+				"summary": "Upload",
+				"security": [{"basic": []}],
+				"consumes": ["multipart/form-data"],
+				"parameters": [
+					{"name": "file", "in": "formData", "type": "file"},
+					{"name": "comment", "in": "formData", "type": "string", "default": "hello"}
+				]
+			}
+		}
+	},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "string", "format": "uuid"},
+				"admin": {"type": "boolean"}
+			}
+		}
+	}
+}`
+
+func writeSpec(t *testing.T, name, content string) (string, func()) {
+	t.Helper()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	filePath := filepath.Join(dir, name)
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	return filePath, func() { helper.CleanupTempDir(dir) }
+}
+
+func TestUnitImportFromOpenAPI_V3(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "petstore.yaml", testOpenAPI3YAML)
+	defer cleanup()
+
+	collection, env, err := ImportFromOpenAPI(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if collection.Name != "Pet Store" || collection.Description != "Pets API" {
+		t.Errorf("unexpected collection info %q %q", collection.Name, collection.Description)
+	}
+
+	if collection.EnvName != env.Name {
+		t.Errorf("expected collection to be linked to environment %q, got %q", env.Name, collection.EnvName)
+	}
+
+	if baseURL, _ := env.Get("baseUrl"); baseURL != "https://eu.example.com/v1" {
+		t.Errorf("unexpected baseUrl %q", baseURL)
+	}
+
+	if baseURL, _ := env.Get("baseUrl2"); baseURL != "http://localhost:8080" {
+		t.Errorf("unexpected baseUrl2 %q", baseURL)
+	}
+
+	var names, folders []string
+	for _, item := range collection.Items {
+		names = append(names, item.Name)
+		folders = append(folders, item.Folder)
+	}
+
+	expectedNames := []string{"Create a pet", "Get a pet", "deletePet", "Place an order", "Health"}
+	expectedFolders := []string{"pets", "pets", "pets", "store", ""}
+	if strings.Join(names, ",") != strings.Join(expectedNames, ",") {
+		t.Errorf("expected items %v, got %v", expectedNames, names)
+	}
+	if strings.Join(folders, ",") != strings.Join(expectedFolders, ",") {
+		t.Errorf("expected folders %v, got %v", expectedFolders, folders)
+	}
+}
+
+func TestUnitImportFromOpenAPI_V3Requests(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "petstore.yaml", testOpenAPI3YAML)
+	defer cleanup()
+
+	collection, env, err := ImportFromOpenAPI(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items := make(map[string]int)
+	for i, item := range collection.Items {
+		items[item.Name] = i
+	}
+
+	getPet := collection.Items[items["Get a pet"]].Request
+	if getPet.Method != "GET" || getPet.URL != "{{baseUrl}}/pets/{{petId}}?verbose=true&fields=name%2Ctags%26owner&page={{page}}" {
+		t.Errorf("unexpected request %s %s", getPet.Method, getPet.URL)
+	}
+
+	if petID, _ := env.Get("petId"); petID != "42" {
+		t.Errorf("expected path parameter example in environment, got %q", petID)
+	}
+
+	if getPet.Headers["X-Request-ID"] != "{{X-Request-ID}}" {
+		t.Errorf("expected required header as variable, got %v", getPet.Headers)
+	}
+
+	if getPet.Auth == nil || getPet.Auth.Type != "bearer" || getPet.Auth.Token != "{{token}}" {
+		t.Errorf("expected bearer auth from global security, got %+v", getPet.Auth)
+	}
+
+	if deletePet := collection.Items[items["deletePet"]].Request; deletePet.Auth != nil {
+		t.Errorf("expected no auth for empty security, got %+v", deletePet.Auth)
+	}
+
+	createPet := collection.Items[items["Create a pet"]].Request
+	if createPet.Body == nil || createPet.Body.Type != "json" || createPet.Body.ContentType != "application/json" {
+		t.Fatalf("expected JSON body, got %+v", createPet.Body)
+	}
+
+	var pet map[string]any
+	if err := json.Unmarshal(createPet.Body.Content, &pet); err != nil {
+		t.Fatalf("invalid example body: %v", err)
+	}
+
+	owner, _ := pet["owner"].(map[string]any)
+	if pet["name"] != "Rex" || owner["email"] != "user@example.com" || owner["age"] != float64(0) {
+		t.Errorf("unexpected generated example %s", createPet.Body.Content)
+	}
+
+	order := collection.Items[items["Place an order"]].Request
+	if !strings.Contains(string(order.Body.Content), `"shipDate": "2025-03-01"`) {
+		t.Errorf("expected example value from the spec, got %s", order.Body.Content)
+	}
+
+	if order.Auth == nil || order.Auth.Type != "apikey" || order.Auth.KeyName != "X-API-Key" || order.Auth.Location != "header" {
+		t.Errorf("expected API key auth, got %+v", order.Auth)
+	}
+
+	for _, name := range []string{"token", "apiKey"} {
+		if _, ok := env.Get(name); !ok {
+			t.Errorf("expected credential variable %s in environment", name)
+		}
+	}
+}
+
+func TestUnitImportFromOpenAPI_Swagger2(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "legacy.json", testSwagger2JSON)
+	defer cleanup()
+
+	collection, env, err := ImportFromOpenAPI(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if baseURL, _ := env.Get("baseUrl"); baseURL != "https://api.example.com/v2" {
+		t.Errorf("unexpected baseUrl %q", baseURL)
+	}
+
+	if len(collection.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(collection.Items))
+	}
+
+	update := collection.Items[0]
+	if update.Name != "Update user" || update.Folder != "users" {
+		t.Errorf("unexpected item %q in folder %q", update.Name, update.Folder)
+	}
+
+	if update.Request.URL != "{{baseUrl}}/users/{{id}}" {
+		t.Errorf("unexpected URL %s", update.Request.URL)
+	}
+
+	if !strings.Contains(string(update.Request.Body.Content), `"id": "00000000-0000-0000-0000-000000000000"`) {
+		t.Errorf("expected body generated from definitions, got %s", update.Request.Body.Content)
+	}
+
+	if auth := update.Request.Auth; auth == nil || auth.Type != "apikey" || auth.Location != "query" || auth.KeyName != "api_key" {
+		t.Errorf("expected API key query auth, got %+v", auth)
+	}
+
+	upload := collection.Items[1].Request
+	if upload.Body == nil || upload.Body.Type != "formdata" || !strings.HasPrefix(upload.Body.ContentType, "multipart/form-data") {
+		t.Fatalf("expected multipart body, got %+v", upload.Body)
+	}
+
+	if !strings.Contains(string(upload.Body.Content), "hello") {
+		t.Errorf("expected default form value in body, got %s", upload.Body.Content)
+	}
+
+	if auth := upload.Auth; auth == nil || auth.Type != "basic" || auth.Username != "{{username}}" {
+		t.Errorf("expected basic auth, got %+v", auth)
+	}
+}
+
+func TestUnitImportFromOpenAPI_Invalid(t *testing.T) {
+	filePath, cleanup := writeSpec(t, "other.json", `{"info": {"title": "Not a spec"}}`)
+	defer cleanup()
+
+	if _, _, err := ImportFromOpenAPI(filePath); err == nil {
+		t.Error("expected error for a document without an OpenAPI version")
+	}
+
+	invalidPath, cleanupInvalid := writeSpec(t, "invalid.yaml", "openapi: [")
+	defer cleanupInvalid()
+
+	if _, _, err := ImportFromOpenAPI(invalidPath); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+
+	if _, _, err := ImportFromOpenAPI("/nonexistent/openapi.yaml"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestUnitOpenAPIResolve_Cycle(t *testing.T) {
+	spec := &openAPISpec{root: map[string]any{
+		"a": map[string]any{"$ref": "#/b"},
+		"b": map[string]any{"$ref": "#/a"},
+	}}
+
+	if resolved := spec.resolve(map[string]any{"$ref": "#/a"}); resolved != nil {
+		t.Errorf("expected cyclic reference to resolve to nil, got %v", resolved)
+	}
+}
+
+func TestUnitOpenAPIParameterExample_Examples(t *testing.T) {
+	spec := &openAPISpec{root: map[string]any{}}
+	param := map[string]any{
+		"name": "status",
+		"in":   "query",
+		"examples": map[string]any{
+			"sold":      map[string]any{"value": "sold"},
+			"available": map[string]any{"value": "available"},
+			"pending":   map[string]any{"value": "pending"},
+		},
+	}
+
+	// Map iteration order is random; the first example by name must win every time.
+	for range 20 {
+		if example, ok := spec.parameterExample(param); !ok || example != "available" {
+			t.Fatalf("expected the first example by name, got %q", example)
+		}
+	}
+}
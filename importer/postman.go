@@ -57,6 +57,14 @@ type PostmanBody struct {
 	Raw        string              `json:"raw,omitempty"`
 	Formdata   []PostmanFormData   `json:"formdata,omitempty"`
 	Urlencoded []PostmanURLEncoded `json:"urlencoded,omitempty"`
+	GraphQL    *PostmanGraphQL     `json:"graphql,omitempty"`
+}
+
+// PostmanGraphQL represents a GraphQL body of a Postman request.
+// Variables hold the operation variables as a JSON document.
+type PostmanGraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
 }
 
 // PostmanFormData represents a form data field in a Postman request.
@@ -205,11 +213,38 @@ func convertPostmanBody(postmanBody *PostmanBody) *types.RequestBody {
 			Content:     []byte(body),
 			ContentType: "application/x-www-form-urlencoded",
 		}
+	case "graphql":
+		if postmanBody.GraphQL == nil {
+			return nil
+		}
+		return convertPostmanGraphQL(postmanBody.GraphQL)
 	default:
 		return nil
 	}
 }
 
+// convertPostmanGraphQL maps a Postman GraphQL body to a graphql body.
+// Variables that are not valid JSON (e.g. unquoted {{var}} placeholders) are kept
+// verbatim as VariablesText, which is parsed once the placeholders are resolved.
+func convertPostmanGraphQL(postmanGraphQL *PostmanGraphQL) *types.RequestBody {
+	body := &types.RequestBody{
+		Type:        "graphql",
+		ContentType: "application/json",
+		GraphQL:     &types.GraphQLBody{Query: postmanGraphQL.Query},
+	}
+
+	if strings.TrimSpace(postmanGraphQL.Variables) != "" {
+		var variables map[string]any
+		if err := json.Unmarshal([]byte(postmanGraphQL.Variables), &variables); err != nil {
+			body.GraphQL.VariablesText = postmanGraphQL.Variables
+		} else {
+			body.GraphQL.Variables = variables
+		}
+	}
+
+	return body
+}
+
 func convertPostmanAuth(postmanAuth *PostmanAuth) *types.Auth {
 	authType := strings.ToLower(postmanAuth.Type)
 
@@ -178,6 +178,91 @@ func TestUnitImportFromPostman_WithBody_RAW(t *testing.T) {
 	}
 }
 
+func TestUnitImportFromPostman_WithBody_GraphQL(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	postmanJSON := `{
+		"info": {
+			"name": "Test Collection",
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+		},
+		"item": [
+			{
+				"name": "GraphQL Request",
+				"request": {
+					"method": "POST",
+					"body": {
+						"mode": "graphql",
+						"graphql": {
+							"query": "query User($id: ID!) { user(id: $id) { name } }",
+							"variables": "{\"id\": \"{{userId}}\"}"
+						}
+					},
+					"url": {
+						"raw": "http://example.com/graphql"
+					}
+				}
+			},
+			{
+				"name": "Unquoted Variables",
+				"request": {
+					"method": "POST",
+					"body": {
+						"mode": "graphql",
+						"graphql": {
+							"query": "query { user(id: $id) { name } }",
+							"variables": "{\"id\": {{userId}}}"
+						}
+					},
+					"url": {
+						"raw": "http://example.com/graphql"
+					}
+				}
+			}
+		]
+	}`
+
+	filePath := filepath.Join(dir, "postman.json")
+	if err := os.WriteFile(filePath, []byte(postmanJSON), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	collection, err := ImportFromPostman(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(collection.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(collection.Items))
+	}
+
+	body := collection.Items[0].Request.Body
+	if body == nil || body.Type != "graphql" || body.GraphQL == nil {
+		t.Fatalf("expected graphql body, got %+v", body)
+	}
+
+	if body.GraphQL.Query != "query User($id: ID!) { user(id: $id) { name } }" {
+		t.Errorf("unexpected query: %s", body.GraphQL.Query)
+	}
+
+	if body.GraphQL.Variables["id"] != "{{userId}}" {
+		t.Errorf("expected variable id to be '{{userId}}', got %v", body.GraphQL.Variables["id"])
+	}
+
+	unquoted := collection.Items[1].Request.Body
+	if unquoted == nil || unquoted.Type != "graphql" || unquoted.GraphQL == nil {
+		t.Fatalf("expected graphql body, got %+v", unquoted)
+	}
+
+	if unquoted.GraphQL.VariablesText != `{"id": {{userId}}}` || unquoted.GraphQL.Variables != nil {
+		t.Errorf("expected variables to be kept verbatim, got %+v", unquoted.GraphQL)
+	}
+}
+
 func TestUnitImportFromPostman_WithBody_FormData(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 	if err != nil {
@@ -0,0 +1,216 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// formBoundary is used for imported multipart bodies, so that importing
+// the same source twice gives the same request.
+const formBoundary = "getman-form-boundary"
+
+// clientManagedHeaders are set by the HTTP client on its own and are not imported.
+// Accept-Encoding is left to the client too, so that compressed responses are still
+// decoded.
+var clientManagedHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"connection":        true,
+	"accept-encoding":   true,
+	"transfer-encoding": true,
+}
+
+// formField is a field of an imported multipart form.
+// Fields with a filename are sent as file uploads.
+type formField struct {
+	name     string
+	value    string
+	filename string
+	mimeType string
+}
+
+func multipartBody(fields []formField) (*types.RequestBody, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	if err := writer.SetBoundary(formBoundary); err != nil {
+		return nil, fmt.Errorf("failed to build form body: %w", err)
+	}
+
+	for _, field := range fields {
+		header := make(textproto.MIMEHeader)
+		disposition := fmt.Sprintf(`form-data; name=%q`, field.name)
+		if field.filename != "" {
+			disposition += fmt.Sprintf(`; filename=%q`, field.filename)
+		}
+		header.Set("Content-Disposition", disposition)
+
+		switch {
+		case field.mimeType != "":
+			header.Set("Content-Type", field.mimeType)
+		case field.filename != "":
+			header.Set("Content-Type", "application/octet-stream")
+		}
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build form body: %w", err)
+		}
+
+		if _, err := part.Write([]byte(field.value)); err != nil {
+			return nil, fmt.Errorf("failed to build form body: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build form body: %w", err)
+	}
+
+	return &types.RequestBody{
+		Type:        "formdata",
+		Content:     buf.Bytes(),
+		ContentType: writer.FormDataContentType(),
+	}, nil
+}
+
+// bodyTypeFor returns the body type matching a content type.
+func bodyTypeFor(contentType string) string {
+	contentType = strings.ToLower(contentType)
+
+	switch {
+	case strings.Contains(contentType, "json"):
+		return "json"
+	case strings.Contains(contentType, "xml"):
+		return "xml"
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		return "urlencoded"
+	case strings.HasPrefix(contentType, "text/"):
+		return "text"
+	default:
+		return "raw"
+	}
+}
+
+// requestItemName names an imported request after its method, host and path.
+func requestItemName(req *types.Request) string {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return req.Method + " " + req.URL
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return req.Method + " " + u.Host + path
+}
+
+// findHeader returns the key of a header in a case-insensitive way.
+func findHeader(headers map[string]string, name string) (string, bool) {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// appendHeader adds a header value, joining repeated headers like they are sent on the wire.
+func appendHeader(headers map[string]string, name, value string) {
+	key, ok := findHeader(headers, name)
+	if !ok {
+		headers[name] = value
+		return
+	}
+
+	separator := ", "
+	if strings.EqualFold(name, "Cookie") {
+		separator = "; "
+	}
+
+	headers[key] += separator + value
+}
+
+// moveContentType moves a Content-Type header to the body, where the client applies it.
+// Multipart bodies built by the importer keep their own content type with the boundary.
+func moveContentType(req *types.Request) {
+	key, ok := findHeader(req.Headers, "Content-Type")
+	if !ok || req.Body == nil {
+		return
+	}
+
+	contentType := req.Headers[key]
+	delete(req.Headers, key)
+
+	if strings.Contains(req.Body.ContentType, "boundary="+formBoundary) {
+		return
+	}
+
+	req.Body.ContentType = contentType
+	if req.Body.Type != "graphql" {
+		req.Body.Type = bodyTypeFor(contentType)
+	}
+}
+
+// appendQuery appends a query parameter to a URL. Names and values holding
+// {{variables}} are kept as they are so that they are still resolved.
+func appendQuery(rawURL, name, value string) string {
+	escape := func(s string) string {
+		if strings.Contains(s, "{{") {
+			return s
+		}
+		return url.QueryEscape(s)
+	}
+
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+
+	return rawURL + separator + escape(name) + "=" + escape(value)
+}
+
+// authFromHeader converts a bearer or basic Authorization header to auth settings.
+// A converted header is removed from the headers.
+func authFromHeader(headers map[string]string) *types.Auth {
+	name, ok := findHeader(headers, "Authorization")
+	if !ok {
+		return nil
+	}
+
+	scheme, credentials, _ := strings.Cut(headers[name], " ")
+	credentials = strings.TrimSpace(credentials)
+
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		delete(headers, name)
+		return &types.Auth{Type: "bearer", Token: credentials}
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return nil
+		}
+
+		username, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return nil
+		}
+
+		delete(headers, name)
+		return &types.Auth{Type: "basic", Username: username, Password: password}
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package importer
+
+import (
+	"fmt"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/environment"
+)
+
+// ImportResult holds the collections and environments imported from the files of
+// another tool. Warnings list the features that could not be imported.
+type ImportResult struct {
+	Collections  []*collections.Collection
+	Environments []*environment.Environment
+	Warnings     []string
+}
+
+func (r *ImportResult) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package logging
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/KonnorFrik/getman/errors"
+)
+
+// Log formats of Config.Logging.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ParseLevel parses a log level: "debug", "info", "warn" or "error", in any case.
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("%w: unknown log level %q", errors.ErrInvalidArgument, level)
+	}
+
+	return l, nil
+}
+
+// NewHandler creates a handler writing records of at least the given level to w
+// as text or JSON lines. Secrets are redacted, see RedactAttr.
+func NewHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: l, ReplaceAttr: RedactAttr}
+
+	switch strings.ToLower(format) {
+	case FormatText:
+		return slog.NewTextHandler(w, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown log format %q", errors.ErrInvalidArgument, format)
+	}
+}
+
+// New creates a logger writing records of at least the given level to w as text
+// or JSON lines, like NewHandler.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	handler, err := NewHandler(w, level, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return slog.New(handler), nil
+}
+
+// Discard returns a logger dropping every record.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// Tee returns a handler passing every record to each of the handlers enabled for
+// its level.
+func Tee(handlers ...slog.Handler) slog.Handler {
+	return teeHandler(handlers)
+}
+
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range t {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+
+	for _, handler := range t {
+		if handler.Enabled(ctx, record.Level) {
+			errs = append(errs, handler.Handle(ctx, record.Clone()))
+		}
+	}
+
+	return stderrors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, 0, len(t))
+	for _, handler := range t {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, 0, len(t))
+	for _, handler := range t {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+
+	return handlers
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/errors"
+)
+
+func TestUnitParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"info":  slog.LevelInfo,
+		"WARN":  slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+
+	for text, expected := range tests {
+		level, err := ParseLevel(text)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", text, err)
+		}
+		if level != expected {
+			t.Errorf("%s: expected %v, got %v", text, expected, level)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); !stderrors.Is(err, errors.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestUnitNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, "info", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Debug("hidden")
+	logger.Info("shown", "token", "abc123")
+
+	output := buf.String()
+	if strings.Contains(output, "hidden") {
+		t.Errorf("expected debug records to be dropped, got %q", output)
+	}
+	if !strings.Contains(output, "msg=shown") || !strings.Contains(output, "token=***") {
+		t.Errorf("expected a redacted text record, got %q", output)
+	}
+}
+
+func TestUnitNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, "debug", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Debug("request", "url", "http://example.com/?api_key=secret&page=1")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["url"] != "http://example.com/?api_key=***&page=1" {
+		t.Errorf("expected redacted URL, got %v", record["url"])
+	}
+}
+
+func TestUnitNew_InvalidFormat(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "info", "xml"); !stderrors.Is(err, errors.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestUnitTee(t *testing.T) {
+	var debugBuf, errorBuf bytes.Buffer
+
+	debugHandler, _ := NewHandler(&debugBuf, "debug", "text")
+	errorHandler, _ := NewHandler(&errorBuf, "error", "text")
+
+	logger := slog.New(Tee(debugHandler, errorHandler)).With("run", 1)
+
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("expected the tee to be enabled for the lowest level")
+	}
+
+	logger.Info("started")
+	logger.Error("failed")
+
+	if !strings.Contains(debugBuf.String(), "msg=started run=1") || !strings.Contains(debugBuf.String(), "msg=failed") {
+		t.Errorf("expected both records in the debug handler, got %q", debugBuf.String())
+	}
+	if strings.Contains(errorBuf.String(), "started") || !strings.Contains(errorBuf.String(), "msg=failed run=1") {
+		t.Errorf("expected only the error record in the error handler, got %q", errorBuf.String())
+	}
+}
+
+func TestUnitDiscard(t *testing.T) {
+	if Discard().Enabled(context.Background(), slog.LevelError) {
+		t.Error("expected the discard logger to be disabled")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Recorder is a handler keeping records as log entries, such as the records of a
+// collection run, to be saved with Backend.SaveLogs. The attributes of a record are
+// appended to its message as key=value pairs, with secrets redacted.
+type Recorder struct {
+	handler slog.Handler
+	state   *recorderState
+}
+
+// recorderState is shared by a Recorder and the handlers derived from it.
+type recorderState struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	entries []types.LogEntry
+}
+
+// NewRecorder creates a Recorder keeping records of at least the given level.
+func NewRecorder(level slog.Leveler) *Recorder {
+	state := &recorderState{}
+
+	return &Recorder{
+		handler: slog.NewTextHandler(&state.buf, &slog.HandlerOptions{Level: level, ReplaceAttr: recordAttr}),
+		state:   state,
+	}
+}
+
+// recordAttr leaves the time, level and message out of the formatted attributes,
+// since log entries keep them apart.
+func recordAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 {
+		switch attr.Key {
+		case slog.TimeKey, slog.LevelKey, slog.MessageKey:
+			return slog.Attr{}
+		}
+	}
+
+	return RedactAttr(groups, attr)
+}
+
+// Enabled reports whether the recorder keeps records of the level.
+func (r *Recorder) Enabled(ctx context.Context, level slog.Level) bool {
+	return r.handler.Enabled(ctx, level)
+}
+
+// Handle keeps a record as a log entry.
+func (r *Recorder) Handle(ctx context.Context, record slog.Record) error {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.state.buf.Reset()
+	if err := r.handler.Handle(ctx, record); err != nil {
+		return err
+	}
+
+	message := record.Message
+	if attrs := strings.TrimSpace(r.state.buf.String()); attrs != "" {
+		message += " " + attrs
+	}
+
+	r.state.entries = append(r.state.entries, types.LogEntry{
+		Time:    record.Time,
+		Level:   strings.ToLower(record.Level.String()),
+		Message: message,
+	})
+
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to the records it keeps in r.
+func (r *Recorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Recorder{handler: r.handler.WithAttrs(attrs), state: r.state}
+}
+
+// WithGroup returns a handler qualifying the attributes of the records it keeps in r.
+func (r *Recorder) WithGroup(name string) slog.Handler {
+	return &Recorder{handler: r.handler.WithGroup(name), state: r.state}
+}
+
+// Entries returns the log entries kept so far, oldest first.
+func (r *Recorder) Entries() []types.LogEntry {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	entries := make([]types.LogEntry, len(r.state.entries))
+	copy(entries, r.state.entries)
+	return entries
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestUnitRecorder_Entries(t *testing.T) {
+	recorder := NewRecorder(slog.LevelInfo)
+	logger := slog.New(recorder).With("collection", "api")
+
+	logger.Debug("hidden")
+	logger.Info("request executed", "url", "http://example.com/?token=abc", "status", 200)
+	logger.WithGroup("headers").Warn("sent", "Authorization", "Bearer abc")
+
+	entries := recorder.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+
+	if entries[0].Level != "info" || entries[0].Message != `request executed collection=api url="http://example.com/?token=***" status=200` {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+	if entries[1].Level != "warn" || entries[1].Message != "sent collection=api headers.Authorization=***" {
+		t.Errorf("unexpected entry: %+v", entries[1])
+	}
+	if entries[0].Time.IsZero() {
+		t.Error("expected entry time to be set")
+	}
+}
+
+func TestUnitRecorder_Concurrent(t *testing.T) {
+	recorder := NewRecorder(slog.LevelInfo)
+	logger := slog.New(recorder)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("request executed", "index", i)
+		}()
+	}
+	wg.Wait()
+
+	if entries := recorder.Entries(); len(entries) != 20 {
+		t.Errorf("expected 20 entries, got %d", len(entries))
+	}
+}
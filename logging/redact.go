@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package logging
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secret values in log records.
+const Redacted = "***"
+
+// sensitiveNames are parts of attribute, header and query parameter names that
+// hold secrets.
+var sensitiveNames = []string{
+	"authorization",
+	"cookie",
+	"token",
+	"secret",
+	"password",
+	"passwd",
+	"apikey",
+	"api_key",
+	"api-key",
+	"credential",
+	"session",
+}
+
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// IsSensitive reports whether an attribute, header or query parameter name suggests
+// that its value is a secret.
+func IsSensitive(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedactAttr hides secrets in an attribute, for use as slog.HandlerOptions.ReplaceAttr:
+// the values of sensitive attributes are replaced, and so are the passwords and the
+// sensitive query parameters of URLs in strings and errors.
+func RedactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if IsSensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactString(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.String(attr.Key, RedactString(err.Error()))
+		}
+	}
+
+	return attr
+}
+
+// RedactString hides secrets in the URLs found in s, see RedactURL.
+func RedactString(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+
+	return urlPattern.ReplaceAllStringFunc(s, RedactURL)
+}
+
+// RedactURL hides the password and the sensitive query parameters of a URL. Strings
+// that are not URLs are returned as they are.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	redacted := false
+	_, hasPassword := u.User.Password()
+
+	if hasPassword {
+		// Userinfo would escape Redacted; the escaped username cannot hold ":@".
+		u.User = url.UserPassword(u.User.Username(), "@")
+		redacted = true
+	}
+
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+
+		for i, param := range params {
+			name, _, _ := strings.Cut(param, "=")
+			if unescaped, err := url.QueryUnescape(name); err == nil {
+				name = unescaped
+			}
+
+			if IsSensitive(name) {
+				params[i] = url.QueryEscape(name) + "=" + Redacted
+				redacted = true
+			}
+		}
+
+		u.RawQuery = strings.Join(params, "&")
+	}
+
+	if !redacted {
+		return rawURL
+	}
+
+	if hasPassword {
+		return strings.Replace(u.String(), ":%40@", ":"+Redacted+"@", 1)
+	}
+
+	return u.String()
+}
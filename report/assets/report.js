@@ -0,0 +1,22 @@
+(function () {
+  "use strict";
+
+  var tests = document.querySelectorAll("details.test");
+
+  document.querySelectorAll("[data-action]").forEach(function (control) {
+    var action = control.getAttribute("data-action");
+
+    if (action === "failed-only") {
+      control.addEventListener("change", function () {
+        document.body.classList.toggle("failed-only", control.checked);
+      });
+      return;
+    }
+
+    control.addEventListener("click", function () {
+      tests.forEach(function (test) {
+        test.open = action === "expand";
+      });
+    });
+  });
+})();
@@ -0,0 +1,425 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package report
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/KonnorFrik/getman/logging"
+	"github.com/KonnorFrik/getman/types"
+	"google.golang.org/grpc/codes"
+)
+
+//go:embed assets
+var assets embed.FS
+
+var htmlTemplate = template.Must(template.New("report.html").Funcs(template.FuncMap{
+	"duration": formatDuration,
+}).ParseFS(assets, "assets/report.html"))
+
+// HTMLReporter writes results as a single self-contained HTML page: a summary of
+// the statistics and a latency chart of every run, and an expandable view of every
+// request with the request as sent, the response headers and the pretty-printed
+// body. Failed tests and their snapshot mismatches are highlighted. Styles and
+// scripts are embedded, so the page works offline. Secrets are masked: sensitive
+// headers, query parameters and JSON fields, and the credentials of the request auth.
+type HTMLReporter struct {
+	// Title is the title of the page, "Getman report" when empty.
+	Title string
+	// MaxBodySize is the number of body bytes shown per request, 64 KiB when zero.
+	MaxBodySize int
+}
+
+const defaultHTMLBodySize = 64 << 10
+
+type htmlReport struct {
+	Title     string
+	Generated time.Time
+	CSS       template.CSS
+	JS        template.JS
+	Suites    []htmlSuite
+}
+
+type htmlSuite struct {
+	Name        string
+	Environment string
+	StartTime   time.Time
+	Duration    time.Duration
+	Stats       htmlStats
+	Chart       htmlChart
+	Tests       []htmlTest
+}
+
+type htmlStats struct {
+	Total, Passed, Failed, Errors int
+	Avg, Min, Max                 time.Duration
+}
+
+type htmlChart struct {
+	Width, Height int
+	Bars          []htmlBar
+}
+
+type htmlBar struct {
+	Y, Width int
+	Label    string
+	Duration time.Duration
+	Outcome  string
+}
+
+type htmlTest struct {
+	Index      int
+	Name       string
+	Outcome    string
+	Message    string
+	StatusCode int
+	GRPCStatus string
+	Duration   time.Duration
+	Request    *htmlMessage
+	Response   *htmlMessage
+	Snapshot   *types.SnapshotResult
+	Mismatches []types.SnapshotMismatch
+}
+
+type htmlMessage struct {
+	Title   string
+	Auth    string
+	Headers []htmlHeader
+	Body    string
+	Note    string
+}
+
+type htmlHeader struct {
+	Name, Value string
+}
+
+// Format returns FormatHTML.
+func (r *HTMLReporter) Format() string {
+	return FormatHTML
+}
+
+// Report writes the HTML report of results to w.
+func (r *HTMLReporter) Report(w io.Writer, results ...*types.ExecutionResult) error {
+	css, err := assets.ReadFile("assets/report.css")
+	if err != nil {
+		return err
+	}
+
+	js, err := assets.ReadFile("assets/report.js")
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{
+		Title:     r.Title,
+		Generated: time.Now(),
+		CSS:       template.CSS(css),
+		JS:        template.JS(js),
+	}
+
+	if report.Title == "" {
+		report.Title = "Getman report"
+	}
+
+	for _, result := range results {
+		report.Suites = append(report.Suites, r.suite(result))
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, report); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+func (r *HTMLReporter) suite(result *types.ExecutionResult) htmlSuite {
+	cases := testCases(result, 0)
+	counts := summarize(cases, result.TotalDuration)
+
+	suite := htmlSuite{
+		Name:        resultName(result),
+		Environment: result.Environment,
+		StartTime:   result.StartTime,
+		Duration:    result.TotalDuration,
+		Stats: htmlStats{
+			Total:  counts.tests,
+			Passed: counts.tests - counts.failures - counts.errors,
+			Failed: counts.failures,
+			Errors: counts.errors,
+		},
+		Chart: latencyChart(cases),
+	}
+
+	if stats := result.Statistics; stats != nil {
+		suite.Stats.Avg, suite.Stats.Min, suite.Stats.Max = stats.AvgTime, stats.MinTime, stats.MaxTime
+	} else if len(cases) > 0 {
+		var total time.Duration
+		suite.Stats.Min = cases[0].duration
+
+		for _, tc := range cases {
+			total += tc.duration
+			suite.Stats.Min = min(suite.Stats.Min, tc.duration)
+			suite.Stats.Max = max(suite.Stats.Max, tc.duration)
+		}
+
+		suite.Stats.Avg = total / time.Duration(len(cases))
+	}
+
+	for i, tc := range cases {
+		mask := newMasker(tc.execution)
+
+		test := htmlTest{
+			Index:      i + 1,
+			Name:       mask.text(tc.name),
+			Outcome:    string(tc.outcome),
+			Message:    mask.text(tc.message),
+			StatusCode: tc.statusCode,
+			Duration:   tc.duration,
+			Snapshot:   tc.execution.Snapshot,
+		}
+
+		if tc.grpcCode != nil {
+			test.GRPCStatus = codes.Code(*tc.grpcCode).String()
+		}
+
+		if snapshot := tc.execution.Snapshot; snapshot != nil && snapshot.Status == types.SnapshotMismatched {
+			test.Mismatches = snapshot.Mismatches
+		}
+
+		if req := tc.execution.Request; req != nil {
+			test.Request = r.request(req, mask)
+		}
+
+		if resp := tc.execution.Response; resp != nil {
+			test.Response = r.response(resp, mask)
+		}
+
+		suite.Tests = append(suite.Tests, test)
+	}
+
+	return suite
+}
+
+func (r *HTMLReporter) request(req *types.Request, mask *masker) *htmlMessage {
+	message := &htmlMessage{
+		Title:   req.Method + " " + mask.text(logging.RedactURL(req.URL)),
+		Headers: mask.headers(singleValues(req.Headers)),
+	}
+
+	if auth := req.Auth; auth != nil && auth.Type != "" {
+		message.Auth = authSummary(auth)
+	}
+
+	if body := req.Body; body != nil {
+		switch {
+		case body.GraphQL != nil:
+			content, _ := json.Marshal(body.GraphQL)
+			message.Body = r.body(content, "application/json", mask)
+		default:
+			message.Body = r.body(body.Content, body.ContentType, mask)
+		}
+	}
+
+	return message
+}
+
+func (r *HTMLReporter) response(resp *types.Response, mask *masker) *htmlMessage {
+	status := resp.Status
+	if status == "" {
+		status = fmt.Sprint(resp.StatusCode)
+	}
+
+	message := &htmlMessage{
+		Title:   status,
+		Headers: mask.headers(resp.Headers),
+		Body:    r.body(resp.Body, firstValue(resp.Headers, "Content-Type"), mask),
+	}
+
+	if resp.Truncated {
+		message.Note = fmt.Sprintf("Body truncated, %d bytes received", resp.Size)
+	} else if resp.BodyFile != "" {
+		message.Note = "Body saved to " + resp.BodyFile
+	}
+
+	return message
+}
+
+// body renders a body for display: JSON indented with sensitive fields masked,
+// text as it is and binary content as its size.
+func (r *HTMLReporter) body(content []byte, contentType string, mask *masker) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	var value any
+	if json.Unmarshal(content, &value) == nil {
+		if indented, err := json.MarshalIndent(maskJSON(value), "", "  "); err == nil {
+			content = indented
+		}
+	} else if !utf8.Valid(content) {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType == "" {
+			mediaType = "binary data"
+		}
+
+		return fmt.Sprintf("%d bytes of %s", len(content), mediaType)
+	}
+
+	limit := r.MaxBodySize
+	if limit <= 0 {
+		limit = defaultHTMLBodySize
+	}
+
+	return mask.text(excerpt(content, limit))
+}
+
+// masker hides the credentials of the auth of a request wherever they appear.
+type masker struct {
+	replacer *strings.Replacer
+}
+
+func newMasker(execution *types.RequestExecution) *masker {
+	var pairs []string
+
+	if req := execution.Request; req != nil && req.Auth != nil {
+		for _, secret := range []string{req.Auth.Password, req.Auth.Token, req.Auth.APIKey} {
+			if secret != "" {
+				pairs = append(pairs, secret, logging.Redacted)
+			}
+		}
+	}
+
+	return &masker{replacer: strings.NewReplacer(pairs...)}
+}
+
+func (m *masker) text(s string) string {
+	return m.replacer.Replace(s)
+}
+
+// headers returns headers sorted by name, with the values of sensitive headers masked.
+func (m *masker) headers(headers map[string][]string) []htmlHeader {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]htmlHeader, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		if logging.IsSensitive(name) {
+			value = logging.Redacted
+		}
+
+		result = append(result, htmlHeader{Name: name, Value: m.text(value)})
+	}
+
+	return result
+}
+
+// maskJSON masks the values of sensitive fields of a decoded JSON value.
+func maskJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if logging.IsSensitive(key) {
+				v[key] = logging.Redacted
+			} else {
+				v[key] = maskJSON(field)
+			}
+		}
+	case []any:
+		for i, element := range v {
+			v[i] = maskJSON(element)
+		}
+	}
+
+	return value
+}
+
+func authSummary(auth *types.Auth) string {
+	switch strings.ToLower(auth.Type) {
+	case "basic":
+		return fmt.Sprintf("basic, user %s, password %s", auth.Username, logging.Redacted)
+	case "apikey":
+		return fmt.Sprintf("API key %s in %s %s", logging.Redacted, auth.Location, auth.KeyName)
+	default:
+		return auth.Type + " " + logging.Redacted
+	}
+}
+
+// latencyChart draws a horizontal bar per test, scaled to the slowest one.
+func latencyChart(cases []testCase) htmlChart {
+	const (
+		barHeight = 22
+		maxWidth  = 600
+	)
+
+	chart := htmlChart{Width: maxWidth, Height: len(cases) * barHeight}
+
+	var slowest time.Duration
+	for _, tc := range cases {
+		slowest = max(slowest, tc.duration)
+	}
+
+	for i, tc := range cases {
+		width := 0
+		if slowest > 0 {
+			width = int(float64(maxWidth) * float64(tc.duration) / float64(slowest))
+		}
+
+		chart.Bars = append(chart.Bars, htmlBar{
+			Y:        i * barHeight,
+			Width:    max(width, 1),
+			Label:    fmt.Sprintf("%d. %s", i+1, tc.name),
+			Duration: tc.duration,
+			Outcome:  string(tc.outcome),
+		})
+	}
+
+	return chart
+}
+
+func singleValues(headers map[string]string) map[string][]string {
+	result := make(map[string][]string, len(headers))
+	for name, value := range headers {
+		result[name] = []string{value}
+	}
+
+	return result
+}
+
+func firstValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+// formatDuration rounds a duration for display.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// JSONSchemaVersion is the version of the JSON report schema. It changes only when
+// fields are removed or change meaning; new fields may be added within a version.
+const JSONSchemaVersion = 1
+
+// JSONReporter writes results as a JSON document of the JSONReport schema. Unlike
+// ExecutionResult, the schema is kept stable for tools that read it.
+type JSONReporter struct {
+	// ExcerptSize is the number of response body bytes quoted for a failed test,
+	// DefaultExcerptSize when zero.
+	ExcerptSize int
+}
+
+// JSONReport is the document written by JSONReporter. Durations are in milliseconds.
+type JSONReport struct {
+	Version     int         `json:"version"`
+	Summary     JSONSummary `json:"summary"`
+	Collections []JSONSuite `json:"collections"`
+}
+
+// JSONSummary counts the tests of a report or collection.
+type JSONSummary struct {
+	Tests      int     `json:"tests"`
+	Passed     int     `json:"passed"`
+	Failed     int     `json:"failed"`
+	Errors     int     `json:"errors"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// JSONSuite is a collection run of a JSONReport.
+type JSONSuite struct {
+	Name        string      `json:"name"`
+	Environment string      `json:"environment,omitempty"`
+	StartTime   time.Time   `json:"start_time"`
+	EndTime     time.Time   `json:"end_time"`
+	Summary     JSONSummary `json:"summary"`
+	Tests       []JSONTest  `json:"tests"`
+}
+
+// JSONTest is a request of a JSONSuite. Status is "passed", "failed" (executed but
+// unsuccessful, such as an error status or a snapshot mismatch) or "error" (not
+// executed). StatusCode is the HTTP status of the response, GRPCCode the status code
+// of a gRPC call. Message tells why a test did not pass, Excerpt quotes the start of
+// its response body.
+type JSONTest struct {
+	Name       string  `json:"name"`
+	Method     string  `json:"method,omitempty"`
+	URL        string  `json:"url,omitempty"`
+	Status     string  `json:"status"`
+	StatusCode int     `json:"status_code,omitempty"`
+	GRPCCode   *int    `json:"grpc_code,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+	Message    string  `json:"message,omitempty"`
+	Excerpt    string  `json:"excerpt,omitempty"`
+	Snapshot   string  `json:"snapshot,omitempty"`
+}
+
+// Format returns FormatJSON.
+func (r *JSONReporter) Format() string {
+	return FormatJSON
+}
+
+// Report writes the JSON report of results to w.
+func (r *JSONReporter) Report(w io.Writer, results ...*types.ExecutionResult) error {
+	report := JSONReport{Version: JSONSchemaVersion, Collections: []JSONSuite{}}
+
+	for _, result := range results {
+		suite := r.suite(result)
+		report.Collections = append(report.Collections, suite)
+		report.Summary.Tests += suite.Summary.Tests
+		report.Summary.Passed += suite.Summary.Passed
+		report.Summary.Failed += suite.Summary.Failed
+		report.Summary.Errors += suite.Summary.Errors
+		report.Summary.DurationMS += suite.Summary.DurationMS
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *JSONReporter) suite(result *types.ExecutionResult) JSONSuite {
+	cases := testCases(result, r.ExcerptSize)
+	counts := summarize(cases, result.TotalDuration)
+
+	suite := JSONSuite{
+		Name:        resultName(result),
+		Environment: result.Environment,
+		StartTime:   result.StartTime,
+		EndTime:     result.EndTime,
+		Summary: JSONSummary{
+			Tests:      counts.tests,
+			Passed:     counts.tests - counts.failures - counts.errors,
+			Failed:     counts.failures,
+			Errors:     counts.errors,
+			DurationMS: milliseconds(counts.duration),
+		},
+		Tests: make([]JSONTest, 0, len(cases)),
+	}
+
+	for _, tc := range cases {
+		test := JSONTest{
+			Name:       tc.name,
+			Method:     tc.method,
+			URL:        tc.url,
+			Status:     string(tc.outcome),
+			StatusCode: tc.statusCode,
+			GRPCCode:   tc.grpcCode,
+			DurationMS: milliseconds(tc.duration),
+			Message:    tc.message,
+			Excerpt:    tc.excerpt,
+		}
+
+		if tc.execution.Snapshot != nil {
+			test.Snapshot = tc.execution.Snapshot.Status
+		}
+
+		suite.Tests = append(suite.Tests, test)
+	}
+
+	return suite
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
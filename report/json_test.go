@@ -0,0 +1,111 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitJSONReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := (&JSONReporter{ExcerptSize: 5}).Report(&buf, testResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	if report.Version != JSONSchemaVersion {
+		t.Errorf("expected version %d, got %d", JSONSchemaVersion, report.Version)
+	}
+
+	expected := JSONSummary{Tests: 4, Passed: 1, Failed: 2, Errors: 1, DurationMS: 1000}
+	if report.Summary != expected {
+		t.Errorf("expected summary %+v, got %+v", expected, report.Summary)
+	}
+
+	if len(report.Collections) != 1 || report.Collections[0].Name != "api" || report.Collections[0].Environment != "dev" {
+		t.Fatalf("unexpected collections: %+v", report.Collections)
+	}
+
+	tests := report.Collections[0].Tests
+	if len(tests) != 4 {
+		t.Fatalf("expected 4 tests, got %d", len(tests))
+	}
+
+	if tests[0].Status != "passed" || tests[0].StatusCode != 200 || tests[0].DurationMS != 100 {
+		t.Errorf("unexpected passed test: %+v", tests[0])
+	}
+	if tests[1].Status != "failed" || tests[1].Excerpt != `{"err...` {
+		t.Errorf("unexpected failed test: %+v", tests[1])
+	}
+	if tests[2].Status != "error" || tests[2].Message == "" {
+		t.Errorf("unexpected errored test: %+v", tests[2])
+	}
+	if tests[3].Status != "failed" || tests[3].Snapshot != "mismatched" {
+		t.Errorf("unexpected snapshot test: %+v", tests[3])
+	}
+}
+
+func TestUnitJSONReporter_GRPC(t *testing.T) {
+	var buf bytes.Buffer
+	ok, notFound := 0, 5
+	result := &types.ExecutionResult{
+		CollectionName: "greeter",
+		Requests: []*types.RequestExecution{
+			{
+				Request:  &types.Request{URL: "grpc://localhost:50051/greeter.Greeter/SayHello"},
+				Response: &types.Response{GRPCCode: &ok, Status: "OK"},
+			},
+			{
+				Request:  &types.Request{URL: "grpc://localhost:50051/greeter.Greeter/Fail"},
+				Response: &types.Response{GRPCCode: &notFound, Status: "NotFound: no such greeting"},
+			},
+		},
+	}
+
+	if err := (&JSONReporter{}).Report(&buf, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"grpc_code": 0`) {
+		t.Errorf("expected the OK gRPC code in the report:\n%s", buf.String())
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	tests := report.Collections[0].Tests
+	if tests[0].Status != "passed" || tests[0].StatusCode != 0 || tests[0].GRPCCode == nil || *tests[0].GRPCCode != 0 {
+		t.Errorf("unexpected OK test: %+v", tests[0])
+	}
+	if tests[1].Status != "failed" || tests[1].GRPCCode == nil || *tests[1].GRPCCode != 5 ||
+		tests[1].Message != "unexpected status NotFound: no such greeting" {
+		t.Errorf("unexpected failed test: %+v", tests[1])
+	}
+}
+
+func TestUnitJSONReporter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := (&JSONReporter{}).Report(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	if collections, ok := report["collections"].([]any); !ok || len(collections) != 0 {
+		t.Errorf("expected an empty collections array, got %v", report["collections"])
+	}
+}
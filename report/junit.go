@@ -0,0 +1,153 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+// JUnitReporter writes results as JUnit XML, as read by GitLab and Jenkins: every
+// result is a testsuite named after its collection and every request a testcase
+// named after its item. Failed requests quote the start of their response body.
+type JUnitReporter struct {
+	// ExcerptSize is the number of response body bytes quoted for a failed test,
+	// DefaultExcerptSize when zero.
+	ExcerptSize int
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Format returns FormatJUnit.
+func (r *JUnitReporter) Format() string {
+	return FormatJUnit
+}
+
+// Report writes the JUnit XML report of results to w.
+func (r *JUnitReporter) Report(w io.Writer, results ...*types.ExecutionResult) error {
+	report := junitTestSuites{}
+
+	var total time.Duration
+
+	for _, result := range results {
+		suite := r.suite(result)
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		total += result.TotalDuration
+	}
+
+	report.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (r *JUnitReporter) suite(result *types.ExecutionResult) junitTestSuite {
+	name := resultName(result)
+	cases := testCases(result, r.ExcerptSize)
+	counts := summarize(cases, result.TotalDuration)
+
+	suite := junitTestSuite{
+		Name:     name,
+		Tests:    counts.tests,
+		Failures: counts.failures,
+		Errors:   counts.errors,
+		Time:     seconds(counts.duration),
+	}
+
+	if !result.StartTime.IsZero() {
+		suite.Timestamp = result.StartTime.Format("2006-01-02T15:04:05")
+	}
+
+	if result.Environment != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "environment", Value: result.Environment})
+	}
+
+	for _, tc := range cases {
+		testCase := junitTestCase{
+			Name:      tc.name,
+			ClassName: name,
+			Time:      seconds(tc.duration),
+		}
+
+		switch tc.outcome {
+		case failed:
+			testCase.Failure = &junitProblem{Message: tc.message, Type: "failure", Text: tc.excerpt}
+		case errored:
+			testCase.Error = &junitProblem{Message: tc.message, Type: "error", Text: tc.excerpt}
+		}
+
+		if tc.method != "" {
+			testCase.SystemOut = tc.method + " " + tc.url
+			if tc.statusCode != 0 {
+				testCase.SystemOut += fmt.Sprintf(" -> %d", tc.statusCode)
+			}
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	return suite
+}
+
+// seconds formats a duration in seconds, as JUnit times are.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
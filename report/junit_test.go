@@ -0,0 +1,75 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitJUnitReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := (&JUnitReporter{}).Report(&buf, testResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("expected an XML header, got %q", buf.String())
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse report: %v\n%s", err, buf.String())
+	}
+
+	if report.Tests != 4 || report.Failures != 2 || report.Errors != 1 || report.Time != "1.000" {
+		t.Errorf("unexpected totals: %+v", report)
+	}
+	if len(report.Suites) != 1 {
+		t.Fatalf("expected one testsuite, got %d", len(report.Suites))
+	}
+
+	suite := report.Suites[0]
+	if suite.Name != "api" || suite.Timestamp != "2025-01-02T03:04:05" || len(suite.Cases) != 4 {
+		t.Errorf("unexpected testsuite: %+v", suite)
+	}
+	if len(suite.Properties) != 1 || suite.Properties[0].Value != "dev" {
+		t.Errorf("expected the environment property, got %+v", suite.Properties)
+	}
+
+	passed, failed, errored := suite.Cases[0], suite.Cases[1], suite.Cases[2]
+
+	if passed.Name != "list users" || passed.ClassName != "api" || passed.Time != "0.100" || passed.Failure != nil || passed.Error != nil {
+		t.Errorf("unexpected passed testcase: %+v", passed)
+	}
+	if failed.Failure == nil || failed.Failure.Message != "unexpected status 500 Internal Server Error" || failed.Failure.Text != `{"error": "boom"}` {
+		t.Errorf("unexpected failed testcase: %+v", failed.Failure)
+	}
+	if errored.Error == nil || errored.Error.Message != "request failed: connection refused" {
+		t.Errorf("unexpected errored testcase: %+v", errored.Error)
+	}
+	if passed.SystemOut != "GET http://example.com/users?token=*** -> 200" {
+		t.Errorf("unexpected system-out %q", passed.SystemOut)
+	}
+}
+
+func TestUnitJUnitReporter_SeveralResults(t *testing.T) {
+	other := &types.ExecutionResult{CollectionName: "other"}
+
+	var buf bytes.Buffer
+	if err := (&JUnitReporter{}).Report(&buf, testResult(), other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	if len(report.Suites) != 2 || report.Suites[1].Name != "other" || report.Suites[1].Tests != 0 {
+		t.Errorf("unexpected testsuites: %+v", report.Suites)
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package report
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/logging"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Report formats.
+const (
+	FormatJUnit = "junit"
+	FormatJSON  = "json"
+	FormatTAP   = "tap"
+	FormatHTML  = "html"
+)
+
+// DefaultExcerptSize is the number of response body bytes quoted for a failed test
+// when a reporter does not set its own.
+const DefaultExcerptSize = 1024
+
+// Reporter writes execution results in a machine-readable format, such as for CI.
+// Every request execution is a test, passed when it counts as successful in the
+// statistics of its run. Several results, such as the runs of several collections,
+// can be written as one report.
+type Reporter interface {
+	// Format returns the name of the format, e.g. FormatJUnit.
+	Format() string
+	// Report writes the report of results to w.
+	Report(w io.Writer, results ...*types.ExecutionResult) error
+}
+
+// New returns a reporter of a format with default settings.
+func New(format string) (Reporter, error) {
+	switch strings.ToLower(format) {
+	case FormatJUnit:
+		return &JUnitReporter{}, nil
+	case FormatJSON:
+		return &JSONReporter{}, nil
+	case FormatTAP:
+		return &TAPReporter{}, nil
+	case FormatHTML:
+		return &HTMLReporter{}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown report format %q", errors.ErrInvalidArgument, format)
+	}
+}
+
+// Output is a report to write to a file.
+type Output struct {
+	Reporter Reporter
+	Path     string
+}
+
+// ParseOutput parses an output in the form "format:path", e.g. "junit:report.xml".
+func ParseOutput(spec string) (Output, error) {
+	format, path, ok := strings.Cut(spec, ":")
+	if !ok || path == "" {
+		return Output{}, fmt.Errorf("%w: report output %q is not in the form format:path", errors.ErrInvalidArgument, spec)
+	}
+
+	reporter, err := New(format)
+	if err != nil {
+		return Output{}, err
+	}
+
+	return Output{Reporter: reporter, Path: path}, nil
+}
+
+// WriteFiles writes the report of results to the file of every output. A failed
+// output does not keep the others from being written; the errors are joined.
+func WriteFiles(outputs []Output, results ...*types.ExecutionResult) error {
+	var errs []error
+
+	for _, output := range outputs {
+		var buf bytes.Buffer
+
+		if err := output.Reporter.Report(&buf, results...); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write %s report: %w", output.Reporter.Format(), err))
+			continue
+		}
+
+		if err := storage.WriteFileAtomic(output.Path, buf.Bytes(), 0644); err != nil {
+			errs = append(errs, fmt.Errorf("%w: failed to write %s report: %w", errors.ErrStorageError, output.Reporter.Format(), err))
+		}
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// outcome is the outcome of a test.
+type outcome string
+
+const (
+	passed outcome = "passed"
+	// failed marks a request that was executed but did not succeed, such as an error
+	// status or a snapshot mismatch.
+	failed outcome = "failed"
+	// errored marks a request that could not be executed.
+	errored outcome = "error"
+)
+
+// testCase is a request execution seen as a test.
+type testCase struct {
+	name       string
+	method     string
+	url        string
+	outcome    outcome
+	message    string
+	statusCode int
+	grpcCode   *int
+	duration   time.Duration
+	excerpt    string
+	execution  *types.RequestExecution
+}
+
+// testCases returns the tests of a result. Secrets in URLs and messages are redacted.
+func testCases(result *types.ExecutionResult, excerptSize int) []testCase {
+	cases := make([]testCase, 0, len(result.Requests))
+
+	for _, execution := range result.Requests {
+		if execution == nil {
+			continue
+		}
+
+		tc := testCase{
+			name:      testName(execution),
+			outcome:   passed,
+			duration:  execution.Duration,
+			execution: execution,
+		}
+
+		if execution.Request != nil {
+			tc.method = execution.Request.Method
+			tc.url = logging.RedactURL(execution.Request.URL)
+		}
+
+		if execution.Response != nil {
+			tc.statusCode = execution.Response.StatusCode
+			tc.grpcCode = execution.Response.GRPCCode
+		}
+
+		switch {
+		case execution.Error != "":
+			tc.outcome = errored
+			tc.message = logging.RedactString(execution.Error)
+		case execution.Response == nil:
+			tc.outcome = errored
+			tc.message = "no response"
+		default:
+			if message := failure(execution); message != "" {
+				tc.outcome = failed
+				tc.message = message
+			}
+		}
+
+		if tc.outcome != passed && execution.Response != nil {
+			tc.excerpt = excerpt(execution.Response.Body, excerptSize)
+		}
+
+		cases = append(cases, tc)
+	}
+
+	return cases
+}
+
+// failure returns why an executed request failed, or "" when it succeeded.
+func failure(execution *types.RequestExecution) string {
+	if !collections.IsSuccessful(execution.Request, execution.Response) {
+		status := execution.Response.Status
+		if status == "" {
+			status = fmt.Sprint(execution.Response.StatusCode)
+		}
+
+		return "unexpected status " + status
+	}
+
+	if snapshot := execution.Snapshot; snapshot != nil {
+		switch {
+		case snapshot.Error != "":
+			return "snapshot check failed: " + snapshot.Error
+		case snapshot.Status == types.SnapshotMismatched:
+			return fmt.Sprintf("response does not match snapshot %s: %d differences", snapshot.Path, len(snapshot.Mismatches))
+		}
+	}
+
+	return ""
+}
+
+// testName names a test after its collection item, or its request when the item
+// is not known.
+func testName(execution *types.RequestExecution) string {
+	if execution.Name != "" {
+		return execution.Name
+	}
+
+	req := execution.Request
+	if execution.Unresolved != nil {
+		req = execution.Unresolved
+	}
+
+	if req == nil {
+		return "request"
+	}
+
+	return req.Method + " " + logging.RedactURL(req.URL)
+}
+
+// excerpt returns the start of a response body as text.
+func excerpt(body []byte, size int) string {
+	if size <= 0 {
+		size = DefaultExcerptSize
+	}
+
+	truncated := len(body) > size
+	if truncated {
+		body = body[:size]
+		// Drop the start of a character cut in two.
+		for i := 0; i < utf8.UTFMax-1 && len(body) > 0; i++ {
+			if r, n := utf8.DecodeLastRune(body); r != utf8.RuneError || n > 1 {
+				break
+			}
+			body = body[:len(body)-1]
+		}
+	}
+
+	text := strings.ToValidUTF8(string(body), "�")
+	if truncated {
+		text += "..."
+	}
+
+	return text
+}
+
+// summary counts the outcomes of tests.
+type summary struct {
+	tests, failures, errors int
+	duration                time.Duration
+}
+
+func summarize(cases []testCase, duration time.Duration) summary {
+	s := summary{tests: len(cases), duration: duration}
+
+	for _, tc := range cases {
+		switch tc.outcome {
+		case failed:
+			s.failures++
+		case errored:
+			s.errors++
+		}
+	}
+
+	return s
+}
+
+// resultName names a result after its collection.
+func resultName(result *types.ExecutionResult) string {
+	if result.CollectionName != "" {
+		return result.CollectionName
+	}
+
+	return "requests"
+}
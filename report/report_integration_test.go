@@ -0,0 +1,108 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/collections"
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/environment"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationWriteFiles_CollectionRun(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	resolver, err := core.NewVariableResolver(environment.NewEnvironment("global"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	executor := collections.NewCollectionExecutor(core.NewHTTPClient(10*time.Second, 30*time.Second, false), resolver)
+	collection := &collections.Collection{
+		Name: "Test Collection",
+		Items: []*types.RequestItem{
+			{Name: "health", Request: &types.Request{Method: http.MethodGet, URL: http_server.GetServerURL() + "/health"}},
+			{Name: "not found", Request: &types.Request{Method: http.MethodGet, URL: http_server.GetServerURL() + "/status/404"}},
+		},
+	}
+
+	result, err := executor.ExecuteCollection(collection, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var outputs []Output
+	for _, spec := range []string{"junit:report.xml", "json:report.json", "tap:report.tap", "html:report.html"} {
+		output, err := ParseOutput(spec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		output.Path = filepath.Join(dir, output.Path)
+		outputs = append(outputs, output)
+	}
+
+	if err := WriteFiles(outputs, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "report.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var junit junitTestSuites
+	if err := xml.Unmarshal(data, &junit); err != nil {
+		t.Fatalf("failed to parse JUnit report: %v", err)
+	}
+	if junit.Tests != 2 || junit.Failures != 1 || junit.Suites[0].Cases[1].Failure.Text != "Status: 404" {
+		t.Errorf("unexpected JUnit report:\n%s", data)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "report.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to parse JSON report: %v", err)
+	}
+	if report.Summary.Passed != result.Statistics.Success || report.Summary.Failed != result.Statistics.Failed {
+		t.Errorf("expected the report to agree with the statistics %+v, got %+v", result.Statistics, report.Summary)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "report.tap"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), "ok 1 - health\n") || !strings.Contains(string(data), "not ok 2 - not found\n") {
+		t.Errorf("unexpected TAP report:\n%s", data)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "report.html"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `<details class="test failed" open>`) || !strings.Contains(string(data), "Status: 404") {
+		t.Errorf("unexpected HTML report:\n%s", data)
+	}
+}
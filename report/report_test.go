@@ -0,0 +1,176 @@
+package report
+
+import (
+	stderrors "errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/testutil/fixture"
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// testResult returns a run with a passed, a failed, an errored and a snapshot
+// mismatched request.
+func testResult() *types.ExecutionResult {
+	failedResponse := fixture.CreateTestResponse(http.StatusInternalServerError, []byte(`{"error": "boom"}`))
+	failedResponse.Status = "500 Internal Server Error"
+
+	return &types.ExecutionResult{
+		CollectionName: "api",
+		Environment:    "dev",
+		StartTime:      time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		EndTime:        time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC),
+		TotalDuration:  time.Second,
+		Requests: []*types.RequestExecution{
+			{
+				Name:     "list users",
+				Request:  &types.Request{Method: http.MethodGet, URL: "http://example.com/users?token=abc"},
+				Response: fixture.CreateTestResponse(http.StatusOK, []byte(`[]`)),
+				Duration: 100 * time.Millisecond,
+			},
+			{
+				Name:     "create user",
+				Request:  &types.Request{Method: http.MethodPost, URL: "http://example.com/users"},
+				Response: failedResponse,
+				Duration: 200 * time.Millisecond,
+			},
+			{
+				Name:     "unreachable",
+				Request:  &types.Request{Method: http.MethodGet, URL: "http://127.0.0.1:1"},
+				Error:    "request failed: connection refused",
+				Duration: 0,
+			},
+			{
+				Name:     "get user",
+				Request:  &types.Request{Method: http.MethodGet, URL: "http://example.com/users/1"},
+				Response: fixture.CreateTestResponse(http.StatusOK, []byte(`{"id": 2}`)),
+				Duration: 50 * time.Millisecond,
+				Snapshot: &types.SnapshotResult{
+					Status:     types.SnapshotMismatched,
+					Path:       "api/get user.snap",
+					Mismatches: []types.SnapshotMismatch{{Path: "$.id", Kind: "changed", Expected: "1", Actual: "2"}},
+				},
+			},
+		},
+	}
+}
+
+func TestUnitNew(t *testing.T) {
+	for _, format := range []string{FormatJUnit, FormatJSON, "TAP", FormatHTML} {
+		reporter, err := New(format)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if reporter.Format() != strings.ToLower(format) {
+			t.Errorf("expected %s reporter, got %s", format, reporter.Format())
+		}
+	}
+
+	if _, err := New("pdf"); !stderrors.Is(err, errors.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestUnitParseOutput(t *testing.T) {
+	output, err := ParseOutput("junit:reports/junit.xml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Reporter.Format() != FormatJUnit || output.Path != "reports/junit.xml" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	for _, spec := range []string{"junit", "junit:", "xml:report.xml"} {
+		if _, err := ParseOutput(spec); !stderrors.Is(err, errors.ErrInvalidArgument) {
+			t.Errorf("%q: expected ErrInvalidArgument, got %v", spec, err)
+		}
+	}
+}
+
+func TestUnitWriteFiles(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	outputs := []Output{
+		{Reporter: &JUnitReporter{}, Path: filepath.Join(dir, "report.xml")},
+		{Reporter: &JSONReporter{}, Path: filepath.Join(dir, "missing", "report.json")},
+		{Reporter: &TAPReporter{}, Path: filepath.Join(dir, "report.tap")},
+	}
+
+	err = WriteFiles(outputs, testResult())
+	if !stderrors.Is(err, errors.ErrStorageError) || !strings.Contains(err.Error(), "json") {
+		t.Errorf("expected the json output to fail, got %v", err)
+	}
+
+	for _, name := range []string{"report.xml", "report.tap"} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || len(data) == 0 {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+}
+
+func TestUnitTestCases(t *testing.T) {
+	cases := testCases(testResult(), 0)
+	if len(cases) != 4 {
+		t.Fatalf("expected 4 cases, got %d", len(cases))
+	}
+
+	expected := []struct {
+		outcome outcome
+		message string
+	}{
+		{passed, ""},
+		{failed, "unexpected status 500 Internal Server Error"},
+		{errored, "request failed: connection refused"},
+		{failed, "response does not match snapshot api/get user.snap: 1 differences"},
+	}
+
+	for i, e := range expected {
+		if cases[i].outcome != e.outcome || cases[i].message != e.message {
+			t.Errorf("case %d: expected %s %q, got %s %q", i, e.outcome, e.message, cases[i].outcome, cases[i].message)
+		}
+	}
+
+	if cases[0].url != "http://example.com/users?token=***" {
+		t.Errorf("expected redacted URL, got %q", cases[0].url)
+	}
+	if cases[0].excerpt != "" || cases[1].excerpt != `{"error": "boom"}` {
+		t.Errorf("expected an excerpt for failed tests only, got %q and %q", cases[0].excerpt, cases[1].excerpt)
+	}
+
+	unnamed := &types.ExecutionResult{Requests: []*types.RequestExecution{{
+		Request:    &types.Request{Method: http.MethodGet, URL: "http://example.com/users/1"},
+		Unresolved: &types.Request{Method: http.MethodGet, URL: "{{base}}/users/{{id}}"},
+		Response:   fixture.CreateTestResponse(http.StatusOK, nil),
+	}}}
+	if cases := testCases(unnamed, 0); cases[0].name != "GET {{base}}/users/{{id}}" {
+		t.Errorf("expected the unresolved request as name, got %q", cases[0].name)
+	}
+}
+
+func TestUnitExcerpt(t *testing.T) {
+	if got := excerpt([]byte("short"), 10); got != "short" {
+		t.Errorf("unexpected excerpt %q", got)
+	}
+
+	if got := excerpt([]byte("abcdefgh"), 4); got != "abcd..." {
+		t.Errorf("unexpected excerpt %q", got)
+	}
+
+	if got := excerpt([]byte("abécd"), 3); got != "ab..." {
+		t.Errorf("expected a cut character to be dropped, got %q", got)
+	}
+
+	if got := excerpt([]byte{0xff, 'a'}, 10); got != "�a" {
+		t.Errorf("expected invalid bytes to be replaced, got %q", got)
+	}
+}
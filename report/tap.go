@@ -0,0 +1,104 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/KonnorFrik/getman/types"
+	"gopkg.in/yaml.v3"
+)
+
+// TAPReporter writes results in the Test Anything Protocol, version 13. Every
+// request is a test point named after its item; the tests of each collection
+// follow a comment with its name. Failed tests carry a YAML diagnostic block
+// quoting the start of their response body.
+type TAPReporter struct {
+	// ExcerptSize is the number of response body bytes quoted for a failed test,
+	// DefaultExcerptSize when zero.
+	ExcerptSize int
+}
+
+// tapDiagnostic is the YAML diagnostic block of a failed test.
+type tapDiagnostic struct {
+	Message    string  `yaml:"message"`
+	Severity   string  `yaml:"severity"`
+	Method     string  `yaml:"method,omitempty"`
+	URL        string  `yaml:"url,omitempty"`
+	StatusCode int     `yaml:"status_code,omitempty"`
+	GRPCCode   *int    `yaml:"grpc_code,omitempty"`
+	DurationMS float64 `yaml:"duration_ms"`
+	Excerpt    string  `yaml:"excerpt,omitempty"`
+}
+
+// Format returns FormatTAP.
+func (r *TAPReporter) Format() string {
+	return FormatTAP
+}
+
+// Report writes the TAP report of results to w.
+func (r *TAPReporter) Report(w io.Writer, results ...*types.ExecutionResult) error {
+	suites := make([][]testCase, 0, len(results))
+	total := 0
+
+	for _, result := range results {
+		cases := testCases(result, r.ExcerptSize)
+		suites = append(suites, cases)
+		total += len(cases)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "TAP version 13")
+	fmt.Fprintf(bw, "1..%d\n", total)
+
+	number := 0
+
+	for i, cases := range suites {
+		fmt.Fprintf(bw, "# %s\n", tapEscape(resultName(results[i])))
+
+		for _, tc := range cases {
+			number++
+
+			if tc.outcome == passed {
+				fmt.Fprintf(bw, "ok %d - %s\n", number, tapEscape(tc.name))
+				continue
+			}
+
+			fmt.Fprintf(bw, "not ok %d - %s\n", number, tapEscape(tc.name))
+
+			diagnostic, err := yaml.Marshal(tapDiagnostic{
+				Message:    tc.message,
+				Severity:   string(tc.outcome),
+				Method:     tc.method,
+				URL:        tc.url,
+				StatusCode: tc.statusCode,
+				GRPCCode:   tc.grpcCode,
+				DurationMS: milliseconds(tc.duration),
+				Excerpt:    tc.excerpt,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode TAP diagnostic: %w", err)
+			}
+
+			fmt.Fprintln(bw, "  ---")
+			for _, line := range strings.Split(strings.TrimSuffix(string(diagnostic), "\n"), "\n") {
+				fmt.Fprintf(bw, "  %s\n", line)
+			}
+			fmt.Fprintln(bw, "  ...")
+		}
+	}
+
+	return bw.Flush()
+}
+
+// tapEscape keeps a description on one line and escapes the characters that TAP
+// gives a meaning to.
+func tapEscape(s string) string {
+	s = strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "#", `\#`)
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestUnitTAPReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+
+	other := &types.ExecutionResult{
+		CollectionName: "other #2",
+		Requests: []*types.RequestExecution{
+			{Name: "broken", Request: &types.Request{Method: "GET", URL: "http://example.com"}, Error: "timeout"},
+		},
+	}
+
+	if err := (&TAPReporter{}).Report(&buf, testResult(), other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	expectedLines := []string{
+		"TAP version 13",
+		"1..5",
+		"# api",
+		"ok 1 - list users",
+		"not ok 2 - create user",
+		"  ---",
+		"  message: unexpected status 500 Internal Server Error",
+		"  severity: failed",
+		"  status_code: 500",
+		"  ...",
+		"not ok 3 - unreachable",
+		"  severity: error",
+		"not ok 4 - get user",
+		`# other \#2`,
+		"not ok 5 - broken",
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("expected line %q in:\n%s", line, output)
+		}
+	}
+
+	if strings.Index(output, "ok 1") > strings.Index(output, "not ok 2") {
+		t.Error("expected tests in order")
+	}
+}
+
+func TestUnitTapEscape(t *testing.T) {
+	if got := tapEscape("a # b\nc \\ d"); got != `a \# b c \\ d` {
+		t.Errorf("unexpected escaped description %q", got)
+	}
+}
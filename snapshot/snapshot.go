@@ -0,0 +1,249 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/KonnorFrik/getman/diff"
+	"github.com/KonnorFrik/getman/errors"
+	"github.com/KonnorFrik/getman/storage"
+	"github.com/KonnorFrik/getman/types"
+)
+
+// Extension is the file extension of snapshots.
+const Extension = ".snap"
+
+// Normalizer rewrites the volatile parts of a response body, such as timestamps and
+// generated IDs, before it is compared with or stored as a snapshot.
+type Normalizer func(body []byte) []byte
+
+// RegexpNormalizer returns a Normalizer replacing the matches of pattern with replacement.
+func RegexpNormalizer(pattern *regexp.Regexp, replacement string) Normalizer {
+	return func(body []byte) []byte {
+		return pattern.ReplaceAll(body, []byte(replacement))
+	}
+}
+
+var (
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+	uuidPattern      = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+)
+
+// NormalizeTimestamps replaces RFC 3339 and similar date-times with "<timestamp>".
+var NormalizeTimestamps = RegexpNormalizer(timestampPattern, "<timestamp>")
+
+// NormalizeUUIDs replaces UUIDs with "<uuid>".
+var NormalizeUUIDs = RegexpNormalizer(uuidPattern, "<uuid>")
+
+// Options controls how response bodies are compared with their snapshots.
+type Options struct {
+	// IgnorePaths lists JSON paths that are not compared, in the form of diff.Options.
+	IgnorePaths []string
+	// Normalizers are applied in order to every body before it is compared or stored.
+	Normalizers []Normalizer
+	// Update replaces the stored snapshots with the current bodies instead of comparing.
+	Update bool
+}
+
+// Store keeps snapshots as files, in a directory per collection.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a Store keeping snapshots under dir, usually FileStorage.SnapshotsDir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Path returns the path of the snapshot of a collection item. The folder of the item,
+// if any, becomes a subdirectory. Items sharing a name within a folder share this path;
+// Paths gives them distinct ones.
+func (s *Store) Path(collectionName string, item *types.RequestItem) string {
+	return s.Paths(collectionName, []*types.RequestItem{item})[0]
+}
+
+// Paths returns the paths of the snapshots of the items of a collection, in order.
+// Items with the same name in the same folder get a -N suffix after the first one,
+// as their request files in a collection directory do.
+func (s *Store) Paths(collectionName string, items []*types.RequestItem) []string {
+	dir := filepath.Join(s.dir, fileName(collectionName))
+	used := make(map[string]bool)
+	paths := make([]string, 0, len(items))
+
+	for _, item := range items {
+		var segments []string
+		for _, folder := range strings.Split(item.Folder, "/") {
+			if folder = storage.FileName(folder); folder != "" {
+				segments = append(segments, folder)
+			}
+		}
+
+		relPath := storage.UniquePath(strings.Join(segments, "/"), fileName(item.Name), Extension, used)
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(relPath)))
+	}
+
+	return paths
+}
+
+// Load returns the stored snapshot of a collection item. A missing snapshot gives
+// an error wrapping fs.ErrNotExist.
+func (s *Store) Load(collectionName string, item *types.RequestItem) ([]byte, error) {
+	return load(s.Path(collectionName, item))
+}
+
+// Save stores the snapshot of a collection item.
+func (s *Store) Save(collectionName string, item *types.RequestItem, data []byte) error {
+	return save(s.Path(collectionName, item), data)
+}
+
+func load(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return data, nil
+}
+
+func save(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("%w: failed to create snapshot directory: %w", errors.ErrStorageError, err)
+	}
+
+	if err := storage.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("%w: failed to write snapshot: %w", errors.ErrStorageError, err)
+	}
+
+	return nil
+}
+
+// Delete removes the snapshots of a collection.
+func (s *Store) Delete(collectionName string) error {
+	return os.RemoveAll(filepath.Join(s.dir, fileName(collectionName)))
+}
+
+// Check compares a response body with the snapshot of a collection item. A missing
+// snapshot is recorded, as is every body in update mode, so only the complete bodies
+// of successful responses should be checked. JSON bodies are compared structurally
+// and stored indented, other bodies are compared as they are.
+func (s *Store) Check(collectionName string, item *types.RequestItem, body []byte, opts *Options) *types.SnapshotResult {
+	return s.CheckPath(s.Path(collectionName, item), body, opts)
+}
+
+// CheckPath compares a response body with the snapshot at path, one of Paths, as
+// Check does.
+func (s *Store) CheckPath(path string, body []byte, opts *Options) *types.SnapshotResult {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	result := &types.SnapshotResult{Path: path}
+	actual := normalize(body, opts.Normalizers)
+
+	expected, err := load(path)
+	missing := stderrors.Is(err, fs.ErrNotExist)
+
+	if opts.Update || missing {
+		result.Status = types.SnapshotUpdated
+		if missing {
+			result.Status = types.SnapshotCreated
+		}
+
+		if err := save(path, actual); err != nil {
+			return &types.SnapshotResult{Path: result.Path, Error: err.Error()}
+		}
+
+		return result
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Mismatches = Compare(expected, actual, opts)
+	result.Status = types.SnapshotMatched
+
+	if len(result.Mismatches) > 0 {
+		result.Status = types.SnapshotMismatched
+	}
+
+	return result
+}
+
+// Compare returns the differences between a stored snapshot and a normalized body.
+func Compare(expected, actual []byte, opts *Options) []types.SnapshotMismatch {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	changes, err := diff.JSON(expected, actual, &diff.Options{IgnorePaths: opts.IgnorePaths})
+
+	if err != nil {
+		if bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(actual)) {
+			return nil
+		}
+
+		return []types.SnapshotMismatch{{Path: "$", Kind: string(diff.Changed), Expected: string(expected), Actual: string(actual)}}
+	}
+
+	mismatches := make([]types.SnapshotMismatch, 0, len(changes))
+	for _, change := range changes {
+		mismatches = append(mismatches, types.SnapshotMismatch{
+			Path:     change.Path,
+			Kind:     string(change.Kind),
+			Expected: encodeValue(change.Before, change.Kind == diff.Added),
+			Actual:   encodeValue(change.After, change.Kind == diff.Removed),
+		})
+	}
+
+	return mismatches
+}
+
+// normalize applies the normalizers to a body and indents JSON bodies, so that
+// snapshots are readable and diff well under version control.
+func normalize(body []byte, normalizers []Normalizer) []byte {
+	for _, normalizer := range normalizers {
+		body = normalizer(body)
+	}
+
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		indented.WriteByte('\n')
+		return indented.Bytes()
+	}
+
+	return body
+}
+
+func encodeValue(value any, missing bool) string {
+	if missing {
+		return ""
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// fileName turns a name into a safe file name.
+func fileName(name string) string {
+	if name = storage.FileName(name); name == "" {
+		return "_"
+	}
+
+	return name
+}
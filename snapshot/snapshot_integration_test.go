@@ -0,0 +1,51 @@
+package snapshot
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KonnorFrik/getman/core"
+	"github.com/KonnorFrik/getman/testutil/http_server"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func TestIntegrationStore_CheckEcho(t *testing.T) {
+	_, err := http_server.StartTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer http_server.StopTestServer()
+
+	httpClient := core.NewHTTPClient(10*time.Second, 30*time.Second, false)
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "echo"}
+
+	execute := func(path string) []byte {
+		t.Helper()
+
+		resp, err := httpClient.Execute(&types.Request{Method: http.MethodGet, URL: http_server.GetServerURL() + path})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return resp.Body
+	}
+
+	if result := store.Check("api", item, execute("/echo?page=1"), nil); result.Status != types.SnapshotCreated {
+		t.Fatalf("expected created snapshot, got %+v", result)
+	}
+
+	if result := store.Check("api", item, execute("/echo?page=1"), nil); result.Status != types.SnapshotMatched {
+		t.Errorf("expected matched snapshot, got %+v", result)
+	}
+
+	result := store.Check("api", item, execute("/echo?page=2"), nil)
+	if result.Status != types.SnapshotMismatched || len(result.Mismatches) != 1 || result.Mismatches[0].Path != "$.query.page[0]" {
+		t.Errorf("expected the page query to mismatch, got %+v", result)
+	}
+
+	if result := store.Check("api", item, execute("/echo?page=2"), &Options{IgnorePaths: []string{"$.query"}}); result.Status != types.SnapshotMatched {
+		t.Errorf("expected matched snapshot with ignored query, got %+v", result)
+	}
+}
@@ -0,0 +1,222 @@
+package snapshot
+
+import (
+	stderrors "errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { helper.CleanupTempDir(dir) })
+
+	return NewStore(dir)
+}
+
+func TestUnitStore_Path(t *testing.T) {
+	store := NewStore("/snapshots")
+
+	path := store.Path("My API", &types.RequestItem{Name: "Get user"})
+	if path != filepath.Join("/snapshots", "My API", "Get user.snap") {
+		t.Errorf("unexpected path %q", path)
+	}
+
+	path = store.Path("api", &types.RequestItem{Name: "a/b:c", Folder: "users/admin"})
+	if path != filepath.Join("/snapshots", "api", "users", "admin", "a_b_c.snap") {
+		t.Errorf("unexpected path %q", path)
+	}
+
+	path = store.Path("..", &types.RequestItem{Name: ".."})
+	if path != filepath.Join("/snapshots", "_", "_.snap") {
+		t.Errorf("expected names to stay inside the store, got %q", path)
+	}
+}
+
+func TestUnitStore_Paths(t *testing.T) {
+	store := NewStore("/snapshots")
+
+	paths := store.Paths("api", []*types.RequestItem{
+		{Name: "Get", Folder: "users"},
+		{Name: "Get", Folder: "users"},
+		{Name: "get", Folder: "users"},
+		{Name: "Get"},
+	})
+
+	expected := []string{
+		filepath.Join("/snapshots", "api", "users", "Get.snap"),
+		filepath.Join("/snapshots", "api", "users", "Get-2.snap"),
+		filepath.Join("/snapshots", "api", "users", "get-3.snap"),
+		filepath.Join("/snapshots", "api", "Get.snap"),
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected path %q, got %q", expected[i], paths[i])
+		}
+	}
+}
+
+func TestUnitStore_CheckCreatesAndMatches(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item"}
+
+	result := store.Check("api", item, []byte(`{"id":1,"name":"a"}`), nil)
+	if result.Status != types.SnapshotCreated || result.Error != "" {
+		t.Fatalf("expected created snapshot, got %+v", result)
+	}
+
+	data, err := store.Load("api", item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "{\n  \"id\": 1,\n  \"name\": \"a\"\n}\n" {
+		t.Errorf("expected indented snapshot, got %q", data)
+	}
+
+	result = store.Check("api", item, []byte(`{"name": "a", "id": 1}`), nil)
+	if result.Status != types.SnapshotMatched || len(result.Mismatches) != 0 {
+		t.Errorf("expected matched snapshot, got %+v", result)
+	}
+}
+
+func TestUnitStore_CheckMismatch(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item"}
+
+	store.Check("api", item, []byte(`{"id":1,"tags":["a"]}`), nil)
+
+	result := store.Check("api", item, []byte(`{"id":2,"tags":["a","b"]}`), nil)
+	if result.Status != types.SnapshotMismatched {
+		t.Fatalf("expected mismatched snapshot, got %+v", result)
+	}
+
+	expected := []types.SnapshotMismatch{
+		{Path: "$.id", Kind: "changed", Expected: "1", Actual: "2"},
+		{Path: "$.tags[1]", Kind: "added", Expected: "", Actual: `"b"`},
+	}
+	if len(result.Mismatches) != len(expected) {
+		t.Fatalf("expected %d mismatches, got %+v", len(expected), result.Mismatches)
+	}
+	for i, mismatch := range expected {
+		if result.Mismatches[i] != mismatch {
+			t.Errorf("mismatch %d: expected %+v, got %+v", i, mismatch, result.Mismatches[i])
+		}
+	}
+
+	data, _ := store.Load("api", item)
+	if !strings.Contains(string(data), `"id": 1`) {
+		t.Errorf("expected snapshot to be kept on mismatch, got %s", data)
+	}
+}
+
+func TestUnitStore_CheckUpdate(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item"}
+
+	store.Check("api", item, []byte(`{"id":1}`), nil)
+
+	result := store.Check("api", item, []byte(`{"id":2}`), &Options{Update: true})
+	if result.Status != types.SnapshotUpdated {
+		t.Fatalf("expected updated snapshot, got %+v", result)
+	}
+
+	result = store.Check("api", item, []byte(`{"id":2}`), nil)
+	if result.Status != types.SnapshotMatched {
+		t.Errorf("expected updated snapshot to match, got %+v", result)
+	}
+}
+
+func TestUnitStore_CheckIgnorePaths(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item"}
+	opts := &Options{IgnorePaths: []string{"$.items[*].id", "$.meta"}}
+
+	store.Check("api", item, []byte(`{"items":[{"id":1,"v":"a"}],"meta":{"page":1}}`), opts)
+
+	result := store.Check("api", item, []byte(`{"items":[{"id":7,"v":"a"}],"meta":{"page":3}}`), opts)
+	if result.Status != types.SnapshotMatched {
+		t.Errorf("expected ignored paths to match, got %+v", result)
+	}
+
+	result = store.Check("api", item, []byte(`{"items":[{"id":7,"v":"b"}]}`), opts)
+	if result.Status != types.SnapshotMismatched || len(result.Mismatches) != 1 || result.Mismatches[0].Path != "$.items[0].v" {
+		t.Errorf("expected a single mismatch of $.items[0].v, got %+v", result)
+	}
+}
+
+func TestUnitStore_CheckNormalizers(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item"}
+	opts := &Options{Normalizers: []Normalizer{NormalizeTimestamps, NormalizeUUIDs}}
+
+	store.Check("api", item, []byte(`{"id":"6f1c2d3e-4a5b-4c6d-8e7f-901234567890","at":"2025-01-02T03:04:05.123Z"}`), opts)
+
+	data, _ := store.Load("api", item)
+	if !strings.Contains(string(data), `"<uuid>"`) || !strings.Contains(string(data), `"<timestamp>"`) {
+		t.Errorf("expected normalized snapshot, got %s", data)
+	}
+
+	result := store.Check("api", item, []byte(`{"id":"00000000-1111-2222-3333-444444444444","at":"2026-10-19 12:00:00+02:00"}`), opts)
+	if result.Status != types.SnapshotMatched {
+		t.Errorf("expected normalized bodies to match, got %+v", result)
+	}
+}
+
+func TestUnitRegexpNormalizer(t *testing.T) {
+	normalize := RegexpNormalizer(regexp.MustCompile(`token=\w+`), "token=<token>")
+
+	if got := string(normalize([]byte("a token=abc123 b"))); got != "a token=<token> b" {
+		t.Errorf("unexpected normalized body %q", got)
+	}
+}
+
+func TestUnitStore_CheckText(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item"}
+
+	store.Check("api", item, []byte("hello\n"), nil)
+
+	if result := store.Check("api", item, []byte("hello"), nil); result.Status != types.SnapshotMatched {
+		t.Errorf("expected text bodies to match, got %+v", result)
+	}
+
+	result := store.Check("api", item, []byte("bye"), nil)
+	if result.Status != types.SnapshotMismatched || len(result.Mismatches) != 1 || result.Mismatches[0].Path != "$" {
+		t.Errorf("expected a whole-body mismatch, got %+v", result)
+	}
+}
+
+func TestUnitStore_LoadMissing(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.Load("api", &types.RequestItem{Name: "missing"})
+	if !stderrors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestUnitStore_Delete(t *testing.T) {
+	store := newTestStore(t)
+	item := &types.RequestItem{Name: "item", Folder: "users"}
+
+	store.Check("api", item, []byte(`{}`), nil)
+
+	if err := store.Delete("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(store.Path("api", item)); !os.IsNotExist(err) {
+		t.Errorf("expected snapshot to be deleted, got %v", err)
+	}
+}
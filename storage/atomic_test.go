@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+)
+
+func TestUnitWriteFileAtomic(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	filePath := filepath.Join(dir, "test.json")
+	if err := WriteFileAtomic(filePath, []byte("first"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := WriteFileAtomic(filePath, []byte("second"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("expected second, got %q", data)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary files left, got %d entries", len(entries))
+	}
+}
+
+func TestUnitWriteFileAtomic_MissingDir(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "test.json"), []byte("data"), 0644); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -62,6 +64,7 @@ func (fs *FileStorage) ensureDirectories() error {
 		fs.EnvironmentsDir(),
 		fs.HistoryDir(),
 		fs.LogsDir(),
+		fs.SnapshotsDir(),
 	}
 
 	for _, dir := range dirs {
@@ -102,6 +105,13 @@ func (fs *FileStorage) LogsDir() string {
 	return filepath.Join(fs.basePath, dirName)
 }
 
+// SnapshotsDir returns the path to the directory of response snapshots, which holds
+// a directory per collection.
+func (fs *FileStorage) SnapshotsDir() string {
+	const dirName = "snapshots"
+	return filepath.Join(fs.basePath, dirName)
+}
+
 // ConfigPath returns the path to the configuration file.
 func (fs *FileStorage) ConfigPath() string {
 	const fileName = "config.yaml"
@@ -119,3 +129,108 @@ func FormatTimestamp(t time.Time) string {
 func ParseTimestamp(s string) (time.Time, error) {
 	return time.Parse(timeFormat, s)
 }
+
+// EntryID returns the ID of a history or log entry saved at t: its timestamp, with a
+// numeric suffix for the attempt'th entry saved within the same 100µs.
+func EntryID(t time.Time, attempt int) string {
+	if attempt == 0 {
+		return FormatTimestamp(t)
+	}
+
+	return fmt.Sprintf("%s-%d", FormatTimestamp(t), attempt)
+}
+
+// ParseEntryID returns the time and the attempt number of a history or log entry ID.
+func ParseEntryID(id string) (time.Time, int, error) {
+	timestamp, suffix, found := strings.Cut(id, "-")
+	t, err := ParseTimestamp(timestamp)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	if !found {
+		return t, 0, nil
+	}
+
+	attempt, err := strconv.Atoi(suffix)
+	if err != nil || attempt <= 0 {
+		return time.Time{}, 0, fmt.Errorf("invalid entry ID %q", id)
+	}
+
+	return t, attempt, nil
+}
+
+// EntryTime returns the time a history or log entry was saved at, from its ID.
+// IDs hold the local wall clock time with a resolution of 100µs.
+func EntryTime(id string) (time.Time, error) {
+	t, _, err := ParseEntryID(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local), nil
+}
+
+// reserveEntry creates an empty file for a new entry in dir, taking the first
+// free ID, so that entries saved at the same time never overwrite each other.
+func reserveEntry(dir string, t time.Time) (string, error) {
+	for attempt := 0; ; attempt++ {
+		filePath := filepath.Join(dir, EntryID(t, attempt)+".json")
+		file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+
+		if os.IsExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		return filePath, file.Close()
+	}
+}
+
+// listEntries returns the IDs of the entry files in dir, most recent first.
+func listEntries(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		id      string
+		time    time.Time
+		attempt int
+	}
+
+	var found []entry
+
+	for _, dirEntry := range entries {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		id, ok := strings.CutSuffix(dirEntry.Name(), ".json")
+		if !ok {
+			continue
+		}
+
+		if t, attempt, err := ParseEntryID(id); err == nil {
+			found = append(found, entry{id: id, time: t, attempt: attempt})
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if !found[i].time.Equal(found[j].time) {
+			return found[i].time.After(found[j].time)
+		}
+		return found[i].attempt > found[j].attempt
+	})
+
+	ids := make([]string, len(found))
+	for i, e := range found {
+		ids[i] = e.id
+	}
+
+	return ids, nil
+}
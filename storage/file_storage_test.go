@@ -171,6 +171,28 @@ func TestUnitLogsDir(t *testing.T) {
 	}
 }
 
+func TestUnitSnapshotsDir(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	fs, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedDir := filepath.Join(dir, "snapshots")
+	if fs.SnapshotsDir() != expectedDir {
+		t.Errorf("expected snapshots dir %s, got %s", expectedDir, fs.SnapshotsDir())
+	}
+
+	if info, err := os.Stat(expectedDir); err != nil || !info.IsDir() {
+		t.Errorf("expected snapshots dir to be created: %v", err)
+	}
+}
+
 func TestUnitConfigPath(t *testing.T) {
 	dir, err := helper.CreateTempDir()
 	if err != nil {
@@ -263,3 +285,42 @@ func TestUnitExpandPath_NoTilde(t *testing.T) {
 // 		t.Errorf("expected parsed timestamp to match original (within second), got %v vs %v", parsed, origTrunc)
 // 	}
 // }
+
+func TestUnitEntryID(t *testing.T) {
+	now := time.Date(2025, 3, 4, 10, 20, 30, 123400000, time.UTC)
+
+	if id := EntryID(now, 0); id != FormatTimestamp(now) {
+		t.Errorf("expected plain timestamp, got %s", id)
+	}
+
+	id := EntryID(now, 2)
+	parsed, attempt, err := ParseEntryID(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parsed.Equal(now) || attempt != 2 {
+		t.Errorf("expected %v and 2, got %v and %d", now, parsed, attempt)
+	}
+
+	for _, invalid := range []string{"invalid", FormatTimestamp(now) + "-x", FormatTimestamp(now) + "-0"} {
+		if _, _, err := ParseEntryID(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestUnitEntryTime(t *testing.T) {
+	now := time.Date(2025, 3, 4, 10, 20, 30, 123400000, time.Local)
+
+	saved, err := EntryTime(EntryID(now, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !saved.Equal(now) {
+		t.Errorf("expected %v, got %v", now, saved)
+	}
+
+	if _, err := EntryTime("invalid"); err == nil {
+		t.Error("expected error for invalid ID")
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+*/
+package storage
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// FileName replaces the characters that are not allowed in file names on common file
+// systems. It returns "" when nothing usable is left, as for "." and "..".
+func FileName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." {
+		return ""
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	return strings.TrimRight(name, ". ")
+}
+
+// UniquePath returns the slash-separated path of the file name+ext in dir. A name whose
+// path is already in used gets a -N suffix; paths are compared case-insensitively, for
+// case-insensitive file systems. The returned path is added to used.
+func UniquePath(dir, name, ext string, used map[string]bool) string {
+	for i := 1; ; i++ {
+		fileName := name
+		if i > 1 {
+			fileName = fmt.Sprintf("%s-%d", name, i)
+		}
+
+		relPath := path.Join(dir, fileName+ext)
+		if key := strings.ToLower(relPath); !used[key] {
+			used[key] = true
+			return relPath
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package storage
+
+import "testing"
+
+func TestUnitFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Get user", "Get user"},
+		{" a/b:c ", "a_b_c"},
+		{"tab\there", "tab_here"},
+		{"trailing. ", "trailing"},
+		{"..", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := FileName(tt.name); got != tt.expected {
+			t.Errorf("FileName(%q): expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestUnitUniquePath(t *testing.T) {
+	used := make(map[string]bool)
+
+	tests := []struct {
+		dir, name, expected string
+	}{
+		{"dir", "a", "dir/a.json"},
+		{"dir", "A", "dir/A-2.json"},
+		{"dir", "a", "dir/a-3.json"},
+		{"", "a", "a.json"},
+	}
+
+	for _, tt := range tests {
+		if got := UniquePath(tt.dir, tt.name, ".json", used); got != tt.expected {
+			t.Errorf("UniquePath(%q, %q): expected %q, got %q", tt.dir, tt.name, tt.expected, got)
+		}
+	}
+}
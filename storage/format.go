@@ -0,0 +1,385 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+
+*/
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/KonnorFrik/getman/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the on-disk format of collection and environment files.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Extensions are the file extensions of collection and environment files, in the
+// order they are looked up.
+var Extensions = []string{".json", ".yaml", ".yml"}
+
+// Keys that hold textual bodies in place of the base64 encoded ones.
+const (
+	requestTextKey  = "text"
+	responseTextKey = "body_text"
+)
+
+var (
+	requestBodyType = reflect.TypeOf(types.RequestBody{})
+	responseType    = reflect.TypeOf(types.Response{})
+)
+
+// FormatForPath returns the format of a file by its extension.
+// Files without a .yaml or .yml extension are JSON.
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// Extension returns the file extension used for new files of a format.
+func Extension(format Format) string {
+	if format == FormatYAML {
+		return ".yaml"
+	}
+	return ".json"
+}
+
+// TrimExtension returns the name of a collection or environment file without its
+// extension. It reports false for files with an unsupported extension.
+func TrimExtension(fileName string) (string, bool) {
+	ext := filepath.Ext(fileName)
+	for _, supported := range Extensions {
+		if strings.EqualFold(ext, supported) && len(fileName) > len(ext) {
+			return fileName[:len(fileName)-len(ext)], true
+		}
+	}
+	return "", false
+}
+
+// FindFile returns the path of the collection or environment file with the given
+// name in dir, trying the supported extensions in order. When there is no such file,
+// the path of a new JSON file is returned.
+func FindFile(dir, name string) string {
+	for _, ext := range Extensions {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, name+Extensions[0])
+}
+
+// ListNames returns the names of the collection or environment files in dir.
+// A name stored with several extensions is listed once.
+func ListNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name, ok := TrimExtension(entry.Name())
+		if ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// Marshal encodes v in the given format, keeping the field order of its JSON
+// encoding. Textual request and response bodies are written as strings, so that the
+// files stay readable in diffs; binary bodies stay base64 encoded.
+func Marshal(v any, format Format) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+	root := doc.Content[0]
+	bodyNodes(root, reflect.TypeOf(v), replaceBody)
+
+	if format == FormatYAML {
+		plainStyle(root)
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(root); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, root); err != nil {
+		return nil, err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// Unmarshal decodes data in the given format into v by its JSON decoding. Bodies may
+// be stored either as text or base64 encoded.
+func Unmarshal(data []byte, v any, format Format) error {
+	if format == FormatJSON && !json.Valid(data) {
+		// The JSON decoder reports where the input is invalid.
+		return json.Unmarshal(data, v)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("document is empty")
+	}
+	root := doc.Content[0]
+	bodyNodes(root, reflect.TypeOf(v), restoreBody)
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, root); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(buf.Bytes(), v)
+}
+
+// bodyNodes calls fn for every request body and response in a document decoded into
+// or encoded from a value of type t, with the key of the base64 encoded body and the
+// key of the textual one. Bodies are found by the Go types of the document, so maps
+// that merely have the same keys, such as environment variables, are left alone.
+func bodyNodes(node *yaml.Node, t reflect.Type, fn func(node *yaml.Node, key, textKey string)) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		switch t {
+		case requestBodyType:
+			fn(node, "content", requestTextKey)
+		case responseType:
+			fn(node, "body", responseTextKey)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if field, ok := jsonField(t, node.Content[i].Value); ok {
+				bodyNodes(node.Content[i+1], field.Type, fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, child := range node.Content {
+			bodyNodes(child, t.Elem(), fn)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 1; i < len(node.Content); i += 2 {
+			bodyNodes(node.Content[i], t.Elem(), fn)
+		}
+	}
+}
+
+// jsonField returns the field of struct type t that the JSON key decodes into. Like
+// encoding/json it prefers an exact match of the name to a case-insensitive one.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var (
+		folded reflect.StructField
+		found  bool
+	)
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if field.Anonymous {
+				// The fields of embedded structs are visible fields of their own.
+				continue
+			}
+			name = field.Name
+		}
+
+		if name == key {
+			return field, true
+		}
+		if !found && strings.EqualFold(name, key) {
+			folded, found = field, true
+		}
+	}
+
+	return folded, found
+}
+
+// replaceBody replaces a base64 encoded body that holds text with a string.
+func replaceBody(node *yaml.Node, key, textKey string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		if keyNode.Value != key || value.Kind != yaml.ScalarNode || value.ShortTag() != "!!str" {
+			continue
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(value.Value)
+		if err != nil || !isText(decoded) {
+			return
+		}
+
+		keyNode.Value = textKey
+		value.Value = string(decoded)
+		return
+	}
+}
+
+// restoreBody turns a textual body back into the base64 form of a []byte field.
+func restoreBody(node *yaml.Node, key, textKey string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		if keyNode.Value != textKey || value.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		keyNode.Value = key
+		if value.ShortTag() != "!!null" {
+			value.Value = base64.StdEncoding.EncodeToString([]byte(value.Value))
+			value.Tag = "!!str"
+		}
+		return
+	}
+}
+
+// isText reports whether a body can be stored as a string without losing bytes.
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// plainStyle drops the JSON styles of a parsed document so that it is written as
+// block YAML. Multi-line strings are written as literal blocks.
+func plainStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" && strings.Contains(node.Value, "\n") &&
+		!strings.Contains(node.Value, "\r") && strings.TrimRight(node.Value, " \t") == node.Value {
+		node.Style = yaml.LiteralStyle
+	}
+	for _, child := range node.Content {
+		plainStyle(child)
+	}
+}
+
+// writeJSON writes a document as JSON keeping the order of mapping keys.
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	var value any
+	switch node.ShortTag() {
+	case "!!str", "!!timestamp", "!!binary":
+		value = node.Value
+	default:
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	buf.Write(data)
+	return nil
+}
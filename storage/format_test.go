@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+	"github.com/KonnorFrik/getman/types"
+)
+
+type testDocument struct {
+	Name     string               `json:"name"`
+	Count    int                  `json:"count"`
+	Enabled  bool                 `json:"enabled"`
+	Vars     map[string]string    `json:"vars"`
+	Bodies   []*types.RequestBody `json:"bodies"`
+	Response *types.Response      `json:"response"`
+}
+
+func newTestDocument() *testDocument {
+	return &testDocument{
+		Name:    "api",
+		Count:   3,
+		Enabled: true,
+		Vars:    map[string]string{"b": "true", "a": "1"},
+		Bodies: []*types.RequestBody{
+			{Type: "json", Content: []byte("{\n  \"name\": \"Ann\"\n}")},
+			{Type: "raw", Content: []byte{0x00, 0xff, 0x10}},
+		},
+		Response: &types.Response{StatusCode: 200, Status: "200 OK", Body: []byte("ok")},
+	}
+}
+
+func TestUnitFormatForPath(t *testing.T) {
+	tests := map[string]Format{
+		"api.json":  FormatJSON,
+		"api.yaml":  FormatYAML,
+		"api.YML":   FormatYAML,
+		"api":       FormatJSON,
+		"dir/a.txt": FormatJSON,
+	}
+
+	for path, expected := range tests {
+		if format := FormatForPath(path); format != expected {
+			t.Errorf("%s: expected %s, got %s", path, expected, format)
+		}
+	}
+}
+
+func TestUnitTrimExtension(t *testing.T) {
+	tests := []struct {
+		fileName string
+		name     string
+		ok       bool
+	}{
+		{"api.json", "api", true},
+		{"api.yaml", "api", true},
+		{"my.api.yml", "my.api", true},
+		{"api.txt", "", false},
+		{".json", "", false},
+	}
+
+	for _, tt := range tests {
+		name, ok := TrimExtension(tt.fileName)
+		if name != tt.name || ok != tt.ok {
+			t.Errorf("%s: expected %q %v, got %q %v", tt.fileName, tt.name, tt.ok, name, ok)
+		}
+	}
+}
+
+func TestUnitMarshal_YAML(t *testing.T) {
+	data, err := Marshal(newTestDocument(), FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `name: api
+count: 3
+enabled: true
+vars:
+  a: "1"
+  b: "true"
+bodies:
+  - type: json
+    text: |-
+      {
+        "name": "Ann"
+      }
+  - type: raw
+    content: AP8Q
+response:
+  status_code: 200
+  status: 200 OK
+  headers: null
+  body_text: ok
+  duration: 0
+  size: 0
+`
+	if string(data) != expected {
+		t.Errorf("unexpected YAML:\n%s", data)
+	}
+}
+
+func TestUnitMarshal_JSON(t *testing.T) {
+	data, err := Marshal(newTestDocument(), FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"text": "{\n  \"name\": \"Ann\"\n}"`) {
+		t.Errorf("expected textual body to be a string, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), `"content": "AP8Q"`) {
+		t.Errorf("expected binary body to stay base64 encoded, got:\n%s", data)
+	}
+	if strings.Index(string(data), `"name"`) > strings.Index(string(data), `"count"`) {
+		t.Errorf("expected field order to be kept, got:\n%s", data)
+	}
+}
+
+func TestUnitMarshal_WireJSONUnchanged(t *testing.T) {
+	doc := newTestDocument()
+
+	if _, err := Marshal(doc, FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(data), `"text"`) || strings.Contains(string(data), `"body_text"`) {
+		t.Errorf("expected the JSON encoding of the types to keep base64 bodies, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), `"body":"b2s="`) {
+		t.Errorf("expected a base64 response body, got:\n%s", data)
+	}
+}
+
+func TestUnitMarshalUnmarshal_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			doc := newTestDocument()
+
+			data, err := Marshal(doc, format)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			var decoded testDocument
+			if err := Unmarshal(data, &decoded, format); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if !reflect.DeepEqual(doc, &decoded) {
+				t.Errorf("expected %+v, got %+v", doc, &decoded)
+			}
+		})
+	}
+}
+
+func TestUnitMarshalUnmarshal_MapsLikeBodies(t *testing.T) {
+	doc := map[string]map[string]string{
+		"variables": {"type": "admin", "text": "hello"},
+		"headers":   {"Type": "x", "status_code": "200", "body_text": "ok"},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := Marshal(doc, format)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			var decoded map[string]map[string]string
+			if err := Unmarshal(data, &decoded, format); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if !reflect.DeepEqual(doc, decoded) {
+				t.Errorf("expected %v, got %v", doc, decoded)
+			}
+		})
+	}
+}
+
+func TestUnitUnmarshal_Base64Bodies(t *testing.T) {
+	data := `{"name": "api", "bodies": [{"type": "json", "content": "eyJhIjoxfQ=="}], "response": {"status_code": 204, "body": null}}`
+
+	var decoded testDocument
+	if err := Unmarshal([]byte(data), &decoded, FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(decoded.Bodies[0].Content) != `{"a":1}` {
+		t.Errorf("unexpected body: %s", decoded.Bodies[0].Content)
+	}
+	if decoded.Response.Body != nil {
+		t.Errorf("expected nil response body, got %v", decoded.Response.Body)
+	}
+}
+
+func TestUnitUnmarshal_HandWrittenYAML(t *testing.T) {
+	data := `# edited by hand
+name: api
+count: 2
+vars:
+  date: 2025-01-01
+bodies:
+  - type: text
+    text: |
+      hello
+      world
+`
+
+	var decoded testDocument
+	if err := Unmarshal([]byte(data), &decoded, FormatYAML); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.Count != 2 || decoded.Vars["date"] != "2025-01-01" {
+		t.Errorf("unexpected document: %+v", decoded)
+	}
+	if string(decoded.Bodies[0].Content) != "hello\nworld\n" {
+		t.Errorf("unexpected body: %q", decoded.Bodies[0].Content)
+	}
+}
+
+func TestUnitUnmarshal_Invalid(t *testing.T) {
+	var decoded testDocument
+
+	if err := Unmarshal([]byte(`{"name": `), &decoded, FormatJSON); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if err := Unmarshal([]byte("name: [unclosed"), &decoded, FormatYAML); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+	if err := Unmarshal([]byte(""), &decoded, FormatYAML); err == nil {
+		t.Error("expected error for empty document")
+	}
+}
+
+func TestUnitFindFileAndListNames(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	for _, name := range []string{"a.json", "b.yaml", "c.yml", "c.json", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	if path := FindFile(dir, "b"); path != filepath.Join(dir, "b.yaml") {
+		t.Errorf("expected YAML file, got %s", path)
+	}
+	if path := FindFile(dir, "c"); path != filepath.Join(dir, "c.json") {
+		t.Errorf("expected JSON file first, got %s", path)
+	}
+	if path := FindFile(dir, "new"); path != filepath.Join(dir, "new.json") {
+		t.Errorf("expected new JSON file, got %s", path)
+	}
+
+	names, err := ListNames(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
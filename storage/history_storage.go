@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/KonnorFrik/getman/errors"
@@ -28,27 +27,28 @@ func NewHistoryStorage(fileStorage *FileStorage) *HistoryStorage {
 	}
 }
 
-// Save saves an execution result to a timestamped JSON file. If the file exists, it will be overwritten.
+// Save saves an execution result to a timestamped JSON file. Results saved at the
+// same time get distinct IDs, and the file is written atomically.
 func (hs *HistoryStorage) Save(result *types.ExecutionResult) error {
-	timestamp := FormatTimestamp(time.Now())
-	filename := fmt.Sprintf("%s.json", timestamp)
-
-	dir := hs.fileStorage.HistoryDir()
-	filePath := filepath.Join(dir, filename)
 	data, err := json.MarshalIndent(result, "", "  ")
 
 	if err != nil {
 		return fmt.Errorf("failed to marshal execution result: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	filePath, err := reserveEntry(hs.fileStorage.HistoryDir(), time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: failed to create history file: %w", errors.ErrStorageError, err)
+	}
+
+	if err := WriteFileAtomic(filePath, data, 0644); err != nil {
 		return fmt.Errorf("%w: failed to write history file: %w", errors.ErrStorageError, err)
 	}
 
 	return nil
 }
 
-// Load loads an execution result by timestamp.
+// Load loads an execution result by its ID.
 func (hs *HistoryStorage) Load(timestamp string) (*types.ExecutionResult, error) {
 	filename := fmt.Sprintf("%s.json", timestamp)
 	filePath := filepath.Join(hs.fileStorage.HistoryDir(), filename)
@@ -66,35 +66,34 @@ func (hs *HistoryStorage) Load(timestamp string) (*types.ExecutionResult, error)
 	return &result, nil
 }
 
-// List returns all available history timestamps, sorted in reverse chronological order.
+// List returns all available history IDs, sorted in reverse chronological order.
 func (hs *HistoryStorage) List() ([]string, error) {
-	dir := hs.fileStorage.HistoryDir()
-	entries, err := os.ReadDir(dir)
+	timestamps, err := listEntries(hs.fileStorage.HistoryDir())
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to read history directory: %w", err)
 	}
 
-	var timestamps []string
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
+	return timestamps, nil
+}
 
-		name := entry.Name()
+// Size returns the size in bytes of a history entry.
+func (hs *HistoryStorage) Size(timestamp string) (int64, error) {
+	info, err := os.Stat(filepath.Join(hs.fileStorage.HistoryDir(), timestamp+".json"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat history file: %w", err)
+	}
 
-		if len(name) > 5 && name[len(name)-5:] == ".json" {
-			timestamp := name[:len(name)-5]
+	return info.Size(), nil
+}
 
-			if _, err := ParseTimestamp(timestamp); err == nil {
-				timestamps = append(timestamps, timestamp)
-			}
-		}
+// Delete removes a history entry by its ID.
+func (hs *HistoryStorage) Delete(timestamp string) error {
+	if err := os.Remove(filepath.Join(hs.fileStorage.HistoryDir(), timestamp+".json")); err != nil {
+		return fmt.Errorf("failed to remove history file: %w", err)
 	}
 
-	sort.Sort(sort.Reverse(sort.StringSlice(timestamps)))
-	return timestamps, nil
+	return nil
 }
 
 // GetLast returns the most recent execution result.
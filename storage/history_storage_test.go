@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -498,3 +499,82 @@ func TestUnitList_IgnoreInvalidFiles(t *testing.T) {
 		t.Errorf("expected empty list (invalid files should be ignored), got %d items", len(list))
 	}
 }
+
+func TestUnitHistoryStorage_Save_NoCollisions(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	fs, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hs := NewHistoryStorage(fs)
+
+	const count = 30
+	for i := 0; i < count; i++ {
+		if err := hs.Save(&types.ExecutionResult{CollectionName: fmt.Sprintf("run %d", i)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	ids, err := hs.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != count {
+		t.Fatalf("expected %d entries, got %d", count, len(ids))
+	}
+
+	last, err := hs.Load(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last.CollectionName != fmt.Sprintf("run %d", count-1) {
+		t.Errorf("expected most recent run first, got %s", last.CollectionName)
+	}
+}
+
+func TestUnitHistoryStorage_SizeAndDelete(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	fs, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hs := NewHistoryStorage(fs)
+	if err := hs.Save(&types.ExecutionResult{CollectionName: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := hs.List()
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("expected 1 entry, got %v (%v)", ids, err)
+	}
+
+	size, err := hs.Size(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size == 0 {
+		t.Error("expected non-zero size")
+	}
+
+	if err := hs.Delete(ids[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := hs.Load(ids[0]); err == nil {
+		t.Error("expected error for deleted entry")
+	}
+	if err := hs.Delete(ids[0]); err == nil {
+		t.Error("expected error for deleting a missing entry")
+	}
+}
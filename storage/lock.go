@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+
+*/
+package storage
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// FileLock is an exclusive advisory lock shared by all processes that lock the same
+// path. It only guards code that takes the lock too; other writers are not blocked.
+type FileLock struct {
+	file *os.File
+}
+
+// LockFile takes the lock for path, waiting while another process or goroutine holds
+// it. The lock is held on a separate path+".lock" file, so that the file at path can
+// still be replaced with WriteFileAtomic.
+func LockFile(path string) (*FileLock, error) {
+	file, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return &FileLock{file: file}, nil
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	if err := unlockFile(l.file); err != nil {
+		l.file.Close()
+		return fmt.Errorf("failed to unlock: %w", err)
+	}
+
+	return l.file.Close()
+}
+
+// Remove releases the lock and deletes its lock file, for a path that is deleted.
+func (l *FileLock) Remove() error {
+	if err := l.Unlock(); err != nil {
+		return err
+	}
+
+	if err := os.Remove(l.file.Name()); err != nil && !stderrors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+
+	return nil
+}
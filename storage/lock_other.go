@@ -0,0 +1,25 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+
+*/
+package storage
+
+import (
+	"os"
+	"sync"
+)
+
+// Platforms without flock fall back to a lock that only works within the process.
+var processLock sync.Mutex
+
+func lockFile(file *os.File) error {
+	processLock.Lock()
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	processLock.Unlock()
+	return nil
+}
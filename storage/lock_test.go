@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/KonnorFrik/getman/testutil/helper"
+)
+
+func TestUnitLockFile_SerializesReadModifyWrite(t *testing.T) {
+	dir, err := helper.CreateTempDir()
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer helper.CleanupTempDir(dir)
+
+	counterPath := filepath.Join(dir, "counter")
+	if err := os.WriteFile(counterPath, []byte("0"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lock, err := LockFile(counterPath)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer lock.Unlock()
+
+			data, err := os.ReadFile(counterPath)
+			if err != nil {
+				errs <- err
+				return
+			}
+			count, _ := strconv.Atoi(string(data))
+			errs <- WriteFileAtomic(counterPath, []byte(strconv.Itoa(count+1)), 0644)
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(counterPath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(data) != strconv.Itoa(workers) {
+		t.Errorf("expected %d, got %s", workers, data)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/KonnorFrik/getman/types"
@@ -27,25 +26,26 @@ func NewLogStorage(fileStorage *FileStorage) *LogStorage {
 	}
 }
 
-// Save saves log entries to a timestamped JSON file.
+// Save saves log entries to a timestamped JSON file, written atomically.
 func (ls *LogStorage) Save(logs []types.LogEntry) error {
-	timestamp := FormatTimestamp(time.Now())
-	filename := fmt.Sprintf("%s.json", timestamp)
-	filePath := filepath.Join(ls.fileStorage.LogsDir(), filename)
-
 	data, err := json.MarshalIndent(logs, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal logs: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	filePath, err := reserveEntry(ls.fileStorage.LogsDir(), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+
+	if err := WriteFileAtomic(filePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write log file: %w", err)
 	}
 
 	return nil
 }
 
-// Load loads log entries by timestamp.
+// Load loads log entries by their ID.
 func (ls *LogStorage) Load(timestamp string) ([]types.LogEntry, error) {
 	filename := fmt.Sprintf("%s.json", timestamp)
 	filePath := filepath.Join(ls.fileStorage.LogsDir(), filename)
@@ -63,35 +63,27 @@ func (ls *LogStorage) Load(timestamp string) ([]types.LogEntry, error) {
 	return logs, nil
 }
 
-// GetLast returns the most recent log entries as JSON bytes.
-func (ls *LogStorage) GetLast() ([]byte, error) {
-	dir := ls.fileStorage.LogsDir()
-	entries, err := os.ReadDir(dir)
+// List returns all available log IDs, sorted in reverse chronological order.
+func (ls *LogStorage) List() ([]string, error) {
+	timestamps, err := listEntries(ls.fileStorage.LogsDir())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read logs directory: %w", err)
 	}
 
-	var timestamps []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		name := entry.Name()
-		if len(name) > 5 && name[len(name)-5:] == ".json" {
-			timestamp := name[:len(name)-5]
-			if _, err := ParseTimestamp(timestamp); err == nil {
-				timestamps = append(timestamps, timestamp)
-			}
-		}
+	return timestamps, nil
+}
+
+// GetLast returns the most recent log entries as JSON bytes.
+func (ls *LogStorage) GetLast() ([]byte, error) {
+	timestamps, err := ls.List()
+	if err != nil {
+		return nil, err
 	}
 
 	if len(timestamps) == 0 {
 		return nil, fmt.Errorf("no log files found")
 	}
 
-	sort.Sort(sort.Reverse(sort.StringSlice(timestamps)))
-
 	logs, err := ls.Load(timestamps[0])
 	if err != nil {
 		return nil, err
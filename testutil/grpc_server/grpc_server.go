@@ -0,0 +1,222 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+
+*/
+package grpc_server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// ServiceName is the full name of the test service.
+	ServiceName = "getman.test.Greeter"
+	// SayHelloMethod greets the name of the request. It replies with
+	// the x-greeter header, the x-trailer trailer and echoes the
+	// authorization metadata in the reply.
+	SayHelloMethod = "/" + ServiceName + "/SayHello"
+	// FailMethod always fails with the NOT_FOUND status.
+	FailMethod = "/" + ServiceName + "/Fail"
+)
+
+// GRPCServer is a local gRPC server with the Greeter test service and server reflection.
+// The service is defined through descriptors, so no generated code is needed.
+type GRPCServer struct {
+	server   *grpc.Server
+	listener net.Listener
+	file     protoreflect.FileDescriptor
+}
+
+// StartGRPCServer starts the test gRPC server. With reflection disabled the
+// service can only be called with the descriptor set from DescriptorSet.
+func StartGRPCServer(withReflection bool) (*GRPCServer, error) {
+	file, err := greeterFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptors: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create listener: %w", err)
+	}
+
+	gs := &GRPCServer{
+		server:   grpc.NewServer(),
+		listener: listener,
+		file:     file,
+	}
+
+	gs.server.RegisterService(gs.serviceDesc(), struct{}{})
+
+	if withReflection {
+		files := new(protoregistry.Files)
+		if err := files.RegisterFile(file); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to register descriptors: %w", err)
+		}
+
+		reflectionpb.RegisterServerReflectionServer(gs.server, reflection.NewServerV1(reflection.ServerOptions{
+			Services:           gs.server,
+			DescriptorResolver: files,
+		}))
+	}
+
+	go gs.server.Serve(listener)
+
+	return gs, nil
+}
+
+// Address returns the host:port the server listens on.
+func (gs *GRPCServer) Address() string {
+	return gs.listener.Addr().String()
+}
+
+// URL returns the getman URL of a method of the server, e.g. URL(SayHelloMethod).
+func (gs *GRPCServer) URL(method string) string {
+	return "grpc://" + gs.Address() + method
+}
+
+// DescriptorSet returns the serialized FileDescriptorSet of the test service.
+func (gs *GRPCServer) DescriptorSet() ([]byte, error) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(gs.file)},
+	}
+
+	return proto.Marshal(set)
+}
+
+func (gs *GRPCServer) Close() {
+	gs.server.Stop()
+}
+
+func (gs *GRPCServer) serviceDesc() *grpc.ServiceDesc {
+	service := gs.file.Services().ByName("Greeter")
+	input := service.Methods().ByName("SayHello").Input()
+	output := service.Methods().ByName("SayHello").Output()
+
+	return &grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "SayHello",
+				Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+					req := dynamicpb.NewMessage(input)
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+
+					name := req.Get(input.Fields().ByName("name")).String()
+					count := req.Get(input.Fields().ByName("count")).Int()
+					if name == "" {
+						return nil, status.Error(codes.InvalidArgument, "name is required")
+					}
+
+					grpc.SetHeader(ctx, metadata.Pairs("x-greeter", "test"))
+					grpc.SetTrailer(ctx, metadata.Pairs("x-trailer", "done"))
+
+					reply := dynamicpb.NewMessage(output)
+					reply.Set(output.Fields().ByName("message"), protoreflect.ValueOfString("Hello, "+name))
+
+					tags := reply.Mutable(output.Fields().ByName("tags")).List()
+					for i := int64(0); i < count; i++ {
+						tags.Append(protoreflect.ValueOfString(fmt.Sprintf("tag%d", i+1)))
+					}
+
+					if md, ok := metadata.FromIncomingContext(ctx); ok {
+						reply.Set(output.Fields().ByName("authorization"), protoreflect.ValueOfString(strings.Join(md.Get("authorization"), ",")))
+					}
+
+					return reply, nil
+				},
+			},
+			{
+				MethodName: "Fail",
+				Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+					req := dynamicpb.NewMessage(input)
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+
+					grpc.SetTrailer(ctx, metadata.Pairs("x-trailer", "failed"))
+					return nil, status.Error(codes.NotFound, "no such greeting")
+				},
+			},
+		},
+	}
+}
+
+func greeterFile() (protoreflect.FileDescriptor, error) {
+	field := func(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     fieldType.Enum(),
+			Label:    label.Enum(),
+		}
+	}
+
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	int32Type := descriptorpb.FieldDescriptorProto_TYPE_INT32
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("getman/test/greeter.proto"),
+		Package: proto.String("getman.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("HelloRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, stringType, optional),
+					field("count", 2, int32Type, optional),
+				},
+			},
+			{
+				Name: proto.String("HelloReply"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("message", 1, stringType, optional),
+					field("tags", 2, stringType, repeated),
+					field("authorization", 3, stringType, optional),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("Greeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("SayHello"),
+						InputType:  proto.String(".getman.test.HelloRequest"),
+						OutputType: proto.String(".getman.test.HelloReply"),
+					},
+					{
+						Name:       proto.String("Fail"),
+						InputType:  proto.String(".getman.test.HelloRequest"),
+						OutputType: proto.String(".getman.test.HelloReply"),
+					},
+				},
+			},
+		},
+	}
+
+	return protodesc.NewFile(file, new(protoregistry.Files))
+}
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 var (
@@ -50,6 +52,9 @@ func StartTestServer() (*TestServer, error) {
 	mux.HandleFunc("/cookies", handleCookies)
 	mux.HandleFunc("/body", handleBody)
 	mux.HandleFunc("/delay/", handleDelay)
+	mux.HandleFunc("/sse", handleSSE)
+	mux.HandleFunc("/ws/echo", handleWebSocketEcho)
+	mux.HandleFunc("/graphql", handleGraphQL)
 
 	var err error
 	listener, err = net.Listen("tcp", ":0")
@@ -270,3 +275,127 @@ func handleDelay(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Delayed response"))
 }
+
+// handleSSE streams "tick" events followed by a final "done" event.
+// Query parameters: count (default 3), interval in milliseconds (default 10)
+// and hold=1 to keep the connection open after the last event.
+func handleSSE(w http.ResponseWriter, r *http.Request) {
+	count := 3
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil {
+		count = v
+	}
+
+	interval := 10 * time.Millisecond
+	if v, err := strconv.Atoi(r.URL.Query().Get("interval")); err == nil {
+		interval = time.Duration(v) * time.Millisecond
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": stream start\nretry: 1000\n\n")
+	flusher.Flush()
+
+	for i := 1; i <= count; i++ {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(interval):
+		}
+
+		event := "tick"
+		if i == count {
+			event = "done"
+		}
+
+		fmt.Fprintf(w, "id: %d\nevent: %s\ndata: {\"n\": %d}\n\n", i, event, i)
+		flusher.Flush()
+	}
+
+	if r.URL.Query().Get("hold") == "1" {
+		<-r.Context().Done()
+	}
+}
+
+// handleGraphQL answers GraphQL POST requests with the received operation name and
+// variables under data.echo. Queries containing "fail" get an errors array with status 200.
+func handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.Contains(payload.Query, "fail") {
+		json.NewEncoder(w).Encode(map[string]any{
+			"data":   nil,
+			"errors": []map[string]any{{"message": "query failed"}},
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"data": map[string]any{
+			"echo": map[string]any{
+				"operationName": payload.OperationName,
+				"variables":     payload.Variables,
+			},
+		},
+	})
+}
+
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// handleWebSocketEcho sends every received message back with the same type.
+// With greet=1 it first sends a JSON greeting holding the Authorization header
+// and the query string of the handshake.
+func handleWebSocketEcho(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	if r.URL.Query().Get("greet") == "1" {
+		greeting := map[string]string{
+			"type":          "welcome",
+			"authorization": r.Header.Get("Authorization"),
+			"query":         r.URL.RawQuery,
+		}
+
+		if err := conn.WriteJSON(greeting); err != nil {
+			return
+		}
+	}
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if err := conn.WriteMessage(messageType, data); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,400 @@
+/*
+Copyright © 2025 Шелковский Сергей (Shelkovskiy Sergey) <konnor.frik666@gmail.com>
+
+*/
+package proxy_server
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProxyServer is a local HTTP forward proxy with CONNECT support.
+// It records every target it forwards traffic to.
+type ProxyServer struct {
+	server   *http.Server
+	listener net.Listener
+	url      string
+	username string
+	password string
+
+	mu      sync.Mutex
+	targets []string
+}
+
+// StartProxyServer starts an HTTP proxy. If username is not empty,
+// clients must send matching Proxy-Authorization credentials.
+func StartProxyServer(username, password string) (*ProxyServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create listener: %w", err)
+	}
+
+	ps := &ProxyServer{
+		listener: listener,
+		url:      "http://" + listener.Addr().String(),
+		username: username,
+		password: password,
+	}
+
+	ps.server = &http.Server{
+		Handler: http.HandlerFunc(ps.handle),
+	}
+
+	go func() {
+		if err := ps.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			panic(fmt.Sprintf("failed to start proxy server: %v", err))
+		}
+	}()
+
+	return ps, nil
+}
+
+func (ps *ProxyServer) URL() string {
+	return ps.url
+}
+
+// Targets returns the hosts (or absolute URLs for plain HTTP) that went through the proxy.
+func (ps *ProxyServer) Targets() []string {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return append([]string(nil), ps.targets...)
+}
+
+func (ps *ProxyServer) Close() error {
+	return ps.server.Close()
+}
+
+func (ps *ProxyServer) record(target string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.targets = append(ps.targets, target)
+}
+
+func (ps *ProxyServer) authorized(r *http.Request) bool {
+	if ps.username == "" {
+		return true
+	}
+
+	header := r.Header.Get("Proxy-Authorization")
+	if !strings.HasPrefix(header, "Basic ") {
+		return false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic "))
+	if err != nil {
+		return false
+	}
+
+	return string(decoded) == ps.username+":"+ps.password
+}
+
+func (ps *ProxyServer) handle(w http.ResponseWriter, r *http.Request) {
+	if !ps.authorized(r) {
+		w.Header().Set("Proxy-Authenticate", `Basic realm="proxy"`)
+		w.WriteHeader(http.StatusProxyAuthRequired)
+		return
+	}
+
+	if r.Method == http.MethodConnect {
+		ps.handleConnect(w, r)
+		return
+	}
+
+	ps.handleForward(w, r)
+}
+
+func (ps *ProxyServer) handleConnect(w http.ResponseWriter, r *http.Request) {
+	ps.record(r.Host)
+
+	upstream, err := net.DialTimeout("tcp", r.Host, 5*time.Second)
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+
+	pipe(conn, upstream)
+}
+
+func (ps *ProxyServer) handleForward(w http.ResponseWriter, r *http.Request) {
+	if !r.URL.IsAbs() {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ps.record(r.URL.String())
+
+	outReq, err := http.NewRequest(r.Method, r.URL.String(), r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	for k, v := range r.Header {
+		if k == "Proxy-Authorization" || k == "Proxy-Connection" {
+			continue
+		}
+		outReq.Header[k] = v
+	}
+
+	transport := &http.Transport{Proxy: nil}
+	defer transport.CloseIdleConnections()
+
+	resp, err := transport.RoundTrip(outReq)
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+
+	w.Header().Set("X-Proxied-By", "getman-test-proxy")
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// SOCKS5Server is a minimal local SOCKS5 proxy supporting the CONNECT command
+// with either no authentication or username/password authentication.
+type SOCKS5Server struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu      sync.Mutex
+	targets []string
+}
+
+// StartSOCKS5Server starts a SOCKS5 proxy. If username is not empty,
+// clients must authenticate with matching credentials.
+func StartSOCKS5Server(username, password string) (*SOCKS5Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create listener: %w", err)
+	}
+
+	s := &SOCKS5Server{
+		listener: listener,
+		username: username,
+		password: password,
+	}
+
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *SOCKS5Server) URL() string {
+	return "socks5://" + s.listener.Addr().String()
+}
+
+// Targets returns the host:port pairs that went through the proxy.
+func (s *SOCKS5Server) Targets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.targets...)
+}
+
+func (s *SOCKS5Server) Close() error {
+	return s.listener.Close()
+}
+
+func (s *SOCKS5Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+const (
+	socksVersion        = 0x05
+	socksMethodNoAuth   = 0x00
+	socksMethodPassword = 0x02
+	socksMethodNone     = 0xff
+	socksCmdConnect     = 0x01
+	socksAddrIPv4       = 0x01
+	socksAddrDomain     = 0x03
+	socksAddrIPv6       = 0x04
+)
+
+func (s *SOCKS5Server) handle(conn net.Conn) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil || header[0] != socksVersion {
+		conn.Close()
+		return
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		conn.Close()
+		return
+	}
+
+	wanted := byte(socksMethodNoAuth)
+	if s.username != "" {
+		wanted = socksMethodPassword
+	}
+
+	if !containsByte(methods, wanted) {
+		conn.Write([]byte{socksVersion, socksMethodNone})
+		conn.Close()
+		return
+	}
+
+	conn.Write([]byte{socksVersion, wanted})
+
+	if wanted == socksMethodPassword && !s.authenticate(conn) {
+		conn.Close()
+		return
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil || request[1] != socksCmdConnect {
+		conn.Close()
+		return
+	}
+
+	var host string
+
+	switch request[3] {
+	case socksAddrIPv4:
+		addr := make([]byte, net.IPv4len)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			conn.Close()
+			return
+		}
+		host = net.IP(addr).String()
+	case socksAddrIPv6:
+		addr := make([]byte, net.IPv6len)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			conn.Close()
+			return
+		}
+		host = net.IP(addr).String()
+	case socksAddrDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			conn.Close()
+			return
+		}
+		addr := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			conn.Close()
+			return
+		}
+		host = string(addr)
+	default:
+		conn.Close()
+		return
+	}
+
+	portBytes := make([]byte, 2)
+	if _, err := io.ReadFull(conn, portBytes); err != nil {
+		conn.Close()
+		return
+	}
+
+	target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(portBytes))))
+
+	s.mu.Lock()
+	s.targets = append(s.targets, target)
+	s.mu.Unlock()
+
+	upstream, err := net.DialTimeout("tcp", target, 5*time.Second)
+	if err != nil {
+		conn.Write([]byte{socksVersion, 0x05, 0x00, socksAddrIPv4, 0, 0, 0, 0, 0, 0})
+		conn.Close()
+		return
+	}
+
+	conn.Write([]byte{socksVersion, 0x00, 0x00, socksAddrIPv4, 0, 0, 0, 0, 0, 0})
+	pipe(conn, upstream)
+}
+
+func (s *SOCKS5Server) authenticate(conn net.Conn) bool {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return false
+	}
+
+	username := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, username); err != nil {
+		return false
+	}
+
+	length := make([]byte, 1)
+	if _, err := io.ReadFull(conn, length); err != nil {
+		return false
+	}
+
+	password := make([]byte, length[0])
+	if _, err := io.ReadFull(conn, password); err != nil {
+		return false
+	}
+
+	if string(username) != s.username || string(password) != s.password {
+		conn.Write([]byte{0x01, 0x01})
+		return false
+	}
+
+	conn.Write([]byte{0x01, 0x00})
+	return true
+}
+
+func containsByte(data []byte, b byte) bool {
+	for _, v := range data {
+		if v == b {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pipe(a, b net.Conn) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		io.Copy(a, b)
+		a.Close()
+	}()
+
+	go func() {
+		defer wg.Done()
+		io.Copy(b, a)
+		b.Close()
+	}()
+
+	wg.Wait()
+}
@@ -8,20 +8,38 @@ import "time"
 
 // Request represents an HTTP request.
 type Request struct {
-	Method  string            `json:"method"`
-	URL     string            `json:"url"`
-	Headers map[string]string `json:"headers,omitempty"`
-	Body    *RequestBody      `json:"body,omitempty"`
-	Auth    *Auth             `json:"auth,omitempty"`
-	Timeout *Timeout          `json:"timeout,omitempty"`
-	Cookies *CookieSettings   `json:"cookies,omitempty"`
+	Method       string                `json:"method"`
+	URL          string                `json:"url"`
+	Headers      map[string]string     `json:"headers,omitempty"`
+	Body         *RequestBody          `json:"body,omitempty"`
+	Auth         *Auth                 `json:"auth,omitempty"`
+	Timeout      *Timeout              `json:"timeout,omitempty"`
+	Cookies      *CookieSettings       `json:"cookies,omitempty"`
+	Proxy        *ProxySettings        `json:"proxy,omitempty"`
+	TLS          *TLSSettings          `json:"tls,omitempty"`
+	ResponseBody *ResponseBodySettings `json:"response_body,omitempty"`
+	Stream       *StreamSettings       `json:"stream,omitempty"`
+	WebSocket    *WebSocketSettings    `json:"websocket,omitempty"`
+	GRPC         *GRPCSettings         `json:"grpc,omitempty"`
 }
 
 // RequestBody represents the body of an HTTP request.
+// Bodies of type "graphql" hold the operation in GraphQL and are encoded when the request is sent.
 type RequestBody struct {
-	Type        string `json:"type"`
-	Content     []byte `json:"content"`
-	ContentType string `json:"content_type,omitempty"`
+	Type        string       `json:"type"`
+	Content     []byte       `json:"content"`
+	ContentType string       `json:"content_type,omitempty"`
+	GraphQL     *GraphQLBody `json:"graphql,omitempty"`
+}
+
+// GraphQLBody represents a GraphQL operation sent as a JSON POST body.
+// VariablesText holds variables that only become JSON once their {{variables}} are
+// resolved, such as {"id": {{userId}}}; it is parsed into Variables when resolved.
+type GraphQLBody struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	VariablesText string         `json:"variablesText,omitempty"`
 }
 
 // Auth represents authentication configuration for a request.
@@ -46,7 +64,98 @@ type CookieSettings struct {
 	AutoManage bool `json:"auto_manage"`
 }
 
+// ProxySettings represents proxy configuration for a request.
+// Supported URL schemes are http, https, socks5 and socks5h.
+// When URL is empty the proxy is taken from the HTTP_PROXY, HTTPS_PROXY
+// and NO_PROXY environment variables unless IgnoreEnvironment is set.
+type ProxySettings struct {
+	URL               string   `json:"url,omitempty"`
+	Username          string   `json:"username,omitempty"`
+	Password          string   `json:"password,omitempty"`
+	NoProxy           []string `json:"no_proxy,omitempty"`
+	IgnoreEnvironment bool     `json:"ignore_environment,omitempty"`
+	Disabled          bool     `json:"disabled,omitempty"`
+}
+
+// TLSSettings controls the TLS connection of a request. InsecureSkipVerify accepts
+// any server certificate, like curl --insecure.
+type TLSSettings struct {
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+}
+
+// ResponseBodySettings controls how the response body of a request is stored.
+// MaxSize limits the number of bytes kept in memory: zero means the client default,
+// a negative value no limit.
+// When OutputFile is set, the body is streamed to that file instead of memory.
+type ResponseBodySettings struct {
+	MaxSize    int64  `json:"max_size,omitempty"`
+	OutputFile string `json:"output_file,omitempty"`
+}
+
+// StreamSettings marks a request as a Server-Sent Events stream and controls when it is stopped.
+// The stream stops after MaxEvents events, after MaxDuration, or after the first event whose
+// type equals UntilEvent or whose data contains UntilData, whichever happens first.
+// Without MaxDuration the read timeout of the client limits the stream.
+type StreamSettings struct {
+	MaxEvents   int           `json:"max_events,omitempty"`
+	MaxDuration time.Duration `json:"max_duration,omitempty"`
+	UntilEvent  string        `json:"until_event,omitempty"`
+	UntilData   string        `json:"until_data,omitempty"`
+}
+
+// SSEEvent represents a single Server-Sent Event.
+type SSEEvent struct {
+	ID    string        `json:"id,omitempty"`
+	Event string        `json:"event"`
+	Data  string        `json:"data"`
+	Retry time.Duration `json:"retry,omitempty"`
+}
+
+// WebSocketSettings describes the scripted exchange of a WebSocket request.
+// A request is a WebSocket request when its URL uses the ws or wss scheme.
+// Timeout is the default time to wait for an expected reply.
+type WebSocketSettings struct {
+	Steps   []WebSocketStep `json:"steps,omitempty"`
+	Timeout time.Duration   `json:"timeout,omitempty"`
+}
+
+// WebSocketStep is a single step of a WebSocket script. A step sends Send (if set)
+// and then, if Expect is set or Receive is true, waits for a reply. A reply matches
+// when it contains Expect; with an empty Expect any reply matches.
+type WebSocketStep struct {
+	Send    string        `json:"send,omitempty"`
+	Binary  bool          `json:"binary,omitempty"`
+	Expect  string        `json:"expect,omitempty"`
+	Receive bool          `json:"receive,omitempty"`
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// Directions of messages in a WebSocket transcript.
+const (
+	WebSocketSent     = "sent"
+	WebSocketReceived = "received"
+)
+
+// WebSocketMessage is a single message of a WebSocket transcript.
+// Direction is either WebSocketSent or WebSocketReceived.
+type WebSocketMessage struct {
+	Direction string    `json:"direction"`
+	Binary    bool      `json:"binary,omitempty"`
+	Data      string    `json:"data"`
+	Time      time.Time `json:"time"`
+}
+
+// GRPCSettings configures a gRPC request. A request is a gRPC request when its URL
+// has the form grpc://host:port/package.Service/Method (grpcs for TLS); the body holds
+// the request message as JSON. DescriptorSet is the path of a FileDescriptorSet
+// describing the service; without it the service is discovered through server reflection.
+// The response GRPCCode holds the gRPC status code and Trailers the trailer metadata.
+type GRPCSettings struct {
+	DescriptorSet string `json:"descriptor_set,omitempty"`
+}
+
 // Response represents an HTTP response.
+// Responses of gRPC calls have no HTTP StatusCode; GRPCCode holds their gRPC status code.
 type Response struct {
 	StatusCode int                 `json:"status_code"`
 	Status     string              `json:"status"`
@@ -54,21 +163,84 @@ type Response struct {
 	Body       []byte              `json:"body"`
 	Duration   time.Duration       `json:"duration"`
 	Size       int64               `json:"size"`
+	Truncated  bool                `json:"truncated,omitempty"`
+	BodyFile   string              `json:"body_file,omitempty"`
+	Timings    *Timings            `json:"timings,omitempty"`
+	Trailers   map[string][]string `json:"trailers,omitempty"`
+	GRPCCode   *int                `json:"grpc_code,omitempty"`
+}
+
+// Timings contains the phase breakdown of a single HTTP request.
+// TimeToFirstByte is measured from the start of the request, so it includes
+// the DNS lookup, connect and TLS handshake phases.
+type Timings struct {
+	DNSLookup        time.Duration `json:"dns_lookup"`
+	TCPConnect       time.Duration `json:"tcp_connect"`
+	TLSHandshake     time.Duration `json:"tls_handshake"`
+	TimeToFirstByte  time.Duration `json:"time_to_first_byte"`
+	ContentTransfer  time.Duration `json:"content_transfer"`
+	Total            time.Duration `json:"total"`
+	ConnectionReused bool          `json:"connection_reused"`
 }
 
 // RequestItem represents a named request item in a collection.
+// Folder groups items of a collection, e.g. by API tag; it is empty for top-level items.
+// Examples holds saved example responses, e.g. captured in a HAR file.
 type RequestItem struct {
-	Name    string   `json:"name"`
-	Request *Request `json:"request"`
+	Name     string      `json:"name"`
+	Folder   string      `json:"folder,omitempty"`
+	Request  *Request    `json:"request"`
+	Examples []*Response `json:"examples,omitempty"`
 }
 
 // RequestExecution represents the result of executing a single request.
+// Request is the request as sent, Unresolved the request before its variables
+// were resolved, when it was known. Name is the name of the collection item, when
+// the request was executed as part of a collection.
 type RequestExecution struct {
-	Request   *Request      `json:"request"`
-	Response  *Response     `json:"response,omitempty"`
-	Error     string        `json:"error,omitempty"`
-	Duration  time.Duration `json:"duration"`
-	Timestamp time.Time     `json:"timestamp"`
+	Name       string              `json:"name,omitempty"`
+	Request    *Request            `json:"request"`
+	Unresolved *Request            `json:"unresolved,omitempty"`
+	Response   *Response           `json:"response,omitempty"`
+	Events     []*SSEEvent         `json:"events,omitempty"`
+	Messages   []*WebSocketMessage `json:"messages,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	Duration   time.Duration       `json:"duration"`
+	Timestamp  time.Time           `json:"timestamp"`
+	Snapshot   *SnapshotResult     `json:"snapshot,omitempty"`
+}
+
+// Snapshot statuses of SnapshotResult.
+const (
+	// SnapshotMatched means the response body matched the stored snapshot.
+	SnapshotMatched = "matched"
+	// SnapshotMismatched means the response body differed from the stored snapshot.
+	SnapshotMismatched = "mismatched"
+	// SnapshotCreated means no snapshot existed and the response body was recorded.
+	SnapshotCreated = "created"
+	// SnapshotUpdated means the stored snapshot was replaced by the response body.
+	SnapshotUpdated = "updated"
+	// SnapshotSkipped means the request failed, so its response was neither compared
+	// nor recorded.
+	SnapshotSkipped = "skipped"
+)
+
+// SnapshotResult is the outcome of comparing a response body with its stored snapshot.
+// A check that could not be done has an Error and no Status.
+type SnapshotResult struct {
+	Status     string             `json:"status"`
+	Path       string             `json:"path,omitempty"`
+	Mismatches []SnapshotMismatch `json:"mismatches,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// SnapshotMismatch is a difference between a response body and its snapshot.
+// Path is a JSON path such as "$.items[0].id", or "$" for bodies that are not JSON.
+type SnapshotMismatch struct {
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
 }
 
 // ExecutionResult represents the result of executing a collection of requests.
@@ -84,12 +256,23 @@ type ExecutionResult struct {
 
 // Statistics contains execution statistics for a collection run.
 type Statistics struct {
-	Total   int           `json:"total"`
-	Success int           `json:"success"`
-	Failed  int           `json:"failed"`
-	AvgTime time.Duration `json:"avg_time"`
-	MinTime time.Duration `json:"min_time"`
-	MaxTime time.Duration `json:"max_time"`
+	Total   int               `json:"total"`
+	Success int               `json:"success"`
+	Failed  int               `json:"failed"`
+	AvgTime time.Duration     `json:"avg_time"`
+	MinTime time.Duration     `json:"min_time"`
+	MaxTime time.Duration     `json:"max_time"`
+	Timings *TimingStatistics `json:"timings,omitempty"`
+}
+
+// TimingStatistics contains averaged request phase timings for a collection run.
+type TimingStatistics struct {
+	AvgDNSLookup       time.Duration `json:"avg_dns_lookup"`
+	AvgTCPConnect      time.Duration `json:"avg_tcp_connect"`
+	AvgTLSHandshake    time.Duration `json:"avg_tls_handshake"`
+	AvgTimeToFirstByte time.Duration `json:"avg_time_to_first_byte"`
+	AvgContentTransfer time.Duration `json:"avg_content_transfer"`
+	ReusedConnections  int           `json:"reused_connections"`
 }
 
 // LogEntry represents a single log entry.